@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/job"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/pagination"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/storage"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/tracing"
+	"github.com/umefy/go-web-app-template/internal/service"
+	dataImportSvc "github.com/umefy/go-web-app-template/internal/service/dataimport"
+	"go.uber.org/fx"
+)
+
+// import ingests a CSV file of users or orders the same way the upload endpoints do, but runs the
+// import in the foreground and can write the row-level error report to a local file.
+func main() {
+
+	var env string
+	var configPath string
+	var entity string
+	var filePath string
+	var reportPath string
+	flag.StringVar(&env, "env", "dev", "active environment. Available options: dev, test, prod.")
+	flag.StringVar(&configPath, "config", "", "config file path. If set, will ignore env option")
+	flag.StringVar(&entity, "entity", "users", "entity to import. Available options: users, orders.")
+	flag.StringVar(&filePath, "file", "", "path of the CSV file to import")
+	flag.StringVar(&reportPath, "report", "", "path to write the error report to. If not set, the report is only stored")
+	flag.Parse()
+
+	if filePath == "" {
+		log.Fatal("-file is required")
+	}
+
+	args := config.Options{
+		Env:        env,
+		ConfigPath: configPath,
+	}
+
+	var dataImportService dataImportSvc.Service
+	app := fx.New(
+		fx.Supply(args),
+		fx.Provide(func() context.Context {
+			return context.Background()
+		}),
+		config.Module,
+		database.Module,
+		logger.Module,
+		tracing.Module,
+		storage.Module,
+		job.Module,
+		pagination.Module,
+		service.Module,
+		fx.NopLogger,
+		fx.Populate(&dataImportService),
+	)
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		log.Fatalf("failed to start: %v", err)
+	}
+
+	err := runImport(ctx, dataImportService, dataImportDomain.Entity(entity), filePath, reportPath)
+
+	if stopErr := app.Stop(ctx); stopErr != nil {
+		log.Printf("failed to stop: %v", stopErr)
+	}
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+}
+
+func runImport(ctx context.Context, dataImportService dataImportSvc.Service, entity dataImportDomain.Entity, filePath string, reportPath string) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dataImport, err := dataImportService.RunImport(ctx, &dataImportSvc.DataImportCreateInput{
+		Entity: entity,
+		Source: file,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("import %d done: %d rows, %d imported, %d failed",
+		dataImport.ID, dataImport.TotalRows, dataImport.ImportedRows, dataImport.FailedRows)
+
+	if reportPath == "" {
+		return nil
+	}
+
+	return writeReport(ctx, dataImportService, dataImport.ID, reportPath)
+}
+
+func writeReport(ctx context.Context, dataImportService dataImportSvc.Service, importID int, reportPath string) error {
+	_, report, err := dataImportService.OpenImportReport(ctx, fmt.Sprint(importID))
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	out, err := os.Create(reportPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, report); err != nil {
+		out.Close() //nolint:errcheck
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	log.Printf("report written to %s", reportPath)
+	return nil
+}
@@ -4,18 +4,27 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 	"time"
 
 	"github.com/umefy/go-web-app-template/internal/core/config"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/health"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/job"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/metrics"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/pagination"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/server/grpc"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/server/http"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/storage"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/tracing"
 	"github.com/umefy/go-web-app-template/internal/service"
+	appHealth "github.com/umefy/go-web-app-template/pkg/health"
 	"github.com/umefy/go-web-app-template/pkg/server/grpcserver"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver"
 	"go.uber.org/fx"
+	"golang.org/x/sync/errgroup"
 )
 
 func main() {
@@ -33,6 +42,7 @@ func main() {
 		ConfigPath: configPath,
 	}
 
+	var cfg config.Config
 	app := fx.New(
 		fx.Supply(args),
 		fx.Provide(func() context.Context {
@@ -42,28 +52,80 @@ func main() {
 		database.Module,
 		logger.Module,
 		tracing.Module,
+		metrics.Module,
+		storage.Module,
+		job.Module,
+		pagination.Module,
+		ratelimit.Module,
+		health.Module,
 		http.Module,
 		grpc.Module,
 		service.Module,
+		fx.Populate(&cfg),
 		fx.Invoke(start),
 	)
 
-	app.Run()
+	os.Exit(run(app, cfg))
 }
 
-func start(ctx context.Context, lc fx.Lifecycle, httpServer *httpserver.Server, grpcServer *grpcserver.GrpcServer, cfg config.Config) {
-	lc.Append(fx.Hook{
+// run is fx.App.Run, with a stop timeout covering every step of the shutdown instead of the fx default.
+func run(app *fx.App, cfg config.Config) int {
+	startCtx, cancelStart := context.WithTimeout(context.Background(), app.StartTimeout())
+	defer cancelStart()
+	if err := app.Start(startCtx); err != nil {
+		log.Printf("Failed to start: %v", err)
+		return 1
+	}
+
+	signal := <-app.Wait()
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), stopTimeout(cfg))
+	defer cancelStop()
+	if err := app.Stop(stopCtx); err != nil {
+		log.Printf("Failed to stop: %v", err)
+		return 1
+	}
+
+	return signal.ExitCode
+}
+
+// stopTimeout adds up the drain delay, the slowest server and the background jobs, plus some time for the
+// remaining hooks like flushing traces.
+func stopTimeout(cfg config.Config) time.Duration {
+	shutdownCfg := cfg.GetShutdownConfig()
+	serversTimeout := max(cfg.GetHttpServerConfig().ShutdownTimeoutInSeconds, cfg.GetGrpcServerConfig().ShutdownTimeoutInSeconds)
+
+	return time.Duration(shutdownCfg.DrainDelayInSeconds+serversTimeout+shutdownCfg.JobsTimeoutInSeconds)*time.Second + 5*time.Second
+}
+
+type startParams struct {
+	fx.In
+
+	Lifecycle      fx.Lifecycle
+	Shutdowner     fx.Shutdowner
+	HttpServer     *httpserver.Server
+	GrpcServer     *grpcserver.GrpcServer
+	HealthRegistry *appHealth.Registry
+	Config         config.Config
+}
+
+func start(params startParams) {
+	cfg := params.Config
+
+	params.Lifecycle.Append(fx.Hook{
 		OnStart: func(ctx context.Context) error {
-			// Start servers in background goroutines without waiting
+			// Start servers in background goroutines without waiting, a server failing stops the app
 			go func() {
-				if err := startHttpServer(httpServer, cfg); err != nil {
+				if err := startHttpServer(params.HttpServer, cfg); err != nil {
 					log.Printf("HTTP server error: %v", err)
+					_ = params.Shutdowner.Shutdown(fx.ExitCode(1))
 				}
 			}()
 
 			go func() {
-				if err := startGrpcServer(grpcServer, cfg); err != nil {
+				if err := startGrpcServer(params.GrpcServer, cfg); err != nil {
 					log.Printf("gRPC server error: %v", err)
+					_ = params.Shutdowner.Shutdown(fx.ExitCode(1))
 				}
 			}()
 
@@ -71,21 +133,47 @@ func start(ctx context.Context, lc fx.Lifecycle, httpServer *httpserver.Server,
 		},
 		OnStop: func(ctx context.Context) error {
 			log.Println("Server stopped...")
-			if err := stopHttpServer(ctx, httpServer, cfg); err != nil {
-				log.Printf("HTTP server stop error: %v", err)
-				return err
-			}
-
-			if err := stopGrpcServer(ctx, grpcServer, cfg); err != nil {
-				log.Printf("gRPC server stop error: %v", err)
-				return err
-			}
+			// readiness fails from here on, load balancers stop routing to this instance during the drain delay
+			params.HealthRegistry.SetShuttingDown()
+			drain(ctx, time.Duration(cfg.GetShutdownConfig().DrainDelayInSeconds)*time.Second)
+
+			// both servers drain at the same time, the background jobs are waited for by their own hook afterwards
+			var g errgroup.Group
+			g.Go(func() error {
+				if err := stopHttpServer(ctx, params.HttpServer, cfg); err != nil {
+					log.Printf("HTTP server stop error: %v", err)
+					return err
+				}
+				return nil
+			})
+			g.Go(func() error {
+				if err := stopGrpcServer(ctx, params.GrpcServer, cfg); err != nil {
+					log.Printf("gRPC server stop error: %v", err)
+					return err
+				}
+				return nil
+			})
 
-			return nil
+			return g.Wait()
 		},
 	})
 }
 
+func drain(ctx context.Context, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+
+	log.Printf("Draining for %s before stopping the servers...", delay)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
+
 func startHttpServer(httpServer *httpserver.Server, cfg config.Config) error {
 	httpCfg := cfg.GetHttpServerConfig()
 	if !httpCfg.Enabled {
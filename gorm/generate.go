@@ -20,6 +20,9 @@ func getTablesToGenerate() []string {
 	return []string{
 		"users",
 		"orders",
+		"audit_logs",
+		"data_exports",
+		"data_imports",
 	}
 }
 
@@ -32,7 +35,7 @@ func main() {
 	})
 
 	g.WithDataTypeMap(getDataTypeMap())
-	g.WithImportPkgPath("github.com/guregu/null/v6", "gorm.io/plugin/optimisticlock") // specify the 3rd party library import path
+	g.WithImportPkgPath("github.com/guregu/null/v6", "gorm.io/plugin/optimisticlock", "time") // specify the 3rd party library import path
 
 	db, err := gorm.Open(postgres.Open(os.Getenv("DATABASE_URL")))
 
@@ -58,6 +61,9 @@ func generateModels(g *gen.Generator) {
 				gen.FieldType("id", "int"),
 				gen.FieldType("user_id", "int"),
 				gen.FieldType("version", "optimisticlock.Version"),
+				gen.FieldType("erased_at", "null.Value[time.Time]"),
+				gen.FieldType("completed_at", "null.Value[time.Time]"),
+				gen.FieldIgnore("search_vector"), // maintained by database triggers
 			),
 		)
 	}
@@ -19,6 +19,13 @@ type AppConfig struct {
 	DataBase   DbConfig         `mapstructure:"database"`
 	GrpcServer GrpcServerConfig `mapstructure:"grpc_server"`
 	Tracing    TracingConfig    `mapstructure:"tracing"`
+	Metrics    MetricsConfig    `mapstructure:"metrics"`
+	Storage    StorageConfig    `mapstructure:"storage"`
+	Pagination PaginationConfig `mapstructure:"pagination"`
+	RateLimit  RateLimitConfig  `mapstructure:"rate_limit"`
+	Health     HealthConfig     `mapstructure:"health"`
+	Shutdown   ShutdownConfig   `mapstructure:"shutdown"`
+	Auth       AuthConfig       `mapstructure:"auth"`
 }
 
 var _ validation.Validate = (*AppConfig)(nil)
@@ -32,5 +39,12 @@ func (a AppConfig) Validate() error {
 		validation.FieldStruct(&a.DataBase),
 		validation.FieldStruct(&a.GrpcServer),
 		validation.FieldStruct(&a.Tracing),
+		validation.FieldStruct(&a.Metrics),
+		validation.FieldStruct(&a.Storage),
+		validation.FieldStruct(&a.Pagination),
+		validation.FieldStruct(&a.RateLimit),
+		validation.FieldStruct(&a.Health),
+		validation.FieldStruct(&a.Shutdown),
+		validation.FieldStruct(&a.Auth),
 	)
 }
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"regexp"
+
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+// APIKeyConfig names the principal of the clients sending an API key. Only the hash of the key is configured.
+type APIKeyConfig struct {
+	Principal string
+	// Sha256 is the hex SHA-256 of the key, e.g. printf %s "$KEY" | sha256sum
+	Sha256 string `mapstructure:"sha256"`
+}
+
+var _ validation.Validate = (*APIKeyConfig)(nil)
+
+func (c APIKeyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Principal, validation.Required),
+		validation.Field(&c.Sha256, validation.Required, validation.Match(regexp.MustCompile("^[0-9a-f]{64}$")).Error("must be a lowercase hex SHA-256")),
+	)
+}
+
+type AuthConfig struct {
+	// APIKeyHeader is the HTTP header, and the gRPC metadata key, clients send their API key in. Requests with a
+	// key that is not in APIKeys are refused.
+	APIKeyHeader string         `mapstructure:"api_key_header"`
+	APIKeys      []APIKeyConfig `mapstructure:"api_keys"`
+}
+
+var _ validation.Validate = (*AuthConfig)(nil)
+
+func (c AuthConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.APIKeyHeader, validation.Required),
+		validation.Field(&c.APIKeys),
+	)
+}
+
+// PrincipalsByKeyHash indexes the principals of APIKeys by key hash.
+func (c AuthConfig) PrincipalsByKeyHash() map[string]string {
+	principals := make(map[string]string, len(c.APIKeys))
+	for _, key := range c.APIKeys {
+		principals[key.Sha256] = key.Principal
+	}
+	return principals
+}
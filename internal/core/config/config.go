@@ -8,6 +8,13 @@ type Config interface {
 	GetDBConfig() DbConfig
 	GetGrpcServerConfig() GrpcServerConfig
 	GetTracingConfig() TracingConfig
+	GetMetricsConfig() MetricsConfig
+	GetStorageConfig() StorageConfig
+	GetPaginationConfig() PaginationConfig
+	GetRateLimitConfig() RateLimitConfig
+	GetHealthConfig() HealthConfig
+	GetShutdownConfig() ShutdownConfig
+	GetAuthConfig() AuthConfig
 }
 
 type coreConfig struct {
@@ -47,3 +54,31 @@ func (c *coreConfig) GetGrpcServerConfig() GrpcServerConfig {
 func (c *coreConfig) GetTracingConfig() TracingConfig {
 	return c.appConfig.Tracing
 }
+
+func (c *coreConfig) GetMetricsConfig() MetricsConfig {
+	return c.appConfig.Metrics
+}
+
+func (c *coreConfig) GetStorageConfig() StorageConfig {
+	return c.appConfig.Storage
+}
+
+func (c *coreConfig) GetPaginationConfig() PaginationConfig {
+	return c.appConfig.Pagination
+}
+
+func (c *coreConfig) GetRateLimitConfig() RateLimitConfig {
+	return c.appConfig.RateLimit
+}
+
+func (c *coreConfig) GetHealthConfig() HealthConfig {
+	return c.appConfig.Health
+}
+
+func (c *coreConfig) GetShutdownConfig() ShutdownConfig {
+	return c.appConfig.Shutdown
+}
+
+func (c *coreConfig) GetAuthConfig() AuthConfig {
+	return c.appConfig.Auth
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var CORS_METHODS = []interface{}{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+type CorsConfig struct {
+	AllowedMethods []string `mapstructure:"allowed_methods"`
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	// ExposedHeaders are the response headers readable by browser scripts, besides the CORS safelisted ones
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`
+	AllowCredentials bool     `mapstructure:"allow_credentials"`
+	// MaxAgeInSeconds is how long browsers cache preflight responses, 0 leaves it to the browser
+	MaxAgeInSeconds int `mapstructure:"max_age_in_seconds"`
+	// Routes override the settings for the paths under their prefix
+	Routes []CorsRouteConfig `mapstructure:"routes"`
+}
+
+var _ validation.Validate = (*CorsConfig)(nil)
+
+func (c CorsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.AllowedMethods, validation.Each(validation.In(CORS_METHODS...))),
+		validation.Field(&c.MaxAgeInSeconds, validation.Min(0)),
+		validation.Field(&c.Routes),
+	)
+}
+
+// CorsRouteConfig overrides the CORS settings under PathPrefix. Settings left out are inherited.
+type CorsRouteConfig struct {
+	PathPrefix       string   `mapstructure:"path_prefix"`
+	AllowedOrigins   []string `mapstructure:"allowed_origins"`
+	AllowedMethods   []string `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string `mapstructure:"allowed_headers"`
+	ExposedHeaders   []string `mapstructure:"exposed_headers"`
+	AllowCredentials *bool    `mapstructure:"allow_credentials"`
+	MaxAgeInSeconds  *int     `mapstructure:"max_age_in_seconds"`
+}
+
+var _ validation.Validate = (*CorsRouteConfig)(nil)
+
+func (c CorsRouteConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.PathPrefix, validation.Required, validation.Match(regexp.MustCompile("^/")).Error("must start with /")),
+		validation.Field(&c.AllowedMethods, validation.Each(validation.In(CORS_METHODS...))),
+		validation.Field(&c.MaxAgeInSeconds, validation.When(c.MaxAgeInSeconds != nil, validation.Min(0))),
+	)
+}
+
+// CorsPolicy is the CORS settings in effect for a path.
+type CorsPolicy struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAgeInSeconds  int
+}
+
+// CorsPolicy returns the CORS settings for path, from the route with the longest matching prefix.
+func (s HttpServerConfig) CorsPolicy(path string) CorsPolicy {
+	policy := CorsPolicy{
+		AllowedOrigins:   s.AllowedOrigins,
+		AllowedMethods:   s.Cors.AllowedMethods,
+		AllowedHeaders:   s.Cors.AllowedHeaders,
+		ExposedHeaders:   s.Cors.ExposedHeaders,
+		AllowCredentials: s.Cors.AllowCredentials,
+		MaxAgeInSeconds:  s.Cors.MaxAgeInSeconds,
+	}
+
+	var route *CorsRouteConfig
+	for i, r := range s.Cors.Routes {
+		if middleware.HasPathPrefix(path, r.PathPrefix) && (route == nil || len(r.PathPrefix) > len(route.PathPrefix)) {
+			route = &s.Cors.Routes[i]
+		}
+	}
+	if route == nil {
+		return policy
+	}
+
+	if len(route.AllowedOrigins) > 0 {
+		policy.AllowedOrigins = route.AllowedOrigins
+	}
+	if len(route.AllowedMethods) > 0 {
+		policy.AllowedMethods = route.AllowedMethods
+	}
+	if len(route.AllowedHeaders) > 0 {
+		policy.AllowedHeaders = route.AllowedHeaders
+	}
+	if len(route.ExposedHeaders) > 0 {
+		policy.ExposedHeaders = route.ExposedHeaders
+	}
+	if route.AllowCredentials != nil {
+		policy.AllowCredentials = *route.AllowCredentials
+	}
+	if route.MaxAgeInSeconds != nil {
+		policy.MaxAgeInSeconds = *route.MaxAgeInSeconds
+	}
+	return policy
+}
+
+// validateCorsPolicies rejects credentials with the "*" origin, browsers refuse that combination.
+func (s HttpServerConfig) validateCorsPolicies(any) error {
+	paths := []string{"/"}
+	for _, route := range s.Cors.Routes {
+		paths = append(paths, route.PathPrefix)
+	}
+
+	for _, path := range paths {
+		policy := s.CorsPolicy(path)
+		if policy.AllowCredentials && slices.Contains(policy.AllowedOrigins, "*") {
+			return validation.NewError("validation_cors_credentials", "allow_credentials cannot be used with the \"*\" origin under "+path)
+		}
+	}
+	return nil
+}
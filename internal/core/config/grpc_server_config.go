@@ -5,9 +5,14 @@ import (
 )
 
 type GrpcServerConfig struct {
-	Enabled                  bool
-	Port                     int
-	ShutdownTimeoutInSeconds int `mapstructure:"shutdown_timeout_in_seconds"`
+	Enabled                      bool
+	Port                         int
+	ShutdownTimeoutInSeconds     int  `mapstructure:"shutdown_timeout_in_seconds"`
+	HealthCheck                  bool `mapstructure:"health_check"`
+	HealthCheckIntervalInSeconds int  `mapstructure:"health_check_interval_in_seconds"`
+	Reflection                   bool
+	Channelz                     bool
+	TLS                          TLSConfig `mapstructure:"tls"`
 }
 
 var _ validation.Validate = (*GrpcServerConfig)(nil)
@@ -17,5 +22,7 @@ func (c GrpcServerConfig) Validate() error {
 		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
 		validation.Field(&c.Port, validation.When(c.Enabled, validation.Required)),
 		validation.Field(&c.ShutdownTimeoutInSeconds, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.HealthCheckIntervalInSeconds, validation.When(c.Enabled && c.HealthCheck, validation.Required)),
+		validation.FieldStruct(&c.TLS),
 	)
 }
@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type HealthConfig struct {
+	CheckTimeoutInSeconds int `mapstructure:"check_timeout_in_seconds"`
+	// CacheTtlInSeconds is how long check results are reused, probes from every load balancer share them
+	CacheTtlInSeconds int `mapstructure:"cache_ttl_in_seconds"`
+	// MinFreeDiskBytes reports the storage as degraded below this much free space, 0 disables the check
+	MinFreeDiskBytes uint64 `mapstructure:"min_free_disk_bytes"`
+}
+
+var _ validation.Validate = (*HealthConfig)(nil)
+
+func (c HealthConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CheckTimeoutInSeconds, validation.Required),
+		validation.Field(&c.CacheTtlInSeconds, validation.Min(0)),
+	)
+}
@@ -1,17 +1,46 @@
 package config
 
 import (
+	"net/netip"
+
 	"github.com/umefy/go-web-app-template/pkg/validation"
 )
 
 type HttpServerConfig struct {
-	Enabled                  bool
-	Port                     int
-	ServerName               string   `mapstructure:"server_name"`
-	AllowedOrigins           []string `mapstructure:"allowed_origins"`
-	HealthCheckEndpoint      string   `mapstructure:"health_check_endpoint"`
-	ProfilerEndpoint         string   `mapstructure:"profiler_endpoint"`
-	ShutdownTimeoutInSeconds int      `mapstructure:"shutdown_timeout_in_seconds"`
+	Enabled    bool
+	Port       int
+	ServerName string `mapstructure:"server_name"`
+	// AllowedOrigins are the origins allowed by CORS and for WebSocket connections. "*" matches any origin and
+	// one "*" can replace part of an origin, e.g. "https://*.example.com"
+	AllowedOrigins []string   `mapstructure:"allowed_origins"`
+	Cors           CorsConfig `mapstructure:"cors"`
+	// LiveEndpoint, ReadyEndpoint and HealthEndpoint serve the liveness and readiness probes and the detailed
+	// report of the health checks
+	LiveEndpoint             string `mapstructure:"live_endpoint"`
+	ReadyEndpoint            string `mapstructure:"ready_endpoint"`
+	HealthEndpoint           string `mapstructure:"health_endpoint"`
+	ProfilerEndpoint         string `mapstructure:"profiler_endpoint"`
+	ShutdownTimeoutInSeconds int    `mapstructure:"shutdown_timeout_in_seconds"`
+	// RestGateway mounts the REST routes generated from the protos under /api/v2
+	RestGateway bool      `mapstructure:"rest_gateway"`
+	TLS         TLSConfig `mapstructure:"tls"`
+	// H2C serves HTTP/2 without TLS, for internal traffic behind a TLS terminating proxy
+	H2C bool `mapstructure:"h2c"`
+	// RedirectPort, when set, listens for plain HTTP and redirects everything to HTTPS
+	RedirectPort int `mapstructure:"redirect_port"`
+	// RequestTimeoutInSeconds bounds the handling of a request, routes can override it
+	RequestTimeoutInSeconds    int `mapstructure:"request_timeout_in_seconds"`
+	ReadHeaderTimeoutInSeconds int `mapstructure:"read_header_timeout_in_seconds"`
+	// ReadTimeoutInSeconds, WriteTimeoutInSeconds and IdleTimeoutInSeconds are disabled when 0
+	ReadTimeoutInSeconds  int `mapstructure:"read_timeout_in_seconds"`
+	WriteTimeoutInSeconds int `mapstructure:"write_timeout_in_seconds"`
+	IdleTimeoutInSeconds  int `mapstructure:"idle_timeout_in_seconds"`
+	MaxHeaderBytes        int `mapstructure:"max_header_bytes"`
+	// MaxBodyBytes limits request bodies, routes can override it
+	MaxBodyBytes    int64                 `mapstructure:"max_body_bytes"`
+	SecurityHeaders SecurityHeadersConfig `mapstructure:"security_headers"`
+	// TrustedProxies are the IPs or CIDRs of the proxies whose forwarding headers tell the client IP
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 var _ validation.Validate = (*HttpServerConfig)(nil)
@@ -22,9 +51,49 @@ func (s HttpServerConfig) Validate() error {
 		validation.Field(&s.Enabled, validation.In(true, false).Error("can only be set to true or false")),
 		validation.Field(&s.ServerName, validation.When(s.Enabled, validation.Required)),
 		validation.Field(&s.Port, validation.When(s.Enabled, validation.Required)),
-		validation.Field(&s.AllowedOrigins, validation.When(s.Enabled, validation.Required)),
-		validation.Field(&s.HealthCheckEndpoint, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.AllowedOrigins, validation.When(s.Enabled, validation.Required), validation.By(s.validateCorsPolicies)),
+		validation.FieldStruct(&s.Cors),
+		validation.Field(&s.LiveEndpoint, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.ReadyEndpoint, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.HealthEndpoint, validation.When(s.Enabled, validation.Required)),
 		validation.Field(&s.ProfilerEndpoint, validation.When(s.Enabled, validation.Required)),
 		validation.Field(&s.ShutdownTimeoutInSeconds, validation.When(s.Enabled, validation.Required)),
+		validation.FieldStruct(&s.TLS),
+		validation.Field(&s.RedirectPort, validation.When(!s.TLS.Enabled, validation.Empty.Error("requires tls to be enabled"))),
+		validation.Field(&s.RequestTimeoutInSeconds, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.ReadHeaderTimeoutInSeconds, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.ReadTimeoutInSeconds, validation.Min(0)),
+		validation.Field(&s.WriteTimeoutInSeconds, validation.Min(0)),
+		validation.Field(&s.IdleTimeoutInSeconds, validation.Min(0)),
+		validation.Field(&s.MaxHeaderBytes, validation.When(s.Enabled, validation.Required)),
+		validation.Field(&s.MaxBodyBytes, validation.When(s.Enabled, validation.Required)),
+		validation.FieldStruct(&s.SecurityHeaders),
+		validation.Field(&s.TrustedProxies, validation.Each(validation.By(validatePrefix))),
 	)
 }
+
+// TrustedProxyPrefixes parses TrustedProxies, single IPs become prefixes of their full length.
+func (s HttpServerConfig) TrustedProxyPrefixes() []netip.Prefix {
+	prefixes := make([]netip.Prefix, 0, len(s.TrustedProxies))
+	for _, proxy := range s.TrustedProxies {
+		if prefix, err := parsePrefix(proxy); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+func validatePrefix(value any) error {
+	if _, err := parsePrefix(value.(string)); err != nil {
+		return validation.NewError("validation_cidr", "must be an IP or a CIDR")
+	}
+	return nil
+}
+
+func parsePrefix(value string) (netip.Prefix, error) {
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(value)
+	return prefix.Masked(), err
+}
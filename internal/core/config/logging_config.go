@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/umefy/go-web-app-template/pkg/redact"
 	"github.com/umefy/go-web-app-template/pkg/validation"
 )
 
@@ -8,12 +9,45 @@ var LOG_LEVELS = []interface{}{"debug", "info", "warn", "error"}
 
 var LOG_WRITERS = []interface{}{"stdout"}
 
+var LOG_REDACT_MODES = []interface{}{"mask", "hash"}
+
 type LoggingConfig struct {
 	Level     string
 	Writer    string `mapstructure:"writer"`
 	UseJson   bool   `mapstructure:"use_json"`
 	AddSource bool   `mapstructure:"add_source"` // Add logging source file and line number
 	SourceKey string `mapstructure:"source_key"` // The source key field name in the log
+	// SpanEvents adds the error logs as events to the span of their context, next to the trace_id and span_id
+	// added to every log
+	SpanEvents bool `mapstructure:"span_events"`
+	// Redact applies to the application logs and to the SQL params of the database logs
+	Redact LogRedactConfig `mapstructure:"redact"`
+}
+
+// LogRedactConfig replaces the values logged under Keys, and the struct fields tagged `log:"redact"`, by a
+// mask or by a hash keyed with HashKey. Keys ignore case, "_" and "-" and can contain * wildcards.
+type LogRedactConfig struct {
+	Mode    string
+	HashKey string `mapstructure:"hash_key"`
+	Keys    []string
+}
+
+var _ validation.Validate = (*LogRedactConfig)(nil)
+
+func (c LogRedactConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Mode, validation.Required, validation.In(LOG_REDACT_MODES...).Error("can only be set to mask or hash")),
+		// unkeyed hashes of emails or tokens are easy to reverse with a list of common values
+		validation.Field(&c.HashKey, validation.When(c.Mode == "hash", validation.Required)),
+		validation.Field(&c.Keys, validation.Each(validation.Required, validation.By(validateRedactKey))),
+	)
+}
+
+func validateRedactKey(value any) error {
+	if err := redact.ValidPattern(value.(string)); err != nil {
+		return validation.NewError("validation_redact_key", "must be a key or a pattern with * wildcards")
+	}
+	return nil
 }
 
 var _ validation.Validate = (*LoggingConfig)(nil)
@@ -25,5 +59,7 @@ func (l LoggingConfig) Validate() error {
 		validation.Field(&l.UseJson, validation.In(true, false).Error("can only be set to true or false")),
 		validation.Field(&l.AddSource, validation.In(true, false).Error("can only be set to true or false")),
 		validation.Field(&l.SourceKey, validation.When(l.AddSource, validation.Required)),
+		validation.Field(&l.SpanEvents, validation.In(true, false).Error("can only be set to true or false")),
+		validation.FieldStruct(&l.Redact),
 	)
 }
@@ -0,0 +1,23 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type MetricsConfig struct {
+	Enabled bool
+	// Endpoint serves the Prometheus metrics, on the HTTP server or on Port when it is set
+	Endpoint string
+	// Port, when set, serves the metrics on a separate admin listener that is not exposed publicly
+	Port int
+}
+
+var _ validation.Validate = (*MetricsConfig)(nil)
+
+func (c MetricsConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
+		validation.Field(&c.Endpoint, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Port, validation.Min(0)),
+	)
+}
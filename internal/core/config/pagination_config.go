@@ -0,0 +1,17 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type PaginationConfig struct {
+	CursorSecret string `mapstructure:"cursor_secret"` // HMAC key signing pagination cursors
+}
+
+var _ validation.Validate = (*PaginationConfig)(nil)
+
+func (c PaginationConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CursorSecret, validation.Required, validation.Length(32, 0).Error("must be at least 32 characters")),
+	)
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"regexp"
+
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var (
+	RATE_LIMIT_STORES = []interface{}{"memory", "redis"}
+	RATE_LIMIT_KEYS   = []interface{}{"ip", "principal", "api_key", "route_group", "graphql_operation"}
+)
+
+type RateLimitRedisConfig struct {
+	Url       string `mapstructure:"url"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// RateLimitPolicyConfig allows Limit requests per window for each key, the key being made of the KeyBy parts:
+//   - ip: the client IP
+//   - principal: the authenticated principal, or else the verified API key, or else the client IP
+//   - api_key: the verified API key, or else the client IP
+//   - route_group: the prefix of Routes matching the request
+//   - graphql_operation: the GraphQL operation name
+//
+// Without KeyBy, all matching requests share one counter.
+type RateLimitPolicyConfig struct {
+	Name            string
+	Limit           int
+	WindowInSeconds int      `mapstructure:"window_in_seconds"`
+	KeyBy           []string `mapstructure:"key_by"`
+	// Routes restricts the policy to HTTP paths or gRPC methods under these prefixes, e.g. "/api/v1/users"
+	// or "/user.UserService"
+	Routes []string
+	// GraphqlOperations restricts the policy to these GraphQL operations, which are checked once parsed
+	GraphqlOperations []string `mapstructure:"graphql_operations"`
+}
+
+var _ validation.Validate = (*RateLimitPolicyConfig)(nil)
+
+func (c RateLimitPolicyConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Name, validation.Required),
+		validation.Field(&c.Limit, validation.Required, validation.Min(1)),
+		validation.Field(&c.WindowInSeconds, validation.Required, validation.Min(1)),
+		validation.Field(&c.KeyBy, validation.Each(validation.In(RATE_LIMIT_KEYS...))),
+		validation.Field(&c.Routes, validation.Each(validation.Match(regexp.MustCompile("^/")).Error("must start with /"))),
+	)
+}
+
+type RateLimitConfig struct {
+	Enabled bool
+	// Store is memory for counters per instance, or redis for counters shared by all instances
+	Store    string
+	Redis    RateLimitRedisConfig    `mapstructure:"redis"`
+	Policies []RateLimitPolicyConfig `mapstructure:"policies"`
+}
+
+var _ validation.Validate = (*RateLimitConfig)(nil)
+
+func (c RateLimitConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
+		validation.Field(&c.Store, validation.When(c.Enabled, validation.Required, validation.In(RATE_LIMIT_STORES...).Error("can only be set to memory or redis"))),
+		validation.Field(&c.Redis, validation.When(c.Enabled && c.Store == "redis", validation.By(func(any) error {
+			return validation.ValidateStruct(&c.Redis, validation.Field(&c.Redis.Url, validation.Required))
+		}))),
+		validation.Field(&c.Policies),
+	)
+}
+
+// MatchRoute returns the prefix of Routes that route is under. Policies without Routes match any route,
+// with an empty prefix.
+func (c RateLimitPolicyConfig) MatchRoute(route string) (string, bool) {
+	if len(c.Routes) == 0 {
+		return "", true
+	}
+
+	var match string
+	for _, prefix := range c.Routes {
+		if middleware.HasPathPrefix(route, prefix) && len(prefix) > len(match) {
+			match = prefix
+		}
+	}
+	return match, match != ""
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var (
+	SECURITY_FRAME_OPTIONS     = []interface{}{"DENY", "SAMEORIGIN"}
+	SECURITY_REFERRER_POLICIES = []interface{}{
+		"no-referrer",
+		"no-referrer-when-downgrade",
+		"origin",
+		"origin-when-cross-origin",
+		"same-origin",
+		"strict-origin",
+		"strict-origin-when-cross-origin",
+		"unsafe-url",
+	}
+)
+
+type SecurityHeadersConfig struct {
+	Enabled bool
+	// HSTSMaxAgeInSeconds sends Strict-Transport-Security when positive
+	HSTSMaxAgeInSeconds   int  `mapstructure:"hsts_max_age_in_seconds"`
+	HSTSIncludeSubdomains bool `mapstructure:"hsts_include_subdomains"`
+	HSTSPreload           bool `mapstructure:"hsts_preload"`
+	// FrameOptions is the X-Frame-Options value, DENY or SAMEORIGIN
+	FrameOptions      string `mapstructure:"frame_options"`
+	ReferrerPolicy    string `mapstructure:"referrer_policy"`
+	PermissionsPolicy string `mapstructure:"permissions_policy"`
+	// ContentSecurityPolicy maps directives to their sources, e.g. script-src: ["'self'"]. No policy is sent when empty.
+	ContentSecurityPolicy map[string][]string `mapstructure:"content_security_policy"`
+	// CSPNonceDirectives get a nonce per request, for pages with inline scripts and styles like the GraphQL playground
+	CSPNonceDirectives []string `mapstructure:"csp_nonce_directives"`
+	CSPReportOnly      bool     `mapstructure:"csp_report_only"`
+}
+
+var _ validation.Validate = (*SecurityHeadersConfig)(nil)
+
+func (c SecurityHeadersConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
+		validation.Field(&c.HSTSMaxAgeInSeconds, validation.Min(0)),
+		validation.Field(&c.HSTSPreload, validation.When(
+			c.HSTSMaxAgeInSeconds == 0 || !c.HSTSIncludeSubdomains,
+			validation.Empty.Error("requires hsts_max_age_in_seconds and hsts_include_subdomains"),
+		)),
+		validation.Field(&c.FrameOptions, validation.In(SECURITY_FRAME_OPTIONS...).Error("can only be set to DENY or SAMEORIGIN")),
+		validation.Field(&c.ReferrerPolicy, validation.In(SECURITY_REFERRER_POLICIES...)),
+		validation.Field(&c.CSPNonceDirectives, validation.When(len(c.ContentSecurityPolicy) == 0, validation.Empty.Error("requires content_security_policy"))),
+	)
+}
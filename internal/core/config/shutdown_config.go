@@ -0,0 +1,22 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type ShutdownConfig struct {
+	// DrainDelayInSeconds is how long readiness fails before the servers stop accepting connections, so that
+	// load balancers stop routing to the instance first
+	DrainDelayInSeconds int `mapstructure:"drain_delay_in_seconds"`
+	// JobsTimeoutInSeconds is how long background jobs get to finish once the servers stopped
+	JobsTimeoutInSeconds int `mapstructure:"jobs_timeout_in_seconds"`
+}
+
+var _ validation.Validate = (*ShutdownConfig)(nil)
+
+func (c ShutdownConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.DrainDelayInSeconds, validation.Min(0)),
+		validation.Field(&c.JobsTimeoutInSeconds, validation.Required),
+	)
+}
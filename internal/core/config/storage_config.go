@@ -0,0 +1,21 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var STORAGE_DRIVERS = []interface{}{"local"}
+
+type StorageConfig struct {
+	Driver   string
+	LocalDir string `mapstructure:"local_dir"` // base directory for the local driver
+}
+
+var _ validation.Validate = (*StorageConfig)(nil)
+
+func (c StorageConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Driver, validation.Required, validation.In(STORAGE_DRIVERS...).Error("can only be set to local")),
+		validation.Field(&c.LocalDir, validation.When(c.Driver == "local", validation.Required)),
+	)
+}
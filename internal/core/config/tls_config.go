@@ -0,0 +1,69 @@
+package config
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+// ClientPrincipalConfig names the principal of the clients presenting a certificate with the given subject.
+type ClientPrincipalConfig struct {
+	// Subject in the RFC 2253 form, e.g. "CN=billing,O=Acme"
+	Subject   string
+	Principal string
+}
+
+var _ validation.Validate = (*ClientPrincipalConfig)(nil)
+
+func (c ClientPrincipalConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Subject, validation.Required),
+		validation.Field(&c.Principal, validation.Required),
+	)
+}
+
+var (
+	TLS_MIN_VERSIONS    = []interface{}{"1.2", "1.3"}
+	TLS_CIPHER_POLICIES = []interface{}{"default", "strict"}
+)
+
+type TLSConfig struct {
+	Enabled    bool
+	CertFile   string `mapstructure:"cert_file"`
+	KeyFile    string `mapstructure:"key_file"`
+	MinVersion string `mapstructure:"min_version"`
+	// CipherPolicy picks the TLS 1.2 cipher suites: "default" keeps the Go defaults, "strict" only allows
+	// ECDHE key exchange with AEAD ciphers. TLS 1.3 suites are not configurable.
+	CipherPolicy string `mapstructure:"cipher_policy"`
+	// ClientCAFile turns on mutual TLS, client certificates must be signed by one of its CAs
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	// ClientPrincipals names the principals of known client certificates, other clients get their common name
+	ClientPrincipals        []ClientPrincipalConfig `mapstructure:"client_principals"`
+	ReloadIntervalInSeconds int                     `mapstructure:"reload_interval_in_seconds"`
+}
+
+var _ validation.Validate = (*TLSConfig)(nil)
+
+func (c TLSConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
+		validation.Field(&c.CertFile, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.KeyFile, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.MinVersion, validation.When(c.Enabled, validation.Required, validation.In(TLS_MIN_VERSIONS...).Error("can only be set to 1.2 or 1.3"))),
+		validation.Field(&c.CipherPolicy, validation.When(c.Enabled, validation.Required, validation.In(TLS_CIPHER_POLICIES...).Error("can only be set to default or strict"))),
+		validation.Field(&c.ReloadIntervalInSeconds, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.ClientPrincipals),
+	)
+}
+
+// PrincipalsBySubject indexes ClientPrincipals by subject.
+func (c TLSConfig) PrincipalsBySubject() map[string]string {
+	principals := make(map[string]string, len(c.ClientPrincipals))
+	for _, p := range c.ClientPrincipals {
+		principals[p.Subject] = p.Principal
+	}
+	return principals
+}
+
+// MutualTLS tells whether client certificates are required.
+func (c TLSConfig) MutualTLS() bool {
+	return c.Enabled && c.ClientCAFile != ""
+}
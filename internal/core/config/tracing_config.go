@@ -1,23 +1,94 @@
 package config
 
 import (
+	"regexp"
+
 	"github.com/umefy/go-web-app-template/pkg/validation"
 )
 
+var (
+	TRACING_EXPORTERS   = []interface{}{"otlp_http", "otlp_grpc", "stdout", "none"}
+	TRACING_SAMPLERS    = []interface{}{"always", "never", "ratio", "parent_based", "rate_limited"}
+	TRACING_PROPAGATORS = []interface{}{"tracecontext", "baggage", "b3", "b3multi"}
+)
+
+var resourceAttributeRegex = regexp.MustCompile(`^[^=\s]+=.*$`)
+
 type TracingConfig struct {
 	Enabled        bool
-	JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
 	ServiceName    string `mapstructure:"service_name"`
 	ServiceVersion string `mapstructure:"service_version"`
+	// Exporter sends the spans over OTLP, prints them to stdout or drops them with "none", which still gives
+	// trace ids to logs and propagates them downstream
+	Exporter string
+	// Endpoint is the host:port of the OTLP collector
+	Endpoint string
+	// Headers are sent with every OTLP export, e.g. the API key of a hosted collector
+	Headers map[string]string
+	TLS     TracingTLSConfig `mapstructure:"tls"`
+	Sampler TracingSamplerConfig
+	// ResourceAttributes are added to every span as key=value pairs, e.g. deployment.environment=prod. They are
+	// not a map because the config keys cannot contain dots
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+	// Propagators read and write the trace context of incoming and outgoing requests, in order
+	Propagators []string
+}
+
+type TracingTLSConfig struct {
+	// Insecure exports without TLS, e.g. to a collector sidecar
+	Insecure bool
+	// CAFile verifies the collector certificate, the system roots are used when empty
+	CAFile string `mapstructure:"ca_file"`
+	// CertFile and KeyFile authenticate to collectors requiring client certificates
+	CertFile string `mapstructure:"cert_file"`
+	KeyFile  string `mapstructure:"key_file"`
+}
+
+// TracingSamplerConfig decides which traces are recorded. "ratio" keeps Ratio of the traces, "parent_based"
+// follows the decision of the caller and keeps Ratio of the traces it starts, "rate_limited" follows the
+// caller too and starts at most TracesPerSecond traces.
+type TracingSamplerConfig struct {
+	Type            string
+	Ratio           float64
+	TracesPerSecond float64 `mapstructure:"traces_per_second"`
 }
 
 var _ validation.Validate = (*TracingConfig)(nil)
 
 func (c TracingConfig) Validate() error {
+	otlp := c.Exporter == "otlp_http" || c.Exporter == "otlp_grpc"
+
 	return validation.ValidateStruct(&c,
 		validation.Field(&c.Enabled, validation.In(true, false).Error("can only be set to true or false")),
-		validation.Field(&c.JaegerEndpoint, validation.When(c.Enabled, validation.Required)),
 		validation.Field(&c.ServiceName, validation.When(c.Enabled, validation.Required)),
 		validation.Field(&c.ServiceVersion, validation.When(c.Enabled, validation.Required)),
+		validation.Field(&c.Exporter, validation.When(c.Enabled, validation.Required, validation.In(TRACING_EXPORTERS...).Error("can only be set to otlp_http, otlp_grpc, stdout or none"))),
+		validation.Field(&c.Endpoint, validation.When(c.Enabled && otlp, validation.Required)),
+		validation.FieldStruct(&c.TLS),
+		validation.FieldStruct(&c.Sampler),
+		validation.Field(&c.ResourceAttributes, validation.Each(validation.Match(resourceAttributeRegex).Error("must be formatted as key=value"))),
+		validation.Field(&c.Propagators, validation.When(c.Enabled, validation.Required), validation.Each(validation.In(TRACING_PROPAGATORS...).Error("can only be set to tracecontext, baggage, b3 or b3multi"))),
+	)
+}
+
+var _ validation.Validate = (*TracingTLSConfig)(nil)
+
+func (c TracingTLSConfig) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CAFile, validation.When(c.Insecure, validation.Empty.Error("cannot be set with insecure"))),
+		validation.Field(&c.CertFile, validation.When(c.Insecure, validation.Empty.Error("cannot be set with insecure")), validation.When(c.KeyFile != "", validation.Required)),
+		validation.Field(&c.KeyFile, validation.When(c.CertFile != "", validation.Required)),
+	)
+}
+
+var _ validation.Validate = (*TracingSamplerConfig)(nil)
+
+func (c TracingSamplerConfig) Validate() error {
+	withRatio := c.Type == "ratio" || c.Type == "parent_based"
+
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Type, validation.Required, validation.In(TRACING_SAMPLERS...).Error("can only be set to always, never, ratio, parent_based or rate_limited")),
+		validation.Field(&c.Ratio, validation.When(withRatio, validation.Min(0.0), validation.Max(1.0))),
+		validation.Field(&c.TracesPerSecond, validation.When(c.Type == "rate_limited", validation.Required, validation.Min(0.0))),
 	)
 }
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+type apiKeyKey struct{}
+
+// WithAPIKey is to be called once key is verified, the API key of a request is never trusted as sent.
+func WithAPIKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, key)
+}
+
+// APIKeyFromContext returns the verified API key of the request, or "" for requests without one.
+func APIKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyKey{}).(string)
+	return key
+}
+
+// APIKeys verifies the API keys sent by clients against the hashes of the known keys.
+type APIKeys struct {
+	principals map[string]string
+}
+
+// NewAPIKeys takes the principal names by hex SHA-256 of their key.
+func NewAPIKeys(principalsByHash map[string]string) *APIKeys {
+	return &APIKeys{principals: principalsByHash}
+}
+
+// Authenticate verifies key and sets it on ctx, with its principal unless ctx already has one, e.g. from a
+// client certificate. ctx is returned as is when key is empty, and InvalidAPIKey when key is unknown.
+func (k *APIKeys) Authenticate(ctx context.Context, key string) (context.Context, error) {
+	if key == "" {
+		return ctx, nil
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	name, ok := k.principals[hex.EncodeToString(sum[:])]
+	if !ok {
+		return ctx, InvalidAPIKey
+	}
+
+	ctx = WithAPIKey(ctx, key)
+	if PrincipalFromContext(ctx) == nil {
+		ctx = WithPrincipal(ctx, &Principal{Name: name})
+	}
+	return ctx, nil
+}
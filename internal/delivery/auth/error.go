@@ -0,0 +1,9 @@
+package auth
+
+import (
+	"net/http"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+)
+
+var InvalidAPIKey = appError.NewError("auth_1001", "API key is not valid", http.StatusUnauthorized)
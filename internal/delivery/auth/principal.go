@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Name string
+	// Subject of the client certificate the principal was authenticated with, in the RFC 2253 form, empty for
+	// principals authenticated with an API key
+	Subject string
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal of the request, or nil for anonymous callers.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// CertificatePrincipal returns the principal of a connection with a verified client certificate, or nil.
+// principals maps certificate subjects to principal names, other certificates get their common name.
+func CertificatePrincipal(state *tls.ConnectionState, principals map[string]string) *Principal {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+
+	subject := state.VerifiedChains[0][0].Subject
+	name, ok := principals[subject.String()]
+	if !ok {
+		name = subject.CommonName
+	}
+	return &Principal{Name: name, Subject: subject.String()}
+}
@@ -2,6 +2,7 @@ package errutil
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	domainError "github.com/umefy/go-web-app-template/internal/domain/error"
@@ -11,6 +12,7 @@ import (
 func FormatError(err error) (int, map[string]any) {
 	var domainErr *domainError.Error
 	var validateErr *validation.ValidateStructError
+	var maxBytesErr *http.MaxBytesError
 
 	if errors.As(err, &validateErr) {
 		return http.StatusBadRequest, map[string]any{"error": map[string]any{
@@ -20,6 +22,13 @@ func FormatError(err error) (int, map[string]any) {
 		}}
 	}
 
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge, map[string]any{"error": map[string]any{
+			"code":    "REQUEST_BODY_TOO_LARGE",
+			"message": fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytesErr.Limit),
+		}}
+	}
+
 	if errors.As(err, &domainErr) {
 		return domainErr.HTTPCode, map[string]any{"error": map[string]any{
 			"code":    domainErr.Code,
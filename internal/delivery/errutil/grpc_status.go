@@ -0,0 +1,129 @@
+package errutil
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	domainError "github.com/umefy/go-web-app-template/internal/domain/error"
+	privacyError "github.com/umefy/go-web-app-template/internal/domain/privacy/error"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// grpcCodeOverrides holds the domain errors whose HTTP code maps to the wrong gRPC code.
+var grpcCodeOverrides = map[*domainError.Error]codes.Code{
+	userError.UserAlreadyExists:        codes.AlreadyExists,
+	privacyError.UserAlreadyErased:     codes.FailedPrecondition,
+	privacyError.DataExportNotReady:    codes.FailedPrecondition,
+	dataImportError.DataImportNotReady: codes.FailedPrecondition,
+}
+
+// GRPCStatus is the gRPC counterpart of FormatError. Validation errors become InvalidArgument with a
+// BadRequest detail listing the field violations, domain errors get the code matching their HTTP code
+// and an ErrorInfo detail carrying their error code. Any other error becomes Internal, without leaking
+// its message.
+func GRPCStatus(err error) *status.Status {
+	if s, ok := status.FromError(err); ok {
+		return s
+	}
+
+	var domainErr *domainError.Error
+	var validateErr *validation.ValidateStructError
+
+	if errors.As(err, &validateErr) {
+		s := status.New(codes.InvalidArgument, validateErr.Error())
+		return withDetails(s, &errdetails.BadRequest{FieldViolations: fieldViolations("", validateErr.Errors)})
+	}
+
+	if errors.As(err, &domainErr) {
+		code, ok := grpcCodeOverrides[domainErr]
+		if !ok {
+			code = grpcCodeFromHTTPCode(domainErr.HTTPCode)
+		}
+		s := status.New(code, domainErr.Message)
+		return withDetails(s, &errdetails.ErrorInfo{Reason: domainErr.Code})
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.New(codes.Internal, "internal server error")
+}
+
+// fieldViolations flattens nested struct errors into dotted field paths.
+func fieldViolations(prefix string, errs validation.Errors) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, field := range slices.Sorted(maps.Keys(errs)) {
+		path := field
+		if prefix != "" {
+			path = prefix + "." + field
+		}
+
+		var nestedErr *validation.ValidateStructError
+		var nestedErrs validation.Errors
+		switch {
+		case errors.As(errs[field], &nestedErr):
+			violations = append(violations, fieldViolations(path, nestedErr.Errors)...)
+		case errors.As(errs[field], &nestedErrs):
+			violations = append(violations, fieldViolations(path, nestedErrs)...)
+		default:
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       path,
+				Description: errs[field].Error(),
+			})
+		}
+	}
+	return violations
+}
+
+// withDetails returns s as is if detail can't be attached, the code and message matter most.
+func withDetails(s *status.Status, detail protoadapt.MessageV1) *status.Status {
+	withDetail, err := s.WithDetails(detail)
+	if err != nil {
+		return s
+	}
+	return withDetail
+}
+
+// grpcCodeFromHTTPCode follows the mapping of google.rpc.Code to HTTP codes the other way round.
+func grpcCodeFromHTTPCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if httpCode >= 400 && httpCode < 500 {
+		return codes.FailedPrecondition
+	}
+	return codes.Internal
+}
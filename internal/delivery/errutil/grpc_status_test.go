@@ -0,0 +1,75 @@
+package errutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type GRPCStatusSuite struct {
+	suite.Suite
+}
+
+func (s *GRPCStatusSuite) TestValidationError() {
+	err := validation.NewValidateStructError(validation.Errors{
+		"email": errors.New("must be a valid email address"),
+		"address": validation.NewValidateStructError(validation.Errors{
+			"zip": errors.New("cannot be blank"),
+		}),
+	})
+
+	st := GRPCStatus(err)
+
+	s.Equal(codes.InvalidArgument, st.Code())
+	s.Require().Len(st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	s.Require().True(ok)
+	s.Require().Len(badRequest.FieldViolations, 2)
+	s.Equal("address.zip", badRequest.FieldViolations[0].Field)
+	s.Equal("email", badRequest.FieldViolations[1].Field)
+	s.Equal("must be a valid email address", badRequest.FieldViolations[1].Description)
+}
+
+func (s *GRPCStatusSuite) TestDomainErrors() {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{userError.UserNotFound, codes.NotFound},
+		{userError.UserAlreadyExists, codes.AlreadyExists},
+		{userError.UserUpdateConflict, codes.Aborted},
+		{userError.InvalidCursor, codes.InvalidArgument},
+		{fmt.Errorf("wrapped: %w", userError.UserNotFound), codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		st := GRPCStatus(tt.err)
+
+		s.Equal(tt.code, st.Code(), tt.err.Error())
+		s.Require().Len(st.Details(), 1)
+		errorInfo, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		s.Require().True(ok)
+		s.NotEmpty(errorInfo.Reason)
+	}
+}
+
+func (s *GRPCStatusSuite) TestOtherErrors() {
+	st := GRPCStatus(errors.New("connection refused"))
+	s.Equal(codes.Internal, st.Code())
+	s.Equal("internal server error", st.Message())
+
+	st = GRPCStatus(status.Error(codes.Unavailable, "try again"))
+	s.Equal(codes.Unavailable, st.Code())
+	s.Equal("try again", st.Message())
+}
+
+func TestGRPCStatusSuite(t *testing.T) {
+	suite.Run(t, new(GRPCStatusSuite))
+}
@@ -0,0 +1,48 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/mapping"
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
+	privacySrv "github.com/umefy/go-web-app-template/internal/service/privacy"
+)
+
+// RequestUserDataExport is the resolver for the requestUserDataExport field.
+func (r *mutationResolver) RequestUserDataExport(ctx context.Context, userID string, format *model.DataExportFormat) (*model.DataExport, error) {
+	input := &privacySrv.DataExportCreateInput{}
+	if format != nil {
+		input.Format = mapping.GraphqlDataExportFormatToDomainDataExportFormat(*format)
+	}
+
+	dataExport, err := r.PrivacyService.RequestDataExport(ctx, userID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.DomainDataExportToGraphqlDataExport(dataExport), nil
+}
+
+// EraseUser is the resolver for the eraseUser field.
+func (r *mutationResolver) EraseUser(ctx context.Context, userID string) (*model.User, error) {
+	user, err := r.PrivacyService.EraseUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.DomainUserToGraphqlUser(user), nil
+}
+
+// UserDataExport is the resolver for the userDataExport field.
+func (r *queryResolver) UserDataExport(ctx context.Context, userID string, id string) (*model.DataExport, error) {
+	dataExport, err := r.PrivacyService.GetDataExport(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.DomainDataExportToGraphqlDataExport(dataExport), nil
+}
@@ -0,0 +1,25 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/mapping"
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
+	searchSrv "github.com/umefy/go-web-app-template/internal/service/search"
+	"github.com/umefy/go-web-app-template/pkg/cast"
+	"github.com/umefy/godash/sliceskit"
+)
+
+// Search is the resolver for the search field.
+func (r *queryResolver) Search(ctx context.Context, query string, limit *int32) ([]*model.SearchHit, error) {
+	results, err := r.SearchService.Search(ctx, searchSrv.NewSearchInput(query, int(cast.PtrToValue(limit))))
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceskit.Map(results, mapping.SearchResultToGraphqlSearchHit), nil
+}
@@ -2,15 +2,17 @@ package graphql
 
 // This file will be automatically regenerated based on the schema, any resolver implementations
 // will be copied through when generating and any unknown code will be moved to the end.
-// Code generated by github.com/99designs/gqlgen version v0.17.75
+// Code generated by github.com/99designs/gqlgen version v0.17.78
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql/dataloader"
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql/mapping"
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
 	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/go-web-app-template/pkg/cast"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
 	"github.com/umefy/godash/sliceskit"
 	"go.opentelemetry.io/otel/attribute"
@@ -29,9 +31,19 @@ func (r *mutationResolver) CreateUser(ctx context.Context, input model.UserCreat
 	return mapping.DomainUserToGraphqlUser(user), nil
 }
 
+// CreateUsers is the resolver for the createUsers field.
+func (r *mutationResolver) CreateUsers(ctx context.Context, input []*model.UserCreateInput, mode *model.BatchMode) (*model.UserBatchCreatePayload, error) {
+	result, err := r.UserService.CreateUsers(ctx, mapping.GraphqlUserCreateInputsToUserBatchCreateInput(input, mode))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.UserBatchCreateResultToGraphqlUserBatchCreatePayload(result), nil
+}
+
 // AllUsers is the resolver for the allUsers field.
-func (r *queryResolver) AllUsers(ctx context.Context, params *model.PaginationParams) (*model.UsersWithPagination, error) {
-	users, paginationMetadata, err := r.UserService.GetUsers(ctx, pagination.New(int(params.Offset), int(params.PageSize), params.IncludeTotal))
+func (r *queryResolver) AllUsers(ctx context.Context, params *model.PaginationParams, filter *model.UserFilter, sort []*model.UserSort) (*model.UsersWithPagination, error) {
+	users, paginationMetadata, err := r.UserService.GetUsers(ctx, pagination.New(int(params.Offset), int(params.PageSize), params.IncludeTotal), mapping.GraphqlUserFilterToRawQuery(filter, sort))
 	if err != nil {
 		return nil, err
 	}
@@ -42,6 +54,27 @@ func (r *queryResolver) AllUsers(ctx context.Context, params *model.PaginationPa
 	}, nil
 }
 
+// UsersConnection is the resolver for the usersConnection field.
+func (r *queryResolver) UsersConnection(ctx context.Context, first *int32, after *string, last *int32, before *string, includeTotal *bool, filter *model.UserFilter, sort []*model.UserSort) (*model.UserConnection, error) {
+	if first != nil && last != nil {
+		return nil, fmt.Errorf("first and last can't be used together")
+	}
+
+	pageSize := 0
+	if first != nil {
+		pageSize = int(*first)
+	} else if last != nil {
+		pageSize = int(*last)
+	}
+
+	connection, err := r.UserService.GetUsersByCursor(ctx, pagination.NewCursorPagination(pageSize, cast.PtrToValue(after), cast.PtrToValue(before), cast.PtrToValue(includeTotal)), mapping.GraphqlUserFilterToRawQuery(filter, sort))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.UserConnectionToGraphqlUserConnection(connection), nil
+}
+
 // User is the resolver for the user field.
 func (r *queryResolver) User(ctx context.Context, id string) (*model.User, error) {
 	tr := r.TracerProvider.Tracer("graphql.query")
@@ -21,11 +21,12 @@ type Loaders struct {
 type LoaderDeps struct {
 	OrderService order.Service
 	Logger       logger.Logger
+	Metrics      *Metrics
 }
 
 func NewLoaders(ctx context.Context, deps LoaderDeps) *Loaders {
 	return &Loaders{
-		OrderLoader: createOrderLoader(ctx, deps.Logger, deps.OrderService),
+		OrderLoader: createOrderLoader(ctx, deps.Logger, deps.Metrics, deps.OrderService),
 	}
 }
 
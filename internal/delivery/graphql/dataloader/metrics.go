@@ -0,0 +1,35 @@
+package dataloader
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const metricsScope = "github.com/umefy/go-web-app-template/internal/delivery/graphql/dataloader"
+
+// Metrics records how many keys each loader batches, batches of 1 mean the loader is not batching anything.
+type Metrics struct {
+	batchSize metric.Int64Histogram
+}
+
+func NewMetrics(meterProvider metric.MeterProvider) (*Metrics, error) {
+	batchSize, err := meterProvider.Meter(metricsScope).Int64Histogram("graphql.dataloader.batch_size",
+		metric.WithDescription("Number of keys loaded per dataloader batch, by loader."),
+		metric.WithUnit("{key}"),
+		metric.WithExplicitBucketBoundaries(1, 2, 5, 10, 25, 50, 100, 250, 500),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{batchSize: batchSize}, nil
+}
+
+func (m *Metrics) recordBatch(ctx context.Context, loader string, size int) {
+	if m == nil {
+		return
+	}
+	m.batchSize.Record(ctx, int64(size), metric.WithAttributes(attribute.String("loader", loader)))
+}
@@ -14,9 +14,11 @@ import (
 	"github.com/vikstrous/dataloadgen"
 )
 
-func createOrderLoader(ctx context.Context, logger logger.Logger, orderService orderSrv.Service) *dataloadgen.Loader[string, []*model.Order] {
+func createOrderLoader(ctx context.Context, logger logger.Logger, metrics *Metrics, orderService orderSrv.Service) *dataloadgen.Loader[string, []*model.Order] {
 	// Dataloader must return a slice with same order of the userIDs input
 	return dataloadgen.NewLoader(func(ctx context.Context, userIDs []string) ([][]*model.Order, []error) {
+		metrics.recordBatch(ctx, "orders_by_user", len(userIDs))
+
 		userIDsInt, err := sliceskit.MapWithFuncErr(userIDs, func(id string) (int, error) {
 			return strconv.Atoi(id)
 		})
@@ -0,0 +1,101 @@
+package extension
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const metricsScope = "github.com/umefy/go-web-app-template/internal/delivery/graphql/extension"
+
+// MetricsExtension counts the operations and records their duration per operation name and type, and whether
+// they returned errors. HTTP metrics only see POST /graphql/ with a 200.
+type MetricsExtension struct {
+	operations metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = (*MetricsExtension)(nil)
+
+func NewMetricsExtension(meterProvider metric.MeterProvider) (*MetricsExtension, error) {
+	meter := meterProvider.Meter(metricsScope)
+
+	operations, err := meter.Int64Counter("graphql.server.operations",
+		metric.WithDescription("Number of GraphQL operations executed, by operation name and type and whether they failed."),
+		metric.WithUnit("{operation}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("graphql.server.duration",
+		metric.WithDescription("Duration of GraphQL operations, by operation name and type and whether they failed."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetricsExtension{operations: operations, duration: duration}, nil
+}
+
+func (e *MetricsExtension) ExtensionName() string {
+	return "MetricsExtension"
+}
+
+func (e *MetricsExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e *MetricsExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+
+	oc := graphql.GetOperationContext(ctx)
+	// subscriptions respond once per event, they are counted by the WebSocket logs instead
+	if oc.Operation != nil && oc.Operation.Operation == ast.Subscription {
+		return resp
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("graphql.operation.name", operationName(oc)),
+		attribute.String("graphql.operation.type", operationType(oc)),
+		attribute.Bool("error", resp != nil && len(resp.Errors) > 0),
+	)
+	// the response is ready, a canceled context must not drop the measurement
+	mctx := context.WithoutCancel(ctx)
+	e.operations.Add(mctx, 1, attrs)
+	if !oc.Stats.OperationStart.IsZero() {
+		e.duration.Record(mctx, time.Since(oc.Stats.OperationStart).Seconds(), attrs)
+	}
+
+	return resp
+}
+
+func operationName(oc *graphql.OperationContext) string {
+	if oc.OperationName != "" {
+		return oc.OperationName
+	}
+	if oc.Operation != nil && oc.Operation.Name != "" {
+		return oc.Operation.Name
+	}
+	return "anonymous"
+}
+
+// operationType is empty for documents that failed to parse or validate.
+func operationType(oc *graphql.OperationContext) string {
+	if oc.Operation == nil {
+		return "invalid"
+	}
+	return string(oc.Operation.Operation)
+}
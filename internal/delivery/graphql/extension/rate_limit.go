@@ -0,0 +1,40 @@
+package extension
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// RateLimitExtension applies the rate limit policies naming GraphQL operations, once the operation name is
+// known. Refused operations get the rateLimit_1001 error.
+type RateLimitExtension struct {
+	Limiter *ratelimit.Limiter
+	// ErrorPresenter is the one of the server, operation interceptors run before it is available
+	ErrorPresenter graphql.ErrorPresenterFunc
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+} = (*RateLimitExtension)(nil)
+
+func (e *RateLimitExtension) ExtensionName() string {
+	return "RateLimitExtension"
+}
+
+func (e *RateLimitExtension) Validate(schema graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e *RateLimitExtension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+
+	if err := e.Limiter.CheckOperation(ctx, oc.OperationName); err != nil {
+		return graphql.OneShot(&graphql.Response{Errors: gqlerror.List{e.ErrorPresenter(ctx, err)}})
+	}
+
+	return next(ctx)
+}
@@ -0,0 +1,85 @@
+package mapping
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/godash/sliceskit"
+)
+
+func StringFilterToRawConditions(field string, filter *model.StringFilter) []listing.RawCondition {
+	if filter == nil {
+		return nil
+	}
+
+	var conditions []listing.RawCondition
+	conditions = appendRawCondition(conditions, field, listing.OperatorEq, filter.Eq)
+	conditions = appendRawCondition(conditions, field, listing.OperatorNeq, filter.Neq)
+	conditions = appendRawCondition(conditions, field, listing.OperatorContains, filter.Contains)
+	if filter.In != nil {
+		conditions = append(conditions, listing.RawCondition{Field: field, Operator: listing.OperatorIn, Values: filter.In})
+	}
+	return conditions
+}
+
+func IntFilterToRawConditions(field string, filter *model.IntFilter) []listing.RawCondition {
+	if filter == nil {
+		return nil
+	}
+
+	var conditions []listing.RawCondition
+	conditions = appendRawCondition(conditions, field, listing.OperatorEq, formatInt32(filter.Eq))
+	conditions = appendRawCondition(conditions, field, listing.OperatorNeq, formatInt32(filter.Neq))
+	conditions = appendRawCondition(conditions, field, listing.OperatorGt, formatInt32(filter.Gt))
+	conditions = appendRawCondition(conditions, field, listing.OperatorGte, formatInt32(filter.Gte))
+	conditions = appendRawCondition(conditions, field, listing.OperatorLt, formatInt32(filter.Lt))
+	conditions = appendRawCondition(conditions, field, listing.OperatorLte, formatInt32(filter.Lte))
+	if filter.In != nil {
+		conditions = append(conditions, listing.RawCondition{
+			Field:    field,
+			Operator: listing.OperatorIn,
+			Values:   sliceskit.Map(filter.In, func(v int32) string { return strconv.Itoa(int(v)) }),
+		})
+	}
+	return conditions
+}
+
+func TimeFilterToRawConditions(field string, filter *model.TimeFilter) []listing.RawCondition {
+	if filter == nil {
+		return nil
+	}
+
+	var conditions []listing.RawCondition
+	conditions = appendRawCondition(conditions, field, listing.OperatorGt, filter.Gt)
+	conditions = appendRawCondition(conditions, field, listing.OperatorGte, filter.Gte)
+	conditions = appendRawCondition(conditions, field, listing.OperatorLt, filter.Lt)
+	conditions = appendRawCondition(conditions, field, listing.OperatorLte, filter.Lte)
+	return conditions
+}
+
+// SortKeysToRawSort renders sort keys in the listing sort syntax, e.g. "-createdAt,id".
+func SortKeysToRawSort(sortKeys []listing.SortKey) string {
+	return strings.Join(sliceskit.Map(sortKeys, func(sortKey listing.SortKey) string {
+		if sortKey.Desc {
+			return "-" + sortKey.Field
+		}
+		return sortKey.Field
+	}), ",")
+}
+
+func appendRawCondition(conditions []listing.RawCondition, field string, operator listing.Operator, value *string) []listing.RawCondition {
+	if value == nil {
+		return conditions
+	}
+	return append(conditions, listing.RawCondition{Field: field, Operator: operator, Values: []string{*value}})
+}
+
+func formatInt32(v *int32) *string {
+	if v == nil {
+		return nil
+	}
+	s := strconv.Itoa(int(*v))
+	return &s
+}
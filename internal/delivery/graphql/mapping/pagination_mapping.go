@@ -20,3 +20,12 @@ func PaginationMetadataToGraphqlPaginationMetadata(metadata *pagination.Paginati
 		Total:    cast.Int64PtrToIntPtr(metadata.Total),
 	}
 }
+
+func CursorPageInfoToGraphqlPageInfo(pageInfo *pagination.CursorPageInfo) *model.PageInfo {
+	return &model.PageInfo{
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+	}
+}
@@ -0,0 +1,43 @@
+package mapping
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+)
+
+func DomainDataExportToGraphqlDataExport(dataExport *privacyDomain.DataExport) *model.DataExport {
+	graphqlDataExport := &model.DataExport{
+		ID:        strconv.Itoa(dataExport.ID),
+		UserID:    strconv.Itoa(dataExport.UserID),
+		Format:    model.DataExportFormat(strings.ToUpper(string(dataExport.Format))),
+		Status:    model.DataExportStatus(strings.ToUpper(string(dataExport.Status))),
+		CreatedAt: dataExport.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: dataExport.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if dataExport.Error != "" {
+		graphqlDataExport.Error = &dataExport.Error
+	}
+
+	if dataExport.CompletedAt != nil {
+		completedAt := dataExport.CompletedAt.Format(time.RFC3339)
+		graphqlDataExport.CompletedAt = &completedAt
+	}
+
+	// Binary content can't be served over GraphQL, point to the REST download instead.
+	if dataExport.Status == privacyDomain.DataExportStatusCompleted {
+		downloadURL := fmt.Sprintf("/api/v1/users/%d/data-exports/%d/download", dataExport.UserID, dataExport.ID)
+		graphqlDataExport.DownloadURL = &downloadURL
+	}
+
+	return graphqlDataExport
+}
+
+func GraphqlDataExportFormatToDomainDataExportFormat(format model.DataExportFormat) privacyDomain.DataExportFormat {
+	return privacyDomain.DataExportFormat(strings.ToLower(string(format)))
+}
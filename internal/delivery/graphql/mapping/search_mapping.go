@@ -0,0 +1,28 @@
+package mapping
+
+import (
+	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+	"github.com/umefy/godash/sliceskit"
+)
+
+func SearchResultToGraphqlSearchHit(result *searchDomain.Result) *model.SearchHit {
+	searchHit := &model.SearchHit{
+		Rank: result.Rank,
+		Highlights: sliceskit.Map(result.Highlights, func(highlight searchDomain.Highlight) *model.SearchHighlight {
+			return &model.SearchHighlight{
+				Field:   highlight.Field,
+				Snippet: highlight.Snippet,
+			}
+		}),
+	}
+
+	switch result.Type {
+	case searchDomain.ResultTypeUser:
+		searchHit.Node = DomainUserToGraphqlUser(result.User)
+	case searchDomain.ResultTypeOrder:
+		searchHit.Node = OrderModelToGraphqlOrder(result.Order)
+	}
+
+	return searchHit
+}
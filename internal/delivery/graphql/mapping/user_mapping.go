@@ -1,11 +1,19 @@
 package mapping
 
 import (
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql/model"
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/go-web-app-template/pkg/cast"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/go-web-app-template/pkg/pagination"
+	"github.com/umefy/godash/sliceskit"
 )
 
 func DomainUserToGraphqlUser(user *userDomain.User) *model.User {
@@ -17,3 +25,90 @@ func DomainUserToGraphqlUser(user *userDomain.User) *model.User {
 		UpdatedAt: user.UpdatedAt.Format(time.RFC3339),
 	}
 }
+
+func UserConnectionToGraphqlUserConnection(connection *pagination.Connection[*userDomain.User]) *model.UserConnection {
+	return &model.UserConnection{
+		Edges: sliceskit.Map(connection.Edges, func(edge pagination.Edge[*userDomain.User]) *model.UserEdge {
+			return &model.UserEdge{
+				Node:   DomainUserToGraphqlUser(edge.Node),
+				Cursor: edge.Cursor,
+			}
+		}),
+		PageInfo:   CursorPageInfoToGraphqlPageInfo(&connection.PageInfo),
+		TotalCount: cast.Int64PtrToIntPtr(connection.PageInfo.Total),
+	}
+}
+
+var graphqlUserSortFields = map[model.UserSortField]string{
+	model.UserSortFieldID:        "id",
+	model.UserSortFieldEmail:     "email",
+	model.UserSortFieldAge:       "age",
+	model.UserSortFieldCreatedAt: "createdAt",
+	model.UserSortFieldUpdatedAt: "updatedAt",
+}
+
+func GraphqlUserFilterToRawQuery(filter *model.UserFilter, sort []*model.UserSort) listing.RawQuery {
+	var raw listing.RawQuery
+
+	if filter != nil {
+		raw.Filter = slices.Concat(
+			IntFilterToRawConditions("id", filter.ID),
+			StringFilterToRawConditions("email", filter.Email),
+			IntFilterToRawConditions("age", filter.Age),
+			TimeFilterToRawConditions("createdAt", filter.CreatedAt),
+			TimeFilterToRawConditions("updatedAt", filter.UpdatedAt),
+		)
+	}
+
+	raw.Sort = SortKeysToRawSort(sliceskit.Map(sort, func(userSort *model.UserSort) listing.SortKey {
+		return listing.SortKey{
+			Field: graphqlUserSortFields[userSort.Field],
+			Desc:  userSort.Direction != nil && *userSort.Direction == model.SortDirectionDesc,
+		}
+	}))
+
+	return raw
+}
+
+func GraphqlUserCreateInputsToUserBatchCreateInput(input []*model.UserCreateInput, mode *model.BatchMode) *userSrv.UserBatchCreateInput {
+	batchMode := userSrv.BatchModeAtomic
+	if mode != nil {
+		batchMode = userSrv.BatchMode(strings.ToLower(string(*mode)))
+	}
+
+	return &userSrv.UserBatchCreateInput{
+		Mode: batchMode,
+		Items: sliceskit.Map(input, func(item *model.UserCreateInput) *userSrv.UserCreateInput {
+			return &userSrv.UserCreateInput{
+				Email: item.Email,
+				Age:   int(item.Age),
+			}
+		}),
+	}
+}
+
+func UserBatchCreateResultToGraphqlUserBatchCreatePayload(result *userSrv.UserBatchCreateResult) *model.UserBatchCreatePayload {
+	return &model.UserBatchCreatePayload{
+		Items: sliceskit.Map(result.Items, func(item *userSrv.UserBatchItemResult) *model.UserBatchItemResult {
+			graphqlItem := &model.UserBatchItemResult{
+				Index:  int32(item.Index),
+				Status: model.BatchItemStatus(strings.ToUpper(string(item.Status))),
+			}
+			if item.User != nil {
+				graphqlItem.User = DomainUserToGraphqlUser(item.User)
+			}
+			if item.Err != nil {
+				// same code and message as the error extensions of a failed request
+				_, errMap := errutil.FormatError(item.Err)
+				errBody := errMap["error"].(map[string]any)
+				graphqlItem.Error = &model.BatchItemError{
+					Code:    errBody["code"].(string),
+					Message: errBody["message"].(string),
+				}
+			}
+			return graphqlItem
+		}),
+		Created: int32(result.Count(userSrv.BatchItemStatusCreated)),
+		Failed:  int32(result.Count(userSrv.BatchItemStatusFailed)),
+	}
+}
@@ -2,6 +2,45 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type SearchResult interface {
+	IsSearchResult()
+}
+
+type BatchItemError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type DataExport struct {
+	ID     string           `json:"id"`
+	UserID string           `json:"userId"`
+	Format DataExportFormat `json:"format"`
+	Status DataExportStatus `json:"status"`
+	Error  *string          `json:"error,omitempty"`
+	// REST path of the archive, set once the export is completed
+	DownloadURL *string `json:"downloadUrl,omitempty"`
+	CompletedAt *string `json:"completedAt,omitempty"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+type IntFilter struct {
+	Eq  *int32  `json:"eq,omitempty"`
+	Neq *int32  `json:"neq,omitempty"`
+	Gt  *int32  `json:"gt,omitempty"`
+	Gte *int32  `json:"gte,omitempty"`
+	Lt  *int32  `json:"lt,omitempty"`
+	Lte *int32  `json:"lte,omitempty"`
+	In  []int32 `json:"in,omitempty"`
+}
+
 type Mutation struct {
 }
 
@@ -13,6 +52,16 @@ type Order struct {
 	UpdatedAt   string `json:"updatedAt"`
 }
 
+func (Order) IsSearchResult() {}
+
+// Relay connection page info, see https://relay.dev/graphql/connections.htm
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type PaginationMetadata struct {
 	Offset   int32 `json:"offset"`
 	PageSize int32 `json:"pageSize"`
@@ -30,6 +79,25 @@ type PaginationParams struct {
 type Query struct {
 }
 
+type SearchHighlight struct {
+	Field string `json:"field"`
+	// HTML escaped field value with the matches wrapped in <mark> tags
+	Snippet string `json:"snippet"`
+}
+
+type SearchHit struct {
+	Node       SearchResult       `json:"node"`
+	Rank       float64            `json:"rank"`
+	Highlights []*SearchHighlight `json:"highlights"`
+}
+
+type StringFilter struct {
+	Eq       *string  `json:"eq,omitempty"`
+	Neq      *string  `json:"neq,omitempty"`
+	In       []string `json:"in,omitempty"`
+	Contains *string  `json:"contains,omitempty"`
+}
+
 type Subscription struct {
 }
 
@@ -38,6 +106,14 @@ type Time struct {
 	Timestamp string `json:"timestamp"`
 }
 
+// Bounds are RFC 3339 date times
+type TimeFilter struct {
+	Gt  *string `json:"gt,omitempty"`
+	Gte *string `json:"gte,omitempty"`
+	Lt  *string `json:"lt,omitempty"`
+	Lte *string `json:"lte,omitempty"`
+}
+
 type User struct {
 	ID        string   `json:"id"`
 	Email     string   `json:"email"`
@@ -47,12 +123,399 @@ type User struct {
 	Orders    []*Order `json:"orders"`
 }
 
+func (User) IsSearchResult() {}
+
+type UserBatchCreatePayload struct {
+	Items   []*UserBatchItemResult `json:"items"`
+	Created int32                  `json:"created"`
+	Failed  int32                  `json:"failed"`
+}
+
+type UserBatchItemResult struct {
+	// Position of the item in the input
+	Index  int32           `json:"index"`
+	Status BatchItemStatus `json:"status"`
+	User   *User           `json:"user,omitempty"`
+	Error  *BatchItemError `json:"error,omitempty"`
+}
+
+type UserConnection struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+	// Only set when includeTotal is true
+	TotalCount *int `json:"totalCount,omitempty"`
+}
+
 type UserCreateInput struct {
 	Email string `json:"email"`
 	Age   int32  `json:"age"`
 }
 
+type UserEdge struct {
+	Node   *User  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// All set fields must match
+type UserFilter struct {
+	ID        *IntFilter    `json:"id,omitempty"`
+	Email     *StringFilter `json:"email,omitempty"`
+	Age       *IntFilter    `json:"age,omitempty"`
+	CreatedAt *TimeFilter   `json:"createdAt,omitempty"`
+	UpdatedAt *TimeFilter   `json:"updatedAt,omitempty"`
+}
+
+type UserSort struct {
+	Field     UserSortField  `json:"field"`
+	Direction *SortDirection `json:"direction,omitempty"`
+}
+
 type UsersWithPagination struct {
 	Users    []*User             `json:"users"`
 	PageInfo *PaginationMetadata `json:"pageInfo"`
 }
+
+type BatchItemStatus string
+
+const (
+	BatchItemStatusCreated BatchItemStatus = "CREATED"
+	BatchItemStatusFailed  BatchItemStatus = "FAILED"
+	// Valid but not created because another item of an ATOMIC batch failed
+	BatchItemStatusSkipped BatchItemStatus = "SKIPPED"
+)
+
+var AllBatchItemStatus = []BatchItemStatus{
+	BatchItemStatusCreated,
+	BatchItemStatusFailed,
+	BatchItemStatusSkipped,
+}
+
+func (e BatchItemStatus) IsValid() bool {
+	switch e {
+	case BatchItemStatusCreated, BatchItemStatusFailed, BatchItemStatusSkipped:
+		return true
+	}
+	return false
+}
+
+func (e BatchItemStatus) String() string {
+	return string(e)
+}
+
+func (e *BatchItemStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BatchItemStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BatchItemStatus", str)
+	}
+	return nil
+}
+
+func (e BatchItemStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *BatchItemStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e BatchItemStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type BatchMode string
+
+const (
+	BatchModeAtomic  BatchMode = "ATOMIC"
+	BatchModePartial BatchMode = "PARTIAL"
+)
+
+var AllBatchMode = []BatchMode{
+	BatchModeAtomic,
+	BatchModePartial,
+}
+
+func (e BatchMode) IsValid() bool {
+	switch e {
+	case BatchModeAtomic, BatchModePartial:
+		return true
+	}
+	return false
+}
+
+func (e BatchMode) String() string {
+	return string(e)
+}
+
+func (e *BatchMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = BatchMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid BatchMode", str)
+	}
+	return nil
+}
+
+func (e BatchMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *BatchMode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e BatchMode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DataExportFormat string
+
+const (
+	DataExportFormatJSON DataExportFormat = "JSON"
+	DataExportFormatZip  DataExportFormat = "ZIP"
+)
+
+var AllDataExportFormat = []DataExportFormat{
+	DataExportFormatJSON,
+	DataExportFormatZip,
+}
+
+func (e DataExportFormat) IsValid() bool {
+	switch e {
+	case DataExportFormatJSON, DataExportFormatZip:
+		return true
+	}
+	return false
+}
+
+func (e DataExportFormat) String() string {
+	return string(e)
+}
+
+func (e *DataExportFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DataExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DataExportFormat", str)
+	}
+	return nil
+}
+
+func (e DataExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DataExportFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DataExportFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "PENDING"
+	DataExportStatusProcessing DataExportStatus = "PROCESSING"
+	DataExportStatusCompleted  DataExportStatus = "COMPLETED"
+	DataExportStatusFailed     DataExportStatus = "FAILED"
+	DataExportStatusPurged     DataExportStatus = "PURGED"
+)
+
+var AllDataExportStatus = []DataExportStatus{
+	DataExportStatusPending,
+	DataExportStatusProcessing,
+	DataExportStatusCompleted,
+	DataExportStatusFailed,
+	DataExportStatusPurged,
+}
+
+func (e DataExportStatus) IsValid() bool {
+	switch e {
+	case DataExportStatusPending, DataExportStatusProcessing, DataExportStatusCompleted, DataExportStatusFailed, DataExportStatusPurged:
+		return true
+	}
+	return false
+}
+
+func (e DataExportStatus) String() string {
+	return string(e)
+}
+
+func (e *DataExportStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DataExportStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DataExportStatus", str)
+	}
+	return nil
+}
+
+func (e DataExportStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DataExportStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DataExportStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SortDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SortDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type UserSortField string
+
+const (
+	UserSortFieldID        UserSortField = "ID"
+	UserSortFieldEmail     UserSortField = "EMAIL"
+	UserSortFieldAge       UserSortField = "AGE"
+	UserSortFieldCreatedAt UserSortField = "CREATED_AT"
+	UserSortFieldUpdatedAt UserSortField = "UPDATED_AT"
+)
+
+var AllUserSortField = []UserSortField{
+	UserSortFieldID,
+	UserSortFieldEmail,
+	UserSortFieldAge,
+	UserSortFieldCreatedAt,
+	UserSortFieldUpdatedAt,
+}
+
+func (e UserSortField) IsValid() bool {
+	switch e {
+	case UserSortFieldID, UserSortFieldEmail, UserSortFieldAge, UserSortFieldCreatedAt, UserSortFieldUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e UserSortField) String() string {
+	return string(e)
+}
+
+func (e *UserSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserSortField", str)
+	}
+	return nil
+}
+
+func (e UserSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,47 @@
+package graphql
+
+import (
+	"bytes"
+	"net/http"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+)
+
+// nonceTags are the tags of the playground page allowed by the nonce of a Content-Security-Policy.
+var nonceTags = [][]byte{[]byte("<script"), []byte("<style"), []byte("<link")}
+
+// playgroundHandler serves the GraphQL playground, adding the CSP nonce of the request to its scripts and
+// styles. The page is served as is when the policy uses no nonce.
+func playgroundHandler(title, endpoint string) http.Handler {
+	page := playground.Handler(title, endpoint)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce := middleware.CSPNonce(r.Context())
+		if nonce == "" {
+			page.ServeHTTP(w, r)
+			return
+		}
+
+		bw := &bufferedResponseWriter{ResponseWriter: w}
+		page.ServeHTTP(bw, r)
+
+		body := bw.buf.Bytes()
+		attr := []byte(` nonce="` + nonce + `"`)
+		for _, tag := range nonceTags {
+			body = bytes.ReplaceAll(body, tag, slices.Concat(tag, attr))
+		}
+
+		_, _ = w.Write(body)
+	})
+}
+
+type bufferedResponseWriter struct {
+	http.ResponseWriter
+	buf bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
@@ -2,19 +2,25 @@ package graphql
 
 import (
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	privacySvc "github.com/umefy/go-web-app-template/internal/service/privacy"
+	searchSvc "github.com/umefy/go-web-app-template/internal/service/search"
 	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type Resolver struct {
 	UserService    userSvc.Service
+	PrivacyService privacySvc.Service
+	SearchService  searchSvc.Service
 	Logger         logger.Logger
 	TracerProvider trace.TracerProvider
 }
 
-func NewResolver(userService userSvc.Service, logger logger.Logger, tracerProvider trace.TracerProvider) *Resolver {
+func NewResolver(userService userSvc.Service, privacyService privacySvc.Service, searchService searchSvc.Service, logger logger.Logger, tracerProvider trace.TracerProvider) *Resolver {
 	return &Resolver{
 		UserService:    userService,
+		PrivacyService: privacyService,
+		SearchService:  searchService,
 		Logger:         logger,
 		TracerProvider: tracerProvider,
 	}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
-	"path"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -13,7 +12,6 @@ import (
 	gqlgenExtension "github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
-	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/gorilla/websocket"
 	"github.com/umefy/go-web-app-template/internal/core/config"
 	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
@@ -21,25 +19,29 @@ import (
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql/extension"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
 	orderSvc "github.com/umefy/go-web-app-template/internal/service/order"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
 	"github.com/vektah/gqlparser/v2/ast"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/fx"
 )
 
 type GraphqlRouterParams struct {
 	fx.In
 
-	Logger       logger.Logger
-	Config       config.Config
-	DbQuery      *database.Query
-	OrderService orderSvc.Service
-	Resolver     *Resolver
+	Logger        logger.Logger
+	Config        config.Config
+	DbQuery       *database.Query
+	OrderService  orderSvc.Service
+	Resolver      *Resolver
+	RateLimiter   *ratelimit.Limiter
+	MeterProvider metric.MeterProvider
 }
 
-func NewGraphqlRouter(params GraphqlRouterParams) http.Handler {
+func NewGraphqlRouter(params GraphqlRouterParams) (http.Handler, error) {
 	graphqlConfig := Config{
 		Resolvers: params.Resolver,
 	}
@@ -47,12 +49,14 @@ func NewGraphqlRouter(params GraphqlRouterParams) http.Handler {
 	appEnv := params.Config.GetEnv()
 
 	srv := handler.New(NewExecutableSchema(graphqlConfig))
+	// the router is mounted under /graphql, WebSocket connections follow its CORS origins
+	wsOrigins := middleware.NewOriginMatcher(params.Config.GetHttpServerConfig().CorsPolicy("/graphql").AllowedOrigins)
 
 	srv.AddTransport(transport.Websocket{
 		KeepAlivePingInterval: 10 * time.Second,
 		Upgrader: websocket.Upgrader{
 			CheckOrigin: func(r *http.Request) bool {
-				return checkWsOrigin(params, r)
+				return checkWsOrigin(wsOrigins, r)
 			},
 		},
 		InitFunc: func(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
@@ -93,19 +97,26 @@ func NewGraphqlRouter(params GraphqlRouterParams) http.Handler {
 		Cache: lru.New[string](100),
 	})
 
+	metricsExtension, err := extension.NewMetricsExtension(params.MeterProvider)
+	if err != nil {
+		return nil, err
+	}
+	srv.Use(metricsExtension)
+
+	// before the transaction, refused operations do not open one
+	if params.RateLimiter != nil {
+		srv.Use(&extension.RateLimitExtension{
+			Limiter:        params.RateLimiter,
+			ErrorPresenter: presentError,
+		})
+	}
+
 	srv.Use(&extension.TransactionExtension{
 		DbQuery: params.DbQuery,
 		Logger:  params.Logger,
 	})
 
-	srv.SetErrorPresenter(func(ctx context.Context, e error) *gqlerror.Error {
-		err := graphql.DefaultErrorPresenter(ctx, e)
-		_, errMap := errutil.FormatError(e)
-		err.Message = errMap["error"].(map[string]any)["message"].(string)
-		err.Extensions = errMap
-
-		return err
-	})
+	srv.SetErrorPresenter(presentError)
 
 	if appEnv == config.AppEnvDev {
 		srv.Use(gqlgenExtension.Introspection{})
@@ -114,39 +125,36 @@ func NewGraphqlRouter(params GraphqlRouterParams) http.Handler {
 	// Create a router that handles WebSocket connections properly
 	r := router.NewRouter()
 
+	loaderMetrics, err := dataloader.NewMetrics(params.MeterProvider)
+	if err != nil {
+		return nil, err
+	}
 	dataloaderDeps := dataloader.LoaderDeps{
 		OrderService: params.OrderService,
 		Logger:       params.Logger,
+		Metrics:      loaderMetrics,
 	}
 	// Handle playground in development
 	if appEnv == config.AppEnvDev {
-		r.Handle("/playground", dataloader.Middleware(playground.Handler("GraphQL playground", "/graphql"), dataloaderDeps))
+		r.Handle("/playground", dataloader.Middleware(playgroundHandler("GraphQL playground", "/graphql"), dataloaderDeps))
 	}
 
 	// Handle GraphQL requests
 	r.Handle("/", dataloader.Middleware(srv, dataloaderDeps))
 
-	return r
+	return r, nil
 }
 
-func checkWsOrigin(params GraphqlRouterParams, r *http.Request) bool {
-	origin := r.Header.Get("Origin")
-	if origin == "" {
-		return false
-	}
+// presentError formats errors like the REST API, with the error code and message under the extensions.
+func presentError(ctx context.Context, e error) *gqlerror.Error {
+	err := graphql.DefaultErrorPresenter(ctx, e)
+	_, errMap := errutil.FormatError(e)
+	err.Message = errMap["error"].(map[string]any)["message"].(string)
+	err.Extensions = errMap
 
-	for _, allowedOrigin := range params.Config.GetHttpServerConfig().AllowedOrigins {
-		// "*" matches anything
-		if allowedOrigin == "*" {
-			return true
-		}
-
-		// path.Match requires full match, use it as a simple glob
-		ok, err := path.Match(allowedOrigin, origin)
-		if err == nil && ok {
-			return true
-		}
-	}
+	return err
+}
 
-	return false
+func checkWsOrigin(origins *middleware.OriginMatcher, r *http.Request) bool {
+	return origins.Match(r.Header.Get("Origin"))
 }
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"github.com/umefy/go-web-app-template/internal/delivery/grpc/greeter"
+	"github.com/umefy/go-web-app-template/internal/delivery/grpc/user"
 	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
 	"go.uber.org/fx"
 )
@@ -12,5 +13,9 @@ var Module = fx.Module("grpcHandler",
 			greeter.NewHandler,
 			fx.As(new(pb.GreeterServer)),
 		),
+		fx.Annotate(
+			user.NewHandler,
+			fx.As(new(pb.UserServiceServer)),
+		),
 	),
 )
@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryAPIKey verifies the API key sent in the header metadata and sets it with its principal, see
+// auth.APIKeys. Calls with an unknown key fail with auth.InvalidAPIKey.
+func UnaryAPIKey(header string, apiKeys *auth.APIKeys) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := apiKeys.Authenticate(ctx, apiKeyFromMetadata(ctx, header))
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAPIKey is the stream counterpart of UnaryAPIKey.
+func StreamAPIKey(header string, apiKeys *auth.APIKeys) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := apiKeys.Authenticate(stream.Context(), apiKeyFromMetadata(stream.Context(), header))
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func apiKeyFromMetadata(ctx context.Context, header string) string {
+	values := metadata.ValueFromIncomingContext(ctx, strings.ToLower(header))
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
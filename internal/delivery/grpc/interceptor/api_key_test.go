@@ -0,0 +1,60 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type APIKeySuite struct {
+	suite.Suite
+	apiKeys *auth.APIKeys
+}
+
+func (s *APIKeySuite) SetupTest() {
+	sum := sha256.Sum256([]byte("billing-key"))
+	s.apiKeys = auth.NewAPIKeys(map[string]string{hex.EncodeToString(sum[:]): "billing"})
+}
+
+// call returns the principal and the API key the handler was called with.
+func (s *APIKeySuite) call(ctx context.Context) (*auth.Principal, string, error) {
+	var principal *auth.Principal
+	var apiKey string
+	_, err := UnaryAPIKey("X-API-Key", s.apiKeys)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/user.UserService/GetUser"},
+		func(ctx context.Context, req any) (any, error) {
+			principal, apiKey = auth.PrincipalFromContext(ctx), auth.APIKeyFromContext(ctx)
+			return nil, nil
+		})
+	return principal, apiKey, err
+}
+
+func (s *APIKeySuite) TestAPIKey() {
+	principal, apiKey, err := s.call(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "billing-key")))
+	s.Require().NoError(err)
+	s.Equal(&auth.Principal{Name: "billing"}, principal)
+	s.Equal("billing-key", apiKey)
+
+	// a principal from the client certificate is kept
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{Name: "billing-service", Subject: "CN=billing-service"})
+	principal, _, err = s.call(metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "billing-key")))
+	s.Require().NoError(err)
+	s.Equal("billing-service", principal.Name)
+
+	principal, apiKey, err = s.call(context.Background())
+	s.Require().NoError(err)
+	s.Nil(principal)
+	s.Empty(apiKey)
+
+	_, _, err = s.call(metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "unknown")))
+	s.ErrorIs(err, auth.InvalidAPIKey)
+}
+
+func TestAPIKeySuite(t *testing.T) {
+	suite.Run(t, new(APIKeySuite))
+}
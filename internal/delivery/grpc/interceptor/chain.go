@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"google.golang.org/grpc"
+)
+
+// ChainUnary combines interceptors into one, the first one being the outermost like in grpc.ChainUnaryInterceptor.
+// It is meant for calling handlers in-process, outside a grpc.Server.
+func ChainUnary(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req any) (any, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// UnaryChainParams are the dependencies of UnaryChain.
+type UnaryChainParams struct {
+	Logger logger.Logger
+	// Principals maps client certificate subjects to principal names, see UnaryPrincipal
+	Principals   map[string]string
+	APIKeyHeader string
+	APIKeys      *auth.APIKeys
+	// RateLimiter is nil when rate limiting is disabled
+	RateLimiter        *ratelimit.Limiter
+	DbQuery            *database.Query
+	TransactionMethods []string
+	// Metrics and Recovery are optional, the REST gateway leaves them to the HTTP server
+	Metrics  grpc.UnaryServerInterceptor
+	Recovery grpc.UnaryServerInterceptor
+}
+
+// UnaryChain returns the interceptors of unary calls, shared by the gRPC server and the REST gateway so that
+// both authenticate, limit, log and map errors alike. The error mapping is outside of the interceptors whose
+// errors are returned to clients, and the rate limit after the principal and the API key it is keyed on.
+func UnaryChain(params UnaryChainParams) []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		UnaryRequestID(params.Logger),
+		UnaryPrincipal(params.Principals),
+		UnaryLogging(params.Logger),
+	}
+	if params.Metrics != nil {
+		interceptors = append(interceptors, params.Metrics)
+	}
+	interceptors = append(interceptors,
+		UnaryError(params.Logger),
+		UnaryAPIKey(params.APIKeyHeader, params.APIKeys),
+	)
+	if params.RateLimiter != nil {
+		interceptors = append(interceptors, UnaryRateLimit(params.RateLimiter))
+	}
+	if params.Recovery != nil {
+		interceptors = append(interceptors, params.Recovery)
+	}
+	return append(interceptors, Transaction(params.DbQuery, params.Logger, params.TransactionMethods...))
+}
@@ -0,0 +1,62 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryError turns the errors returned by handlers into gRPC statuses, see errutil.GRPCStatus.
+// It must be the outermost interceptor so that it sees the errors of the others too.
+func UnaryError(logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, statusError(ctx, logger, info.FullMethod, err)
+		}
+		return resp, nil
+	}
+}
+
+// StreamError is the stream counterpart of UnaryError.
+func StreamError(logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, stream); err != nil {
+			return statusError(stream.Context(), logger, info.FullMethod, err)
+		}
+		return nil
+	}
+}
+
+func statusError(ctx context.Context, logger logger.Logger, method string, err error) error {
+	s := errutil.GRPCStatus(err)
+	// the client only gets a generic message for these, keep the real one in the logs
+	if s.Code() == codes.Internal {
+		logger.ErrorContext(ctx, "gRPC internal error", slog.String("method", method), slog.String("error", err.Error()))
+	}
+	return &statusErr{status: s, err: err}
+}
+
+// statusErr is the status of err, which it keeps so that the REST gateway can write it like the v1 API does,
+// see errutil.FormatError. The gRPC server only sees the status.
+type statusErr struct {
+	status *status.Status
+	err    error
+}
+
+func (e *statusErr) Error() string {
+	return e.status.Err().Error()
+}
+
+func (e *statusErr) GRPCStatus() *status.Status {
+	return e.status
+}
+
+func (e *statusErr) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryLogging logs the start and the end of every call with its method, status code and latency,
+// like the HTTP Logger middleware. It must run inside the request ID interceptor and outside the error one,
+// so that the records carry the request ID and the final status code.
+func UnaryLogging(logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := logStart(ctx, logger, info.FullMethod)
+		resp, err := handler(ctx, req)
+		logDone(ctx, logger, info.FullMethod, err, start)
+		return resp, err
+	}
+}
+
+// StreamLogging is the stream counterpart of UnaryLogging.
+func StreamLogging(logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := stream.Context()
+		start := logStart(ctx, logger, info.FullMethod)
+		err := handler(srv, stream)
+		logDone(ctx, logger, info.FullMethod, err, start)
+		return err
+	}
+}
+
+func logStart(ctx context.Context, logger logger.Logger, method string) time.Time {
+	logger.InfoContext(ctx, "gRPC Request start",
+		slog.String("method", method),
+		slog.String("remote_ip", peerIP(ctx)),
+	)
+	return time.Now()
+}
+
+func logDone(ctx context.Context, logger logger.Logger, method string, err error, start time.Time) {
+	logger.InfoContext(ctx, "gRPC Request done",
+		slog.String("method", method),
+		slog.String("code", status.Code(err).String()),
+		slog.String("remote_ip", peerIP(ctx)),
+		slog.String("latency", fmt.Sprintf("%dms", time.Since(start).Milliseconds())),
+	)
+}
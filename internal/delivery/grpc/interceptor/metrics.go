@@ -0,0 +1,83 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsScope = "github.com/umefy/go-web-app-template/internal/delivery/grpc/interceptor"
+
+type serverMetrics struct {
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+func newServerMetrics(meterProvider metric.MeterProvider) (*serverMetrics, error) {
+	meter := meterProvider.Meter(metricsScope)
+
+	requests, err := meter.Int64Counter("grpc.server.requests",
+		metric.WithDescription("Number of gRPC calls handled, by method and status code."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("grpc.server.duration",
+		metric.WithDescription("Duration of gRPC calls, by method and status code."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverMetrics{requests: requests, duration: duration}, nil
+}
+
+func (m *serverMetrics) record(ctx context.Context, method string, err error, start time.Time) {
+	attrs := metric.WithAttributes(
+		attribute.String("rpc.method", method),
+		attribute.String("rpc.grpc.status_code", status.Code(err).String()),
+	)
+	// the call is over, a canceled context must not drop the measurement
+	ctx = context.WithoutCancel(ctx)
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, time.Since(start).Seconds(), attrs)
+}
+
+// UnaryMetrics counts the calls and records their duration per method and status code.
+// Like the logging interceptor, it must run outside the error one to see the final status code.
+func UnaryMetrics(meterProvider metric.MeterProvider) (grpc.UnaryServerInterceptor, error) {
+	m, err := newServerMetrics(meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.record(ctx, info.FullMethod, err, start)
+		return resp, err
+	}, nil
+}
+
+// StreamMetrics is the stream counterpart of UnaryMetrics.
+func StreamMetrics(meterProvider metric.MeterProvider) (grpc.StreamServerInterceptor, error) {
+	m, err := newServerMetrics(meterProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		m.record(stream.Context(), info.FullMethod, err, start)
+		return err
+	}, nil
+}
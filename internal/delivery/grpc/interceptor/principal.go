@@ -0,0 +1,43 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// UnaryPrincipal sets the auth principal of calls made with a verified client certificate, see auth.PrincipalFromContext.
+// principals maps certificate subjects to principal names, other certificates get their common name.
+func UnaryPrincipal(principals map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withPrincipal(ctx, principals), req)
+	}
+}
+
+// StreamPrincipal is the stream counterpart of UnaryPrincipal.
+func StreamPrincipal(principals map[string]string) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := withPrincipal(stream.Context(), principals)
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+func withPrincipal(ctx context.Context, principals map[string]string) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return ctx
+	}
+
+	principal := auth.CertificatePrincipal(&tlsInfo.State, principals)
+	if principal == nil {
+		return ctx
+	}
+	return auth.WithPrincipal(ctx, principal)
+}
@@ -0,0 +1,79 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryRateLimit applies the rate limit policies to the full method names, sending the RateLimit-* headers
+// as response metadata. Refused calls fail with the rateLimit_1001 error, so it must run inside UnaryError
+// and after UnaryPrincipal.
+func UnaryRateLimit(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		header, err := checkRateLimit(ctx, limiter, info.FullMethod)
+		if header != nil {
+			// nolint: errcheck
+			grpc.SetHeader(ctx, header)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit is the stream counterpart of UnaryRateLimit, a stream counts as one request.
+func StreamRateLimit(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		header, err := checkRateLimit(stream.Context(), limiter, info.FullMethod)
+		if header != nil {
+			// nolint: errcheck
+			stream.SetHeader(header)
+		}
+		if err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+// rateLimitExemptPrefixes are the operational services, left out like the HTTP health check.
+var rateLimitExemptPrefixes = []string{"/grpc.health.v1.", "/grpc.reflection.", "/grpc.channelz."}
+
+func checkRateLimit(ctx context.Context, limiter *ratelimit.Limiter, method string) (metadata.MD, error) {
+	for _, prefix := range rateLimitExemptPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return nil, nil
+		}
+	}
+
+	// called in-process by the REST gateway, the HTTP server already checked the request and sets the headers
+	if checked, err := limiter.CheckMethod(ctx, method); checked {
+		return nil, err
+	}
+
+	req := ratelimit.Request{
+		Route:  method,
+		APIKey: auth.APIKeyFromContext(ctx),
+		IP:     peerIP(ctx),
+	}
+	if principal := auth.PrincipalFromContext(ctx); principal != nil {
+		req.Principal = principal.Name
+	}
+
+	result, matched := limiter.Check(ctx, req)
+	if !matched {
+		return nil, nil
+	}
+
+	header := metadata.New(result.Headers())
+	if !result.Allowed {
+		return header, ratelimit.RateLimitExceeded
+	}
+	return header, nil
+}
@@ -0,0 +1,149 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type RateLimitSuite struct {
+	suite.Suite
+	limiter *ratelimit.Limiter
+}
+
+func (s *RateLimitSuite) SetupTest() {
+	appConfig := config.NewAppConfig(config.AppConfig{
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Policies: []config.RateLimitPolicyConfig{
+				{Name: "users", Limit: 2, WindowInSeconds: 60, KeyBy: []string{"principal"}, Routes: []string{"/user.UserService"}},
+				{Name: "search", Limit: 1, WindowInSeconds: 60, GraphqlOperations: []string{"Search"}},
+				{Name: "health", Limit: 1, WindowInSeconds: 60, Routes: []string{"/grpc.health.v1.Health"}},
+			},
+		},
+	})
+
+	limiter, err := ratelimit.NewLimiter(ratelimit.LimiterParams{
+		Config:    appConfig,
+		Logger:    loggerMocks.NewMockLogger(s.T()),
+		Lifecycle: fxtest.NewLifecycle(s.T()),
+	})
+	s.Require().NoError(err)
+	s.limiter = limiter
+}
+
+func (s *RateLimitSuite) call(ctx context.Context, method string) error {
+	_, err := UnaryRateLimit(s.limiter)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+	return err
+}
+
+func (s *RateLimitSuite) TestPolicyByPrincipal() {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	billing := auth.WithPrincipal(ctx, &auth.Principal{Name: "billing"})
+
+	s.NoError(s.call(billing, "/user.UserService/GetUser"))
+	s.NoError(s.call(billing, "/user.UserService/GetUsers"))
+	s.ErrorIs(s.call(billing, "/user.UserService/GetUser"), ratelimit.RateLimitExceeded)
+
+	// other principals, and the same IP without principal, have their own counters
+	s.NoError(s.call(auth.WithPrincipal(ctx, &auth.Principal{Name: "reports"}), "/user.UserService/GetUser"))
+	s.NoError(s.call(ctx, "/user.UserService/GetUser"))
+
+	// methods outside the policy routes, and the policies of GraphQL operations, do not apply
+	for range 2 {
+		s.NoError(s.call(billing, "/greeter.Greeter/SayHello"))
+	}
+}
+
+func (s *RateLimitSuite) TestPolicyByAPIKey() {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	withKey := auth.WithAPIKey(ctx, "key-1")
+
+	s.NoError(s.call(withKey, "/user.UserService/GetUser"))
+	s.NoError(s.call(withKey, "/user.UserService/GetUser"))
+	s.ErrorIs(s.call(withKey, "/user.UserService/GetUser"), ratelimit.RateLimitExceeded)
+
+	s.NoError(s.call(auth.WithAPIKey(ctx, "key-2"), "/user.UserService/GetUser"))
+}
+
+func (s *RateLimitSuite) TestRotatingUnverifiedAPIKeys() {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	withKey := func(key string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+	}
+
+	// keys that were not verified are ignored, the calls are counted by IP
+	s.NoError(s.call(withKey("key-1"), "/user.UserService/GetUser"))
+	s.NoError(s.call(withKey("key-2"), "/user.UserService/GetUser"))
+	s.ErrorIs(s.call(withKey("key-3"), "/user.UserService/GetUser"), ratelimit.RateLimitExceeded)
+}
+
+func (s *RateLimitSuite) TestExemptServices() {
+	// even policies naming them do not apply
+	for range 3 {
+		s.NoError(s.call(context.Background(), "/grpc.health.v1.Health/Check"))
+	}
+}
+
+func (s *RateLimitSuite) TestRESTGateway() {
+	appConfig := config.NewAppConfig(config.AppConfig{
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Policies: []config.RateLimitPolicyConfig{
+				{Name: "global", Limit: 2, WindowInSeconds: 60, KeyBy: []string{"ip"}},
+				{Name: "users", Limit: 5, WindowInSeconds: 60, KeyBy: []string{"ip"}, Routes: []string{"/user.UserService"}},
+			},
+		},
+	})
+	limiter, err := ratelimit.NewLimiter(ratelimit.LimiterParams{
+		Config:    appConfig,
+		Logger:    loggerMocks.NewMockLogger(s.T()),
+		Lifecycle: fxtest.NewLifecycle(s.T()),
+	})
+	s.Require().NoError(err)
+	s.limiter = limiter
+
+	// the gateway calls the method while handling the HTTP request, which the HTTP server checked first
+	serve := func(ip string) (http.Header, error) {
+		header := http.Header{}
+		ctx, err := limiter.CheckHTTP(context.Background(), ratelimit.Request{Route: "/api/v2/users/1", IP: ip}, header)
+		if err != nil {
+			return header, err
+		}
+		return header, s.call(ctx, "/user.UserService/GetUser")
+	}
+
+	// the global policy matches both the path and the method but counts each request once
+	header, err := serve("10.0.0.1")
+	s.Require().NoError(err)
+	s.Equal("2", header.Get("RateLimit-Limit"))
+	s.Equal("1", header.Get("RateLimit-Remaining"))
+
+	_, err = serve("10.0.0.1")
+	s.NoError(err)
+	_, err = serve("10.0.0.1")
+	s.ErrorIs(err, ratelimit.RateLimitExceeded)
+
+	_, err = serve("10.0.0.2")
+	s.NoError(err)
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitSuite))
+}
@@ -0,0 +1,99 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"net"
+
+	"github.com/google/uuid"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RequestIDMetadataKey is the gRPC counterpart of the X-Request-ID HTTP header.
+const RequestIDMetadataKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// UnaryRequestID takes the request ID from the incoming metadata, or generates one, and sends it back
+// in the response header. The ID is stored in the context, see GetRequestID, and added to its log records.
+func UnaryRequestID(logger logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, requestID := withRequestID(ctx, logger)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+		return handler(ctx, req)
+	}
+}
+
+// StreamRequestID is the stream counterpart of UnaryRequestID.
+func StreamRequestID(logger logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestID(stream.Context(), logger)
+		_ = stream.SetHeader(metadata.Pairs(RequestIDMetadataKey, requestID))
+		return handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// GetRequestID returns the request ID set by the request ID interceptors, or "" outside of them.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+func withRequestID(ctx context.Context, logger logger.Logger) (context.Context, string) {
+	requestID := incomingRequestID(ctx)
+	if requestID == "" {
+		requestID = newRequestID(ctx)
+	}
+
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	ctx = logger.GetLogger().WithValue(ctx, slog.String("request_id", requestID))
+	return ctx, requestID
+}
+
+func incomingRequestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// newRequestID follows the format of the HTTP RequestID middleware, the peer IP and a short hash.
+func newRequestID(ctx context.Context) string {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(uuid.New().String()))
+	hashedID := fmt.Sprintf("%016x", hasher.Sum64())[:8]
+
+	return fmt.Sprintf("%s-%s", peerIP(ctx), hashedID)
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// serverStream overrides the context of a grpc.ServerStream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
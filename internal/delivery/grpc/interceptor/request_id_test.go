@@ -0,0 +1,57 @@
+package interceptor
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	godashLogger "github.com/umefy/godash/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type RequestIDSuite struct {
+	suite.Suite
+	logger *loggerMocks.MockLogger
+}
+
+func (s *RequestIDSuite) SetupTest() {
+	s.logger = loggerMocks.NewMockLogger(s.T())
+	s.logger.EXPECT().GetLogger().
+		Return(godashLogger.New(godashLogger.NewLoggerOps(false, io.Discard, slog.LevelInfo, false, "source", 0), nil))
+}
+
+func (s *RequestIDSuite) requestIDOf(ctx context.Context) string {
+	var requestID string
+	_, err := UnaryRequestID(s.logger)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"},
+		func(ctx context.Context, req any) (any, error) {
+			requestID = GetRequestID(ctx)
+			return nil, nil
+		})
+	s.Require().NoError(err)
+	return requestID
+}
+
+func (s *RequestIDSuite) TestIncomingRequestID() {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "abc-123"))
+
+	s.Equal("abc-123", s.requestIDOf(ctx))
+}
+
+func (s *RequestIDSuite) TestGeneratedRequestID() {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+
+	requestID := s.requestIDOf(ctx)
+
+	s.Regexp(`^10\.0\.0\.1-[0-9a-f]{8}$`, requestID)
+	s.NotEqual(requestID, s.requestIDOf(ctx))
+}
+
+func TestRequestIDSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDSuite))
+}
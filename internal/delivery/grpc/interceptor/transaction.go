@@ -0,0 +1,26 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"google.golang.org/grpc"
+)
+
+// Transaction runs the given unary methods in a transaction, like the REST Transaction middleware.
+// methods are full method names, e.g. pb.UserService_CreateUser_FullMethodName. The transaction is
+// rolled back when the handler returns an error.
+func Transaction(dbQuery *database.Query, logger logger.Logger, methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		return database.WithTx(ctx, dbQuery, logger, func(ctx context.Context, tx *database.QueryTx) (any, error) {
+			ctx = context.WithValue(ctx, database.TransactionCtxKey, tx)
+			return handler(ctx, req)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package mapping
+
+import (
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/go-web-app-template/pkg/pagination"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"github.com/umefy/godash/sliceskit"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func UserModelToPbUser(user *userDomain.User) *pb.User {
+	return &pb.User{
+		Id:        int64(user.ID),
+		Email:     user.Email,
+		Age:       int32(user.Age),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+func PaginationMetadataToPbPaginationMetadata(paginationMetadata *pagination.PaginationMetadata) *pb.PaginationMetadata {
+	pbPaginationMetadata := &pb.PaginationMetadata{
+		Offset:   int32(paginationMetadata.Offset),
+		PageSize: int32(paginationMetadata.PageSize),
+		Count:    int32(paginationMetadata.Count),
+		HasMore:  paginationMetadata.HasMore,
+	}
+
+	if paginationMetadata.Total != nil {
+		pbPaginationMetadata.Total = wrapperspb.Int64(*paginationMetadata.Total)
+	}
+
+	return pbPaginationMetadata
+}
+
+// PbListUsersRequestToPagination falls back to the defaults for unset fields, the same way the
+// REST query parameters do.
+func PbListUsersRequestToPagination(req *pb.ListUsersRequest) pagination.Pagination {
+	offset, pageSize := pagination.DefaultOffset, pagination.DefaultPageSize
+	if req.Offset != nil {
+		offset = int(req.Offset.GetValue())
+	}
+	if req.PageSize != nil {
+		pageSize = int(req.PageSize.GetValue())
+	}
+
+	return pagination.New(offset, pageSize, req.GetIncludeTotal())
+}
+
+func PbListUsersRequestToRawQuery(req *pb.ListUsersRequest) listing.RawQuery {
+	return listing.RawQuery{
+		Filter: sliceskit.Map(req.GetFilter(), func(condition *pb.FilterCondition) listing.RawCondition {
+			return listing.RawCondition{
+				Field:    condition.GetField(),
+				Operator: listing.Operator(condition.GetOperator()),
+				Values:   condition.GetValues(),
+			}
+		}),
+		Sort: req.GetSort(),
+	}
+}
+
+func PbCreateUserRequestToUserCreateInput(req *pb.CreateUserRequest) *userSrv.UserCreateInput {
+	return &userSrv.UserCreateInput{
+		Email: req.GetEmail(),
+		Age:   int(req.GetAge()),
+	}
+}
+
+func PbUpdateUserRequestToUserUpdateInput(req *pb.UpdateUserRequest) *userSrv.UserUpdateInput {
+	input := &userSrv.UserUpdateInput{}
+	if req.Email != nil {
+		email := req.Email.GetValue()
+		input.Email = &email
+	}
+	if req.Age != nil {
+		age := int(req.Age.GetValue())
+		input.Age = &age
+	}
+	return input
+}
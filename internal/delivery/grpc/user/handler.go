@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/grpc/mapping"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"github.com/umefy/godash/sliceskit"
+)
+
+// TransactionalMethods are the mutating RPCs, they must run in a transaction.
+var TransactionalMethods = []string{
+	pb.UserService_CreateUser_FullMethodName,
+	pb.UserService_UpdateUser_FullMethodName,
+}
+
+type userHandler struct {
+	logger      logger.Logger
+	userService userSvc.Service
+	pb.UnimplementedUserServiceServer
+}
+
+var _ pb.UserServiceServer = (*userHandler)(nil)
+
+func NewHandler(logger logger.Logger, userService userSvc.Service) *userHandler {
+	return &userHandler{logger: logger, userService: userService}
+}
+
+// GetUser implements pb.UserServiceServer.
+func (h *userHandler) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	h.logger.DebugContext(ctx, "GetUser", slog.Int64("id", req.GetId()))
+
+	user, err := h.userService.GetUser(ctx, strconv.FormatInt(req.GetId(), 10))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetUserResponse{User: mapping.UserModelToPbUser(user)}, nil
+}
+
+// ListUsers implements pb.UserServiceServer.
+func (h *userHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	h.logger.DebugContext(ctx, "ListUsers")
+
+	if err := validateListUsersRequest(req); err != nil {
+		return nil, err
+	}
+
+	users, paginationMetadata, err := h.userService.GetUsers(ctx, mapping.PbListUsersRequestToPagination(req), mapping.PbListUsersRequestToRawQuery(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListUsersResponse{
+		Users:    sliceskit.Map(users, mapping.UserModelToPbUser),
+		PageInfo: mapping.PaginationMetadataToPbPaginationMetadata(paginationMetadata),
+	}, nil
+}
+
+// CreateUser implements pb.UserServiceServer.
+func (h *userHandler) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	h.logger.DebugContext(ctx, "CreateUser")
+
+	user, err := h.userService.CreateUser(ctx, mapping.PbCreateUserRequestToUserCreateInput(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateUserResponse{User: mapping.UserModelToPbUser(user)}, nil
+}
+
+// UpdateUser implements pb.UserServiceServer.
+func (h *userHandler) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	h.logger.DebugContext(ctx, "UpdateUser", slog.Int64("id", req.GetId()))
+
+	if err := validateUpdateUserRequest(req); err != nil {
+		return nil, err
+	}
+
+	user, err := h.userService.UpdateUser(ctx, strconv.FormatInt(req.GetId(), 10), mapping.PbUpdateUserRequestToUserUpdateInput(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateUserResponse{User: mapping.UserModelToPbUser(user)}, nil
+}
@@ -0,0 +1,74 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	userSrvMocks "github.com/umefy/go-web-app-template/mocks/service/user"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/go-web-app-template/pkg/pagination"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type UserHandlerSuite struct {
+	suite.Suite
+}
+
+func (s *UserHandlerSuite) TestListUsers() {
+	userService := userSrvMocks.NewMockService(s.T())
+	logger := loggerMocks.NewMockLogger(s.T())
+
+	users := []*userDomain.User{
+		{
+			ID:    1,
+			Email: "john.doe@example.com",
+			Age:   20,
+		},
+	}
+	query := listing.RawQuery{
+		Filter: []listing.RawCondition{{Field: "age", Operator: listing.OperatorGte, Values: []string{"18"}}},
+		Sort:   "-createdAt",
+	}
+
+	userService.EXPECT().GetUsers(context.Background(), pagination.New(0, 10, false), query).
+		Return(users, &pagination.PaginationMetadata{Offset: 0, PageSize: 10, Count: 1}, nil)
+	logger.EXPECT().DebugContext(context.Background(), "ListUsers")
+
+	h := NewHandler(logger, userService)
+
+	resp, err := h.ListUsers(context.Background(), &pb.ListUsersRequest{
+		PageSize: wrapperspb.Int32(10),
+		Filter:   []*pb.FilterCondition{{Field: "age", Operator: "gte", Values: []string{"18"}}},
+		Sort:     "-createdAt",
+	})
+	s.NoError(err)
+
+	s.Len(resp.Users, 1)
+	s.Equal("john.doe@example.com", resp.Users[0].Email)
+	s.Equal(int32(10), resp.PageInfo.PageSize)
+	s.Nil(resp.PageInfo.Total)
+}
+
+func (s *UserHandlerSuite) TestListUsersInvalidPageSize() {
+	userService := userSrvMocks.NewMockService(s.T())
+	logger := loggerMocks.NewMockLogger(s.T())
+
+	logger.EXPECT().DebugContext(context.Background(), "ListUsers")
+
+	h := NewHandler(logger, userService)
+
+	_, err := h.ListUsers(context.Background(), &pb.ListUsersRequest{PageSize: wrapperspb.Int32(-1)})
+
+	var validateErr *validation.ValidateStructError
+	s.ErrorAs(err, &validateErr)
+	s.Contains(validateErr.Errors, "page_size")
+}
+
+func TestUserHandlerSuite(t *testing.T) {
+	suite.Run(t, new(UserHandlerSuite))
+}
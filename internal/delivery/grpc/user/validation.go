@@ -0,0 +1,22 @@
+package user
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+)
+
+// The wrapped fields are checked here, before they are unwrapped into service inputs, so that an
+// out of range value is reported instead of being replaced by a default.
+
+func validateListUsersRequest(req *pb.ListUsersRequest) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.Offset, validation.MinWrapperspb(0)),
+		validation.Field(&req.PageSize, validation.MinWrapperspb(1)),
+	)
+}
+
+func validateUpdateUserRequest(req *pb.UpdateUserRequest) error {
+	return validation.ValidateStruct(req,
+		validation.Field(&req.Age, validation.MinWrapperspb(0), validation.MaxWrapperspb(100)),
+	)
+}
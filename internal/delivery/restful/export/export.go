@@ -0,0 +1,39 @@
+package export
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+	"github.com/umefy/go-web-app-template/pkg/export"
+)
+
+// Timeout bounds an export once the router wide request timeout is lifted.
+const Timeout = 30 * time.Minute
+
+var NotAcceptable = appError.NewError("export_1001", "unsupported export format, accept text/csv or application/x-ndjson", http.StatusNotAcceptable)
+
+// NewResponse starts an export response named after name and the current time, see export.NewResponse.
+func NewResponse[T any](w http.ResponseWriter, r *http.Request, name string, columns []export.Column[T]) (*export.Response[T], error) {
+	resp, err := export.NewResponse(w, r, name+"-"+time.Now().UTC().Format("20060102T150405Z"), columns)
+	if errors.Is(err, export.ErrNotAcceptable) {
+		return nil, NotAcceptable
+	}
+	return resp, err
+}
+
+// Stream runs write, which feeds resp, and finishes the export. Errors before anything is sent are returned
+// for the handler to answer. Later ones abort the connection, so clients can't take a truncated export for a
+// complete one.
+func Stream[T any](resp *export.Response[T], write func() error) error {
+	err := write()
+	if err == nil {
+		err = resp.Close()
+	}
+
+	if err != nil && resp.Started() {
+		panic(http.ErrAbortHandler)
+	}
+	return err
+}
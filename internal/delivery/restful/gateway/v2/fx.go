@@ -0,0 +1,21 @@
+package v2
+
+import (
+	"net/http"
+
+	"go.uber.org/fx"
+)
+
+const (
+	FX_TAG_NAME_API_V2_ROUTER = `name:"apiV2Router"`
+)
+
+var Module = fx.Module("apiV2Router",
+	fx.Provide(
+		fx.Annotate(
+			NewApiV2Router,
+			fx.As(new(http.Handler)),
+			fx.ResultTags(FX_TAG_NAME_API_V2_ROUTER),
+		),
+	),
+)
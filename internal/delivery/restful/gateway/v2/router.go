@@ -0,0 +1,129 @@
+package v2
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
+	"github.com/umefy/go-web-app-template/internal/delivery/grpc/interceptor"
+	userHandler "github.com/umefy/go-web-app-template/internal/delivery/grpc/user"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"github.com/umefy/godash/jsonkit"
+	"go.uber.org/fx"
+	rpcCode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type ApiV2RouterParams struct {
+	fx.In
+
+	Ctx         context.Context
+	Config      config.Config
+	Logger      logger.Logger
+	UserService userSvc.Service
+	DbQuery     *database.Query
+	// RateLimiter is nil when rate limiting is disabled
+	RateLimiter *ratelimit.Limiter
+}
+
+// NewApiV2Router serves the REST routes generated from the google.api.http options of the protos.
+// Requests are handled in-process by the gRPC handlers, through the interceptors of the gRPC server, and errors
+// get the same body as the v1 routes. The routes carry the full /api/v2 path, so the router is mounted without
+// stripping the prefix.
+func NewApiV2Router(params ApiV2RouterParams) (http.Handler, error) {
+	authConfig := params.Config.GetAuthConfig()
+	apiKeyHeader := strings.ToLower(authConfig.APIKeyHeader)
+
+	mux := runtime.NewServeMux(
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			if strings.ToLower(key) == apiKeyHeader {
+				return apiKeyHeader, true
+			}
+			return runtime.DefaultHeaderMatcher(key)
+		}),
+		runtime.WithOutgoingHeaderMatcher(func(key string) (string, bool) {
+			// the HTTP server already sends the request ID
+			if key == interceptor.RequestIDMetadataKey {
+				return "", false
+			}
+			return runtime.MetadataHeaderPrefix + key, true
+		}),
+		runtime.WithMetadata(requestIDMetadata),
+	)
+
+	unaryInterceptor := interceptor.ChainUnary(interceptor.UnaryChain(interceptor.UnaryChainParams{
+		Logger:             params.Logger,
+		Principals:         params.Config.GetHttpServerConfig().TLS.PrincipalsBySubject(),
+		APIKeyHeader:       authConfig.APIKeyHeader,
+		APIKeys:            auth.NewAPIKeys(authConfig.PrincipalsByKeyHash()),
+		RateLimiter:        params.RateLimiter,
+		DbQuery:            params.DbQuery,
+		TransactionMethods: userHandler.TransactionalMethods,
+	})...)
+
+	userServer := &userServer{
+		server:      userHandler.NewHandler(params.Logger, params.UserService),
+		interceptor: unaryInterceptor,
+	}
+	if err := pb.RegisterUserServiceHandlerServer(params.Ctx, mux, userServer); err != nil {
+		return nil, err
+	}
+
+	return withPeer(mux), nil
+}
+
+// withPeer gives the in-process calls the peer a gRPC call would have, the client IP and its TLS state,
+// which the request ID and principal interceptors read.
+func withPeer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(middleware.ExtractIP(r))}}
+		if r.TLS != nil {
+			p.AuthInfo = credentials.TLSInfo{State: *r.TLS}
+		}
+		next.ServeHTTP(w, r.WithContext(peer.NewContext(r.Context(), p)))
+	})
+}
+
+// requestIDMetadata passes the ID of the HTTP RequestID middleware on, so that both log the same one.
+func requestIDMetadata(_ context.Context, r *http.Request) metadata.MD {
+	requestID, ok := r.Context().Value(middleware.RequestIDKey).(string)
+	if !ok {
+		return nil
+	}
+	return metadata.Pairs(interceptor.RequestIDMetadataKey, requestID)
+}
+
+// errorHandler writes errors like the v1 routes do, see errutil.FormatError. Internal errors and the errors of
+// the gateway itself, such as a malformed body, only carry a gRPC status and are written from it.
+func errorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	statusCode, errMap := errutil.FormatError(err)
+	if s, ok := status.FromError(err); ok && statusCode == http.StatusInternalServerError {
+		code := "INTERNAL_SERVER_ERROR"
+		if s.Code() != codes.Internal && s.Code() != codes.Unknown {
+			statusCode = runtime.HTTPStatusFromCode(s.Code())
+			code = rpcCode.Code_name[int32(s.Code())]
+		}
+		errMap = map[string]any{"error": map[string]any{
+			"code":    code,
+			"message": s.Message(),
+		}}
+	}
+
+	// nolint: errcheck
+	jsonkit.JSONResponse(w, statusCode, errMap)
+}
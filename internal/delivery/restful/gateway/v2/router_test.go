@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/delivery/grpc/interceptor"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	userSrvMocks "github.com/umefy/go-web-app-template/mocks/service/user"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	godashLogger "github.com/umefy/godash/logger"
+)
+
+type ApiV2RouterSuite struct {
+	suite.Suite
+	userService *userSrvMocks.MockService
+	router      http.Handler
+}
+
+func (s *ApiV2RouterSuite) SetupTest() {
+	s.userService = userSrvMocks.NewMockService(s.T())
+	logger := loggerMocks.NewMockLogger(s.T())
+	logger.EXPECT().GetLogger().
+		Return(godashLogger.New(godashLogger.NewLoggerOps(false, io.Discard, slog.LevelInfo, false, "source", 0), nil)).Maybe()
+	logger.EXPECT().DebugContext(mock.Anything, mock.Anything, mock.Anything).Maybe()
+	logger.EXPECT().InfoContext(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+	logger.EXPECT().InfoContext(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+	keyHash := sha256.Sum256([]byte("billing-key"))
+	appConfig := config.NewAppConfig(config.AppConfig{
+		Auth: config.AuthConfig{
+			APIKeyHeader: "X-API-Key",
+			APIKeys:      []config.APIKeyConfig{{Principal: "billing", Sha256: hex.EncodeToString(keyHash[:])}},
+		},
+	})
+
+	router, err := NewApiV2Router(ApiV2RouterParams{
+		Ctx:         context.Background(),
+		Config:      appConfig,
+		Logger:      logger,
+		UserService: s.userService,
+	})
+	s.Require().NoError(err)
+	s.router = router
+}
+
+// errorBody is the error body of the v1 routes, see errutil.FormatError.
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (s *ApiV2RouterSuite) errorOf(rr *httptest.ResponseRecorder) errorBody {
+	var body errorBody
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	return body
+}
+
+func (s *ApiV2RouterSuite) TestGetUser() {
+	s.userService.EXPECT().GetUser(mock.Anything, "1").
+		Return(&userDomain.User{ID: 1, Email: "john.doe@example.com", Age: 20}, nil)
+
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v2/users/1", nil))
+
+	s.Equal(http.StatusOK, rr.Code)
+
+	var body struct {
+		User struct {
+			ID    string `json:"id"`
+			Email string `json:"email"`
+		} `json:"user"`
+	}
+	s.Require().NoError(json.Unmarshal(rr.Body.Bytes(), &body))
+	s.Equal("1", body.User.ID)
+	s.Equal("john.doe@example.com", body.User.Email)
+}
+
+func (s *ApiV2RouterSuite) TestGetUserNotFound() {
+	s.userService.EXPECT().GetUser(mock.Anything, "1").Return(nil, userError.UserNotFound)
+
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v2/users/1", nil))
+
+	s.Equal(http.StatusNotFound, rr.Code)
+	body := s.errorOf(rr)
+	s.Equal(userError.UserNotFound.Code, body.Error.Code)
+	s.Equal(userError.UserNotFound.Message, body.Error.Message)
+}
+
+func (s *ApiV2RouterSuite) TestMalformedBody() {
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v2/users", strings.NewReader("{")))
+
+	s.Equal(http.StatusBadRequest, rr.Code)
+	s.Equal("INVALID_ARGUMENT", s.errorOf(rr).Error.Code)
+}
+
+func (s *ApiV2RouterSuite) TestAPIKey() {
+	s.Run("known key", func() {
+		var principal *auth.Principal
+		s.userService.EXPECT().GetUser(mock.Anything, "1").
+			Run(func(args mock.Arguments) {
+				principal = auth.PrincipalFromContext(args.Get(0).(context.Context))
+			}).
+			Return(&userDomain.User{ID: 1}, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/users/1", nil)
+		req.Header.Set("X-API-Key", "billing-key")
+		rr := httptest.NewRecorder()
+		s.router.ServeHTTP(rr, req)
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Require().NotNil(principal)
+		s.Equal("billing", principal.Name)
+	})
+
+	s.Run("unknown key", func() {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/users/1", nil)
+		req.Header.Set("X-API-Key", "other-key")
+		rr := httptest.NewRecorder()
+		s.router.ServeHTTP(rr, req)
+
+		s.Equal(http.StatusUnauthorized, rr.Code)
+		s.Equal(auth.InvalidAPIKey.Code, s.errorOf(rr).Error.Code)
+	})
+}
+
+func (s *ApiV2RouterSuite) TestRequestID() {
+	var requestID string
+	s.userService.EXPECT().GetUser(mock.Anything, "1").
+		Run(func(args mock.Arguments) {
+			requestID = interceptor.GetRequestID(args.Get(0).(context.Context))
+		}).
+		Return(&userDomain.User{ID: 1}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v2/users/1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.RequestIDKey, "10.0.0.1-abcdef12"))
+	rr := httptest.NewRecorder()
+	s.router.ServeHTTP(rr, req)
+
+	s.Equal(http.StatusOK, rr.Code)
+	s.Equal("10.0.0.1-abcdef12", requestID)
+	// the HTTP RequestID middleware sends the header, the gateway doesn't repeat it as metadata
+	s.Empty(rr.Header().Values("Grpc-Metadata-X-Request-Id"))
+}
+
+func TestApiV2RouterSuite(t *testing.T) {
+	suite.Run(t, new(ApiV2RouterSuite))
+}
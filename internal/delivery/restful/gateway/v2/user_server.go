@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"google.golang.org/grpc"
+)
+
+// userServer runs the gateway calls through the gRPC interceptors, which the generated in-process handlers bypass.
+type userServer struct {
+	server      pb.UserServiceServer
+	interceptor grpc.UnaryServerInterceptor
+	pb.UnimplementedUserServiceServer
+}
+
+var _ pb.UserServiceServer = (*userServer)(nil)
+
+func (s *userServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	return invoke(ctx, s, pb.UserService_GetUser_FullMethodName, req, s.server.GetUser)
+}
+
+func (s *userServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	return invoke(ctx, s, pb.UserService_ListUsers_FullMethodName, req, s.server.ListUsers)
+}
+
+func (s *userServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.CreateUserResponse, error) {
+	return invoke(ctx, s, pb.UserService_CreateUser_FullMethodName, req, s.server.CreateUser)
+}
+
+func (s *userServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UpdateUserResponse, error) {
+	return invoke(ctx, s, pb.UserService_UpdateUser_FullMethodName, req, s.server.UpdateUser)
+}
+
+func invoke[Req, Resp any](ctx context.Context, s *userServer, method string, req Req, handler func(context.Context, Req) (Resp, error)) (Resp, error) {
+	info := &grpc.UnaryServerInfo{Server: s.server, FullMethod: method}
+	resp, err := s.interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return handler(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Resp
+		return zero, err
+	}
+	return resp.(Resp), nil
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	routerMiddleware "github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+)
+
+// BodyLimit overrides the router wide request body limit for a route. Reading past it fails with
+// *http.MaxBytesError, which is answered with 413.
+func BodyLimit(n int64) handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			routerMiddleware.SetMaxBodySize(w, r, n)
+			return next(w, r)
+		}
+	}
+}
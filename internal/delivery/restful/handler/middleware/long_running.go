@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	routerMiddleware "github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+)
+
+// deadlineGrace keeps the connection deadlines past the request timeout, so the response can still be written.
+const deadlineGrace = 5 * time.Second
+
+// LongRunning replaces the router wide request timeout with timeout, for handlers streaming large responses.
+func LongRunning(timeout time.Duration) handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			routerMiddleware.StopTimeout(r.Context())
+			extendDeadlines(w, timeout)
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			return next(w, r.WithContext(ctx))
+		}
+	}
+}
+
+// Timeout overrides the router wide request timeout for a route. Unlike LongRunning, a request running
+// past it is still answered with 504.
+func Timeout(timeout time.Duration) handler.Middleware {
+	return func(next handler.HandlerFunc) handler.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			if routerMiddleware.ResetTimeout(r.Context(), timeout) {
+				extendDeadlines(w, timeout)
+			}
+
+			return next(w, r)
+		}
+	}
+}
+
+// extendDeadlines moves the server read and write deadlines of the connection past timeout. Errors are
+// ignored, the server deadlines then stay in place.
+func extendDeadlines(w http.ResponseWriter, timeout time.Duration) {
+	deadline := time.Now().Add(timeout + deadlineGrace)
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+}
@@ -0,0 +1,84 @@
+package dataimport
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	dataImportSrv "github.com/umefy/go-web-app-template/internal/service/dataimport"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"github.com/umefy/godash/jsonkit"
+)
+
+func (h *dataImportHandler) ImportUsers(w http.ResponseWriter, r *http.Request) error {
+	return h.startImport(w, r, dataImportDomain.EntityUsers)
+}
+
+func (h *dataImportHandler) ImportOrders(w http.ResponseWriter, r *http.Request) error {
+	return h.startImport(w, r, dataImportDomain.EntityOrders)
+}
+
+func (h *dataImportHandler) startImport(w http.ResponseWriter, r *http.Request, entity dataImportDomain.Entity) error {
+	dataImport, err := h.uploadAndStartImport(r, entity)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return dataImportError.FileTooLarge
+	}
+	if err != nil {
+		return err
+	}
+
+	dataImportResp := mapping.DataImportModelToApiDataImport(dataImport)
+	resp := api.DataImportResponse{
+		Data: &dataImportResp,
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusAccepted, &resp)
+}
+
+func (h *dataImportHandler) uploadAndStartImport(r *http.Request, entity dataImportDomain.Entity) (*dataImportDomain.DataImport, error) {
+	source, err := uploadedFile(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.dataImportService.StartImport(r.Context(), &dataImportSrv.DataImportCreateInput{
+		Entity: entity,
+		Source: source,
+	})
+}
+
+// uploadedFile returns the CSV file of the request, either its text/csv body or the "file" field of a
+// multipart form. The multipart form is streamed, not parsed into memory.
+func uploadedFile(r *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, validation.NewValidateStructError(validation.Errors{
+				"file": validation.NewError("validation_required", "cannot be blank"),
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "file" {
+			return part, nil
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package dataimport
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+func (h *dataImportHandler) DownloadImportReport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	importID := r.PathValue("id")
+
+	dataImport, report, err := h.dataImportService.OpenImportReport(ctx, importID)
+	if err != nil {
+		return err
+	}
+	defer report.Close()
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-import-%d-report.csv"`, dataImport.Entity, dataImport.ID))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, an error can only be logged from here.
+	if _, err := io.Copy(w, report); err != nil {
+		h.logger.ErrorContext(ctx, "DataImportHandler.DownloadImportReport", slog.String("error", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package dataimport
+
+import (
+	"net/http"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	"github.com/umefy/godash/jsonkit"
+)
+
+func (h *dataImportHandler) GetImport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	importID := r.PathValue("id")
+
+	dataImport, err := h.dataImportService.GetImport(ctx, importID)
+	if err != nil {
+		return err
+	}
+
+	dataImportResp := mapping.DataImportModelToApiDataImport(dataImport)
+	resp := api.DataImportResponse{
+		Data: &dataImportResp,
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusOK, &resp)
+}
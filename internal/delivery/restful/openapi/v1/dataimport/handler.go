@@ -0,0 +1,67 @@
+package dataimport
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler/middleware"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	dataImportSrv "github.com/umefy/go-web-app-template/internal/service/dataimport"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
+)
+
+const (
+	// MaxUploadSize limits the size of an uploaded CSV file.
+	MaxUploadSize = 100 << 20
+	// uploadTimeout covers storing the file, the rows are imported in the background.
+	uploadTimeout = 10 * time.Minute
+)
+
+type Handler interface {
+	handler.Handler
+	handler.Router
+	ImportUsers(w http.ResponseWriter, r *http.Request) error
+	ImportOrders(w http.ResponseWriter, r *http.Request) error
+	GetImport(w http.ResponseWriter, r *http.Request) error
+	DownloadImportReport(w http.ResponseWriter, r *http.Request) error
+}
+
+type dataImportHandler struct {
+	*handler.DefaultHandler
+	dataImportService dataImportSrv.Service
+	logger            logger.Logger
+}
+
+const dataImportHandlerName = "DataImportHandler"
+
+var _ Handler = (*dataImportHandler)(nil)
+
+func NewHandler(dataImportService dataImportSrv.Service, logger logger.Logger) *dataImportHandler {
+	return &dataImportHandler{
+		DefaultHandler: handler.NewDefaultHandler(
+			dataImportHandlerName,
+			logger,
+		),
+		dataImportService: dataImportService,
+		logger:            logger,
+	}
+}
+
+// RegisterRoutes uses full paths for the uploads because "/users" and "/orders" are mounted by their own handlers.
+func (h *dataImportHandler) RegisterRoutes(r *router.Mux) {
+	r.Post("/users/imports", h.Handle(h.ApplyMiddlewares(
+		h.ImportUsers,
+		middleware.LongRunning(uploadTimeout),
+		middleware.BodyLimit(MaxUploadSize),
+	)))
+	r.Post("/orders/imports", h.Handle(h.ApplyMiddlewares(
+		h.ImportOrders,
+		middleware.LongRunning(uploadTimeout),
+		middleware.BodyLimit(MaxUploadSize),
+	)))
+	r.Route("/imports", func(r router.Router) {
+		r.Get("/{id}", h.Handle(h.GetImport))
+		r.Get("/{id}/report", h.Handle(h.DownloadImportReport))
+	})
+}
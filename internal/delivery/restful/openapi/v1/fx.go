@@ -4,6 +4,10 @@ import (
 	"net/http"
 
 	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/dataimport"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/order"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/privacy"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/search"
 	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/user"
 	"go.uber.org/fx"
 )
@@ -25,5 +29,25 @@ var Module = fx.Module("apiV1Router",
 			fx.As(new(handler.Router)),
 			fx.ResultTags(FX_TAG_GROUP_API_V1_ROUTERS),
 		),
+		fx.Annotate(
+			order.NewHandler,
+			fx.As(new(handler.Router)),
+			fx.ResultTags(FX_TAG_GROUP_API_V1_ROUTERS),
+		),
+		fx.Annotate(
+			privacy.NewHandler,
+			fx.As(new(handler.Router)),
+			fx.ResultTags(FX_TAG_GROUP_API_V1_ROUTERS),
+		),
+		fx.Annotate(
+			search.NewHandler,
+			fx.As(new(handler.Router)),
+			fx.ResultTags(FX_TAG_GROUP_API_V1_ROUTERS),
+		),
+		fx.Annotate(
+			dataimport.NewHandler,
+			fx.As(new(handler.Router)),
+			fx.ResultTags(FX_TAG_GROUP_API_V1_ROUTERS),
+		),
 	),
 )
@@ -0,0 +1,27 @@
+package mapping
+
+import (
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+)
+
+func DataImportModelToApiDataImport(dataImport *dataImportDomain.DataImport) api.DataImport {
+	apiDataImport := api.DataImport{
+		Id:            &dataImport.ID,
+		Entity:        string(dataImport.Entity),
+		Status:        string(dataImport.Status),
+		TotalRows:     dataImport.TotalRows,
+		ProcessedRows: dataImport.ProcessedRows,
+		ImportedRows:  dataImport.ImportedRows,
+		FailedRows:    dataImport.FailedRows,
+		CompletedAt:   dataImport.CompletedAt,
+		CreatedAt:     &dataImport.CreatedAt,
+		UpdatedAt:     &dataImport.UpdatedAt,
+	}
+
+	if dataImport.Error != "" {
+		apiDataImport.Error = &dataImport.Error
+	}
+
+	return apiDataImport
+}
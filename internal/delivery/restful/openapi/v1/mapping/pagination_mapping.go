@@ -20,3 +20,18 @@ func PaginationMetadataToApiPaginationMetadata(metadata *pagination.PaginationMe
 		Total:    cast.Int64PtrToIntPtr(metadata.Total),
 	}
 }
+
+func CursorPageInfoToApiCursorPageInfo(pageInfo *pagination.CursorPageInfo) *api.CursorPageInfo {
+
+	if pageInfo == nil {
+		return nil
+	}
+
+	return &api.CursorPageInfo{
+		HasNextPage:     pageInfo.HasNextPage,
+		HasPreviousPage: pageInfo.HasPreviousPage,
+		StartCursor:     pageInfo.StartCursor,
+		EndCursor:       pageInfo.EndCursor,
+		Total:           cast.Int64PtrToIntPtr(pageInfo.Total),
+	}
+}
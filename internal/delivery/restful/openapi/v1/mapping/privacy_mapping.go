@@ -0,0 +1,36 @@
+package mapping
+
+import (
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	privacySrv "github.com/umefy/go-web-app-template/internal/service/privacy"
+)
+
+func DataExportModelToApiDataExport(dataExport *privacyDomain.DataExport) api.DataExport {
+	apiDataExport := api.DataExport{
+		Id:          &dataExport.ID,
+		UserId:      dataExport.UserID,
+		Format:      string(dataExport.Format),
+		Status:      string(dataExport.Status),
+		CompletedAt: dataExport.CompletedAt,
+		CreatedAt:   &dataExport.CreatedAt,
+		UpdatedAt:   &dataExport.UpdatedAt,
+	}
+
+	if dataExport.Error != "" {
+		apiDataExport.Error = &dataExport.Error
+	}
+
+	return apiDataExport
+}
+
+func ApiDataExportCreateToDataExportCreateInput(input *api.DataExportCreate) *privacySrv.DataExportCreateInput {
+	format := privacyDomain.DataExportFormat(input.GetFormat())
+	if format == "" {
+		format = privacyDomain.DataExportFormatZIP
+	}
+
+	return &privacySrv.DataExportCreateInput{
+		Format: format,
+	}
+}
@@ -0,0 +1,32 @@
+package mapping
+
+import (
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+	"github.com/umefy/godash/sliceskit"
+)
+
+func SearchResultToApiSearchResult(result *searchDomain.Result) api.SearchResult {
+	apiSearchResult := api.SearchResult{
+		Type: string(result.Type),
+		Rank: result.Rank,
+		Highlights: sliceskit.Map(result.Highlights, func(highlight searchDomain.Highlight) api.SearchHighlight {
+			return api.SearchHighlight{
+				Field:   highlight.Field,
+				Snippet: highlight.Snippet,
+			}
+		}),
+	}
+
+	if result.User != nil {
+		user := UserModelToApiUser(result.User)
+		apiSearchResult.User = &user
+	}
+
+	if result.Order != nil {
+		order := OrderModelToApiOrder(result.Order)
+		apiSearchResult.Order = &order
+	}
+
+	return apiSearchResult
+}
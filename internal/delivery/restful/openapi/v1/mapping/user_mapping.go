@@ -1,9 +1,12 @@
 package mapping
 
 import (
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
 	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
 	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"github.com/umefy/godash/sliceskit"
 )
 
 func UserModelToApiUser(user *userDomain.User) api.User {
@@ -29,3 +32,50 @@ func ApiUserUpdateToUserModelUpdate(input *api.UserUpdate) *userSrv.UserUpdateIn
 		Age:   input.Age.Get(),
 	}
 }
+
+func ApiUserBatchCreateToUserBatchCreateInput(input *api.UserBatchCreate) *userSrv.UserBatchCreateInput {
+	return &userSrv.UserBatchCreateInput{
+		Mode: userSrv.BatchMode(input.GetMode()),
+		Items: sliceskit.Map(input.Items, func(item api.UserCreate) *userSrv.UserCreateInput {
+			return ApiUserCreateToUserModelCreate(&item)
+		}),
+	}
+}
+
+func UserBatchItemResultToApiUserBatchItemResult(item *userSrv.UserBatchItemResult) api.UserBatchItemResult {
+	apiItem := api.UserBatchItemResult{
+		Index:  item.Index,
+		Status: string(item.Status),
+	}
+
+	if item.User != nil {
+		user := UserModelToApiUser(item.User)
+		apiItem.Data = &user
+	}
+
+	if item.Err != nil {
+		apiItem.Error = ErrorToApiBatchItemError(item.Err)
+	}
+
+	return apiItem
+}
+
+// ErrorToApiBatchItemError formats err like the error body of a failed request.
+func ErrorToApiBatchItemError(err error) *api.BatchItemError {
+	_, errMap := errutil.FormatError(err)
+	errBody := errMap["error"].(map[string]any)
+
+	itemErr := &api.BatchItemError{
+		Code:    errBody["code"].(string),
+		Message: errBody["message"].(string),
+	}
+
+	if details, ok := errBody["details"].(validation.Errors); ok {
+		itemErr.Details = make(map[string]string, len(details))
+		for field, fieldErr := range details {
+			itemErr.Details[field] = fieldErr.Error()
+		}
+	}
+
+	return itemErr
+}
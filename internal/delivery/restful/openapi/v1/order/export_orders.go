@@ -0,0 +1,45 @@
+package order
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	restExport "github.com/umefy/go-web-app-template/internal/delivery/restful/export"
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	"github.com/umefy/go-web-app-template/pkg/export"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/godash/sliceskit"
+)
+
+var orderExportColumns = []export.Column[api.Order]{
+	{Name: "id", Value: func(order api.Order) string { return strconv.Itoa(*order.Id) }},
+	{Name: "userId", Value: func(order api.Order) string { return strconv.Itoa(order.UserId) }},
+	{Name: "amountCents", Value: func(order api.Order) string { return order.AmountCents }},
+	{Name: "createdAt", Value: func(order api.Order) string { return order.CreatedAt.Format(time.RFC3339) }},
+	{Name: "updatedAt", Value: func(order api.Order) string { return order.UpdatedAt.Format(time.RFC3339) }},
+}
+
+func (h *orderHandler) ExportOrders(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	h.logger.DebugContext(ctx, "ExportOrders")
+
+	listingQuery, err := listing.ParseURLValues(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	resp, err := restExport.NewResponse(w, r, "orders", orderExportColumns)
+	if err != nil {
+		return err
+	}
+
+	return restExport.Stream(resp, func() error {
+		return h.orderService.ExportOrders(ctx, listingQuery, func(orders []*orderDomain.Order) error {
+			return resp.Write(sliceskit.Map(orders, mapping.OrderModelToApiOrder))
+		})
+	})
+}
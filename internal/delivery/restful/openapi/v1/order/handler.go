@@ -0,0 +1,48 @@
+package order
+
+import (
+	"net/http"
+
+	restExport "github.com/umefy/go-web-app-template/internal/delivery/restful/export"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler/middleware"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	orderSrv "github.com/umefy/go-web-app-template/internal/service/order"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
+)
+
+type Handler interface {
+	handler.Handler
+	handler.Router
+	ExportOrders(w http.ResponseWriter, r *http.Request) error
+}
+
+type orderHandler struct {
+	*handler.DefaultHandler
+	orderService orderSrv.Service
+	logger       logger.Logger
+}
+
+const orderHandlerName = "OrderHandler"
+
+var _ Handler = (*orderHandler)(nil)
+
+func NewHandler(orderService orderSrv.Service, logger logger.Logger) *orderHandler {
+	return &orderHandler{
+		DefaultHandler: handler.NewDefaultHandler(
+			orderHandlerName,
+			logger,
+		),
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+func (h *orderHandler) RegisterRoutes(r *router.Mux) {
+	r.Route("/orders", func(r router.Router) {
+		r.Get("/export", h.Handle(h.ApplyMiddlewares(
+			h.ExportOrders,
+			middleware.LongRunning(restExport.Timeout),
+		)))
+	})
+}
@@ -0,0 +1,39 @@
+package privacy
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+)
+
+func (h *privacyHandler) DownloadDataExport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	userID := r.PathValue("id")
+	exportID := r.PathValue("exportId")
+
+	dataExport, archive, err := h.privacyService.OpenDataExport(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+
+	contentType, ext := "application/zip", "zip"
+	if dataExport.Format == privacyDomain.DataExportFormatJSON {
+		contentType, ext = "application/json", "json"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="user-%d-data-export-%d.%s"`, dataExport.UserID, dataExport.ID, ext))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, an error can only be logged from here.
+	if _, err := io.Copy(w, archive); err != nil {
+		h.logger.ErrorContext(ctx, "PrivacyHandler.DownloadDataExport", slog.String("error", err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package privacy
+
+import (
+	"net/http"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	"github.com/umefy/godash/jsonkit"
+)
+
+func (h *privacyHandler) GetDataExport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	userID := r.PathValue("id")
+	exportID := r.PathValue("exportId")
+
+	dataExport, err := h.privacyService.GetDataExport(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+
+	dataExportResp := mapping.DataExportModelToApiDataExport(dataExport)
+	resp := api.DataExportResponse{
+		Data: &dataExportResp,
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusOK, &resp)
+}
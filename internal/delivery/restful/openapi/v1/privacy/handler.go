@@ -0,0 +1,55 @@
+package privacy
+
+import (
+	"net/http"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler/middleware"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	privacySrv "github.com/umefy/go-web-app-template/internal/service/privacy"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
+)
+
+type Handler interface {
+	handler.Handler
+	handler.Router
+	RequestDataExport(w http.ResponseWriter, r *http.Request) error
+	GetDataExport(w http.ResponseWriter, r *http.Request) error
+	DownloadDataExport(w http.ResponseWriter, r *http.Request) error
+	EraseUser(w http.ResponseWriter, r *http.Request) error
+}
+
+type privacyHandler struct {
+	*handler.DefaultHandler
+	privacyService privacySrv.Service
+	logger         logger.Logger
+	dbQuery        *database.Query
+}
+
+const privacyHandlerName = "PrivacyHandler"
+
+var _ Handler = (*privacyHandler)(nil)
+
+func NewHandler(privacyService privacySrv.Service, logger logger.Logger, dbQuery *database.Query) *privacyHandler {
+	return &privacyHandler{
+		DefaultHandler: handler.NewDefaultHandler(
+			privacyHandlerName,
+			logger,
+		),
+		privacyService: privacyService,
+		logger:         logger,
+		dbQuery:        dbQuery,
+	}
+}
+
+// RegisterRoutes uses full paths because "/users" is already mounted by the user handler.
+func (h *privacyHandler) RegisterRoutes(r *router.Mux) {
+	r.Post("/users/{id}/data-exports", h.Handle(h.RequestDataExport))
+	r.Get("/users/{id}/data-exports/{exportId}", h.Handle(h.GetDataExport))
+	r.Get("/users/{id}/data-exports/{exportId}/download", h.Handle(h.DownloadDataExport))
+	r.Post("/users/{id}/erasure", h.Handle(h.ApplyMiddlewares(
+		h.EraseUser,
+		middleware.Transaction(h.dbQuery, h.logger),
+	)))
+}
@@ -0,0 +1,37 @@
+package privacy
+
+import (
+	"net/http"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	"github.com/umefy/godash/jsonkit"
+)
+
+func (h *privacyHandler) RequestDataExport(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	// The body is optional, the format defaults to zip.
+	var input api.DataExportCreate
+	if r.ContentLength != 0 {
+		if err := jsonkit.BindRequestBody(r, &input); err != nil {
+			return err
+		}
+	}
+
+	dataExportCreateInput := mapping.ApiDataExportCreateToDataExportCreateInput(&input)
+
+	userID := r.PathValue("id")
+
+	dataExport, err := h.privacyService.RequestDataExport(ctx, userID, dataExportCreateInput)
+	if err != nil {
+		return err
+	}
+
+	dataExportResp := mapping.DataExportModelToApiDataExport(dataExport)
+	resp := api.DataExportResponse{
+		Data: &dataExportResp,
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusAccepted, &resp)
+}
@@ -0,0 +1,41 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	searchSrv "github.com/umefy/go-web-app-template/internal/service/search"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
+)
+
+type Handler interface {
+	handler.Handler
+	handler.Router
+	Search(w http.ResponseWriter, r *http.Request) error
+}
+
+type searchHandler struct {
+	*handler.DefaultHandler
+	searchService searchSrv.Service
+	logger        logger.Logger
+}
+
+const searchHandlerName = "SearchHandler"
+
+var _ Handler = (*searchHandler)(nil)
+
+func NewHandler(searchService searchSrv.Service, logger logger.Logger) *searchHandler {
+	return &searchHandler{
+		DefaultHandler: handler.NewDefaultHandler(
+			searchHandlerName,
+			logger,
+		),
+		searchService: searchService,
+		logger:        logger,
+	}
+}
+
+func (h *searchHandler) RegisterRoutes(r *router.Mux) {
+	r.Get("/search", h.Handle(h.Search))
+}
@@ -0,0 +1,35 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	searchSrv "github.com/umefy/go-web-app-template/internal/service/search"
+	"github.com/umefy/godash/jsonkit"
+	"github.com/umefy/godash/sliceskit"
+)
+
+func (h *searchHandler) Search(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+
+	// an invalid limit falls back to the default like the pagination params do
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil {
+		limit = searchSrv.DefaultLimit
+	}
+
+	results, err := h.searchService.Search(ctx, searchSrv.NewSearchInput(query.Get("q"), limit))
+	if err != nil {
+		return err
+	}
+
+	resp := api.SearchResponse{
+		Data: sliceskit.Map(results, mapping.SearchResultToApiSearchResult),
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusOK, &resp)
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"net/http"
+
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	"github.com/umefy/godash/jsonkit"
+	"github.com/umefy/godash/sliceskit"
+)
+
+func (h *userHandler) CreateUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	h.logger.DebugContext(ctx, "CreateUsers")
+
+	var batchInput api.UserBatchCreate
+	if err := jsonkit.BindRequestBody(r, &batchInput); err != nil {
+		return err
+	}
+
+	result, err := h.userService.CreateUsers(ctx, mapping.ApiUserBatchCreateToUserBatchCreateInput(&batchInput))
+	if err != nil {
+		return err
+	}
+
+	resp := api.UserBatchCreateResponse{
+		Data:    sliceskit.Map(result.Items, mapping.UserBatchItemResultToApiUserBatchItemResult),
+		Created: result.Count(userSrv.BatchItemStatusCreated),
+		Failed:  result.Count(userSrv.BatchItemStatusFailed),
+	}
+
+	// 207 tells clients to look at the per item statuses
+	status := http.StatusOK
+	if resp.Created != len(result.Items) {
+		status = http.StatusMultiStatus
+	}
+
+	return jsonkit.JSONResponse(w, status, &resp)
+}
@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	userSrv "github.com/umefy/go-web-app-template/internal/service/user"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	userSrvMocks "github.com/umefy/go-web-app-template/mocks/service/user"
+)
+
+type CreateUsersSuite struct {
+	suite.Suite
+	userService *userSrvMocks.MockService
+	handler     Handler
+}
+
+func (s *CreateUsersSuite) SetupTest() {
+	s.userService = userSrvMocks.NewMockService(s.T())
+	logger := loggerMocks.NewMockLogger(s.T())
+	logger.EXPECT().DebugContext(context.Background(), "CreateUsers")
+
+	s.handler = NewHandler(s.userService, logger, nil)
+}
+
+func (s *CreateUsersSuite) createUsers(body string) (*httptest.ResponseRecorder, error) {
+	req := httptest.NewRequest(http.MethodPost, "/users:batch", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	err := s.handler.CreateUsers(rec, req)
+	return rec, err
+}
+
+func (s *CreateUsersSuite) responseOf(rec *httptest.ResponseRecorder) api.UserBatchCreateResponse {
+	var resp api.UserBatchCreateResponse
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func (s *CreateUsersSuite) TestAllCreated() {
+	s.userService.EXPECT().CreateUsers(context.Background(), &userSrv.UserBatchCreateInput{
+		Mode:  userSrv.BatchModePartial,
+		Items: []*userSrv.UserCreateInput{{Email: "john@example.com", Age: 30}},
+	}).Return(&userSrv.UserBatchCreateResult{Items: []*userSrv.UserBatchItemResult{
+		{Index: 0, Status: userSrv.BatchItemStatusCreated, User: &userDomain.User{ID: 1, Email: "john@example.com", Age: 30}},
+	}}, nil)
+
+	rec, err := s.createUsers(`{"mode":"partial","items":[{"email":"john@example.com","age":30}]}`)
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusOK, rec.Code)
+	resp := s.responseOf(rec)
+	s.Equal(1, resp.Created)
+	s.Zero(resp.Failed)
+	s.Require().Len(resp.Data, 1)
+	s.Equal("created", resp.Data[0].Status)
+	s.Require().NotNil(resp.Data[0].Data)
+	s.Equal("john@example.com", resp.Data[0].Data.Email)
+	s.Nil(resp.Data[0].Error)
+}
+
+func (s *CreateUsersSuite) TestPartial() {
+	itemErr := (&userSrv.UserCreateInput{Email: "not an email"}).Validate()
+	s.Require().Error(itemErr)
+
+	s.userService.EXPECT().CreateUsers(context.Background(), &userSrv.UserBatchCreateInput{
+		Mode: userSrv.BatchModePartial,
+		Items: []*userSrv.UserCreateInput{
+			{Email: "john@example.com", Age: 30},
+			{Email: "not an email"},
+			{Email: "jane@example.com"},
+			{Email: "john@example.com"},
+		},
+	}).Return(&userSrv.UserBatchCreateResult{Items: []*userSrv.UserBatchItemResult{
+		{Index: 0, Status: userSrv.BatchItemStatusCreated, User: &userDomain.User{ID: 1, Email: "john@example.com", Age: 30}},
+		{Index: 1, Status: userSrv.BatchItemStatusFailed, Err: itemErr},
+		{Index: 2, Status: userSrv.BatchItemStatusFailed, Err: userError.UserAlreadyExists},
+		{Index: 3, Status: userSrv.BatchItemStatusFailed, Err: userError.DuplicateBatchUser},
+	}}, nil)
+
+	rec, err := s.createUsers(`{"mode":"partial","items":[` +
+		`{"email":"john@example.com","age":30},{"email":"not an email"},{"email":"jane@example.com"},{"email":"john@example.com"}]}`)
+	s.Require().NoError(err)
+
+	// 207 as soon as one item was not created
+	s.Equal(http.StatusMultiStatus, rec.Code)
+	resp := s.responseOf(rec)
+	s.Equal(1, resp.Created)
+	s.Equal(3, resp.Failed)
+
+	s.Require().Len(resp.Data, 4)
+	s.Equal([]string{"created", "failed", "failed", "failed"}, []string{
+		resp.Data[0].Status, resp.Data[1].Status, resp.Data[2].Status, resp.Data[3].Status,
+	})
+	s.Require().NotNil(resp.Data[1].Error)
+	s.Equal("VALIDATION_ERROR", resp.Data[1].Error.Code)
+	s.Contains(resp.Data[1].Error.Details, "Email")
+	s.Nil(resp.Data[1].Data)
+	s.Equal(userError.UserAlreadyExists.Code, resp.Data[2].Error.Code)
+	s.Equal(userError.DuplicateBatchUser.Code, resp.Data[3].Error.Code)
+}
+
+func (s *CreateUsersSuite) TestAtomicByDefault() {
+	s.userService.EXPECT().CreateUsers(context.Background(), &userSrv.UserBatchCreateInput{
+		Mode:  userSrv.BatchModeAtomic,
+		Items: []*userSrv.UserCreateInput{{Email: "john@example.com"}, {Email: "jane@example.com"}},
+	}).Return(&userSrv.UserBatchCreateResult{Items: []*userSrv.UserBatchItemResult{
+		{Index: 0, Status: userSrv.BatchItemStatusSkipped},
+		{Index: 1, Status: userSrv.BatchItemStatusFailed, Err: userError.UserAlreadyExists},
+	}}, nil)
+
+	rec, err := s.createUsers(`{"items":[{"email":"john@example.com"},{"email":"jane@example.com"}]}`)
+	s.Require().NoError(err)
+
+	s.Equal(http.StatusMultiStatus, rec.Code)
+	resp := s.responseOf(rec)
+	s.Zero(resp.Created)
+	s.Equal(1, resp.Failed)
+	s.Equal("skipped", resp.Data[0].Status)
+	s.Nil(resp.Data[0].Error)
+}
+
+func (s *CreateUsersSuite) TestServiceError() {
+	serviceErr := errors.New("database is down")
+	s.userService.EXPECT().CreateUsers(context.Background(), &userSrv.UserBatchCreateInput{
+		Mode:  userSrv.BatchModeAtomic,
+		Items: []*userSrv.UserCreateInput{{Email: "john@example.com"}},
+	}).Return(nil, serviceErr)
+
+	// the error is written by the handler wrapper and rolls back the transaction
+	_, err := s.createUsers(`{"items":[{"email":"john@example.com"}]}`)
+	s.ErrorIs(err, serviceErr)
+}
+
+func TestCreateUsersSuite(t *testing.T) {
+	suite.Run(t, new(CreateUsersSuite))
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	restExport "github.com/umefy/go-web-app-template/internal/delivery/restful/export"
+	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
+	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	"github.com/umefy/go-web-app-template/pkg/export"
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"github.com/umefy/godash/sliceskit"
+)
+
+var userExportColumns = []export.Column[api.User]{
+	{Name: "id", Value: func(user api.User) string { return strconv.Itoa(*user.Id) }},
+	{Name: "email", Value: func(user api.User) string { return user.Email }},
+	{Name: "age", Value: func(user api.User) string { return strconv.Itoa(user.Age) }},
+	{Name: "createdAt", Value: func(user api.User) string { return user.CreatedAt.Format(time.RFC3339) }},
+	{Name: "updatedAt", Value: func(user api.User) string { return user.UpdatedAt.Format(time.RFC3339) }},
+}
+
+func (h *userHandler) ExportUsers(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+
+	h.logger.DebugContext(ctx, "ExportUsers")
+
+	listingQuery, err := listing.ParseURLValues(r.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	resp, err := restExport.NewResponse(w, r, "users", userExportColumns)
+	if err != nil {
+		return err
+	}
+
+	return restExport.Stream(resp, func() error {
+		return h.userService.ExportUsers(ctx, listingQuery, func(users []*userDomain.User) error {
+			return resp.Write(sliceskit.Map(users, mapping.UserModelToApiUser))
+		})
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	api "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/generated"
 	"github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1/mapping"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
 	"github.com/umefy/godash/jsonkit"
 	"github.com/umefy/godash/sliceskit"
@@ -17,7 +18,17 @@ func (h *userHandler) GetUsers(w http.ResponseWriter, r *http.Request) error {
 
 	query := r.URL.Query()
 
-	users, paginationMetadata, err := h.userService.GetUsers(ctx, pagination.NewFromQueryParams(query.Get("offset"), query.Get("pageSize"), query.Get("includeTotal")))
+	listingQuery, err := listing.ParseURLValues(query)
+	if err != nil {
+		return err
+	}
+
+	// after/before switch to cursor pagination, an empty value asks for the first page
+	if query.Has("after") || query.Has("before") {
+		return h.getUsersByCursor(w, r, listingQuery)
+	}
+
+	users, paginationMetadata, err := h.userService.GetUsers(ctx, pagination.NewFromQueryParams(query.Get("offset"), query.Get("pageSize"), query.Get("includeTotal")), listingQuery)
 	if err != nil {
 		return err
 	}
@@ -29,3 +40,26 @@ func (h *userHandler) GetUsers(w http.ResponseWriter, r *http.Request) error {
 
 	return jsonkit.JSONResponse(w, http.StatusOK, &resp)
 }
+
+func (h *userHandler) getUsersByCursor(w http.ResponseWriter, r *http.Request, listingQuery listing.RawQuery) error {
+	ctx := r.Context()
+
+	query := r.URL.Query()
+	p := pagination.NewCursorPaginationFromQueryParams(query.Get("pageSize"), query.Get("after"), query.Get("before"), query.Get("includeTotal"))
+
+	connection, err := h.userService.GetUsersByCursor(ctx, p, listingQuery)
+	if err != nil {
+		return err
+	}
+
+	if link := pagination.LinkHeader(r.URL, p.PageSize, connection.PageInfo); link != "" {
+		w.Header().Set("Link", link)
+	}
+
+	resp := api.UserGetAllResponse{
+		Data:       sliceskit.Map(connection.Nodes(), mapping.UserModelToApiUser),
+		CursorInfo: mapping.CursorPageInfoToApiCursorPageInfo(&connection.PageInfo),
+	}
+
+	return jsonkit.JSONResponse(w, http.StatusOK, &resp)
+}
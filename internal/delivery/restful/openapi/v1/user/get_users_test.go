@@ -10,6 +10,7 @@ import (
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
 	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
 	userSrvMocks "github.com/umefy/go-web-app-template/mocks/service/user"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
 )
 
@@ -29,7 +30,7 @@ func (s *GetUsersSuite) TestGetUsers() {
 		},
 	}
 
-	userService.EXPECT().GetUsers(context.Background(), pagination.NewFromQueryParams("0", "25", "false")).Return(users, nil, nil)
+	userService.EXPECT().GetUsers(context.Background(), pagination.NewFromQueryParams("0", "25", "false"), listing.RawQuery{}).Return(users, nil, nil)
 	logger.EXPECT().DebugContext(context.Background(), "GetUsers")
 
 	h := NewHandler(userService, logger, nil)
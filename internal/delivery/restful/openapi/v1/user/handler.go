@@ -3,6 +3,7 @@ package user
 import (
 	"net/http"
 
+	restExport "github.com/umefy/go-web-app-template/internal/delivery/restful/export"
 	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler"
 	"github.com/umefy/go-web-app-template/internal/delivery/restful/handler/middleware"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
@@ -16,7 +17,9 @@ type Handler interface {
 	handler.Router
 	GetUsers(w http.ResponseWriter, r *http.Request) error
 	GetUser(w http.ResponseWriter, r *http.Request) error
+	ExportUsers(w http.ResponseWriter, r *http.Request) error
 	CreateUser(w http.ResponseWriter, r *http.Request) error
+	CreateUsers(w http.ResponseWriter, r *http.Request) error
 	UpdateUser(w http.ResponseWriter, r *http.Request) error
 }
 
@@ -47,6 +50,10 @@ func (h *userHandler) RegisterRoutes(r *router.Mux) {
 	r.Route("/users", func(r router.Router) {
 
 		r.Get("/", h.Handle(h.GetUsers))
+		r.Get("/export", h.Handle(h.ApplyMiddlewares(
+			h.ExportUsers,
+			middleware.LongRunning(restExport.Timeout),
+		)))
 		r.Get("/{id}", h.Handle(h.GetUser))
 		r.Post("/", h.Handle(h.ApplyMiddlewares(
 			h.CreateUser,
@@ -57,6 +64,12 @@ func (h *userHandler) RegisterRoutes(r *router.Mux) {
 			middleware.Transaction(h.dbQuery, h.logger),
 		)))
 	})
+
+	// registered outside of "/users" since the sub router only matches "/users/..." paths
+	r.Post("/users:batch", h.Handle(h.ApplyMiddlewares(
+		h.CreateUsers,
+		middleware.Transaction(h.dbQuery, h.logger),
+	)))
 }
 
 // // Custom error handler
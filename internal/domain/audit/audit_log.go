@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"time"
+)
+
+const (
+	EntityTypeUser = "user"
+)
+
+type AuditLog struct {
+	ID         int
+	Action     string
+	EntityType string
+	EntityID   int
+	RequestID  string
+	Metadata   map[string]any
+	CreatedAt  time.Time
+}
@@ -0,0 +1,12 @@
+package repo
+
+import (
+	"context"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+)
+
+type Repository interface {
+	CreateAuditLog(ctx context.Context, auditLog *auditDomain.AuditLog) (*auditDomain.AuditLog, error)
+	FindAuditLogsByEntity(ctx context.Context, entityType string, entityID int) ([]*auditDomain.AuditLog, error)
+}
@@ -0,0 +1,39 @@
+package dataimport
+
+import (
+	"time"
+)
+
+type Entity string
+
+const (
+	EntityUsers  Entity = "users"
+	EntityOrders Entity = "orders"
+)
+
+type Status string
+
+const (
+	StatusPending    Status = "pending"
+	StatusProcessing Status = "processing"
+	StatusCompleted  Status = "completed"
+	StatusFailed     Status = "failed"
+)
+
+// DataImport tracks the ingestion of an uploaded CSV file. Rows are counted from the first data row,
+// the header is not included.
+type DataImport struct {
+	ID            int
+	Entity        Entity
+	Status        Status
+	SourceKey     string
+	ReportKey     string
+	TotalRows     int
+	ProcessedRows int
+	ImportedRows  int
+	FailedRows    int
+	Error         string
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
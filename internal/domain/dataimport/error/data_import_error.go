@@ -0,0 +1,21 @@
+package error
+
+import (
+	"fmt"
+	"net/http"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+)
+
+const (
+	serviceName = "dataImportService"
+)
+
+var (
+	DataImportNotFound = appError.NewError(fmt.Sprintf("%s_1001", serviceName), "data import not found", http.StatusNotFound)
+	DataImportNotReady = appError.NewError(fmt.Sprintf("%s_1002", serviceName), "data import report is not ready for download", http.StatusConflict)
+	InvalidCSV         = appError.NewError(fmt.Sprintf("%s_1003", serviceName), "file is not valid CSV", http.StatusBadRequest)
+	EmptyFile          = appError.NewError(fmt.Sprintf("%s_1004", serviceName), "CSV file has no data rows", http.StatusBadRequest)
+	FileTooLarge       = appError.NewError(fmt.Sprintf("%s_1005", serviceName), "file exceeds the upload size limit", http.StatusRequestEntityTooLarge)
+	DuplicateEmail     = appError.NewError(fmt.Sprintf("%s_1006", serviceName), "email appears more than once in the file", http.StatusBadRequest)
+)
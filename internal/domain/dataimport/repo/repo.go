@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+)
+
+type Repository interface {
+	CreateDataImport(ctx context.Context, dataImport *dataImportDomain.DataImport) (*dataImportDomain.DataImport, error)
+	FindDataImport(ctx context.Context, id int) (*dataImportDomain.DataImport, error)
+	UpdateDataImport(ctx context.Context, dataImport *dataImportDomain.DataImport) (*dataImportDomain.DataImport, error)
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 
 	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 )
 
 type Repository interface {
 	FindOrdersByUserID(ctx context.Context, userID int) ([]*orderDomain.Order, error)
 	FindOrdersByUserIDs(ctx context.Context, userIDs []int) ([]*orderDomain.Order, error)
+	// CreateOrders must run in a transaction.
+	CreateOrders(ctx context.Context, orders []*orderDomain.Order) ([]*orderDomain.Order, error)
+	// StreamOrders calls fn with batches of the orders matching q, in q's order, until all are read or fn fails.
+	StreamOrders(ctx context.Context, q listing.Query, fn func([]*orderDomain.Order) error) error
 }
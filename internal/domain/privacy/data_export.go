@@ -0,0 +1,35 @@
+package privacy
+
+import (
+	"time"
+)
+
+type DataExportFormat string
+
+const (
+	DataExportFormatJSON DataExportFormat = "json"
+	DataExportFormatZIP  DataExportFormat = "zip"
+)
+
+type DataExportStatus string
+
+const (
+	DataExportStatusPending    DataExportStatus = "pending"
+	DataExportStatusProcessing DataExportStatus = "processing"
+	DataExportStatusCompleted  DataExportStatus = "completed"
+	DataExportStatusFailed     DataExportStatus = "failed"
+	// DataExportStatusPurged means the archive was deleted, e.g. because the user was erased.
+	DataExportStatusPurged DataExportStatus = "purged"
+)
+
+type DataExport struct {
+	ID          int
+	UserID      int
+	Format      DataExportFormat
+	Status      DataExportStatus
+	StorageKey  string
+	Error       string
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
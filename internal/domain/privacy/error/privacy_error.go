@@ -0,0 +1,18 @@
+package error
+
+import (
+	"fmt"
+	"net/http"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+)
+
+const (
+	serviceName = "privacyService"
+)
+
+var (
+	DataExportNotFound = appError.NewError(fmt.Sprintf("%s_1001", serviceName), "data export not found", http.StatusNotFound)
+	DataExportNotReady = appError.NewError(fmt.Sprintf("%s_1002", serviceName), "data export is not ready for download", http.StatusConflict)
+	UserAlreadyErased  = appError.NewError(fmt.Sprintf("%s_1003", serviceName), "user has already been erased", http.StatusConflict)
+)
@@ -0,0 +1,14 @@
+package repo
+
+import (
+	"context"
+
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+)
+
+type Repository interface {
+	CreateDataExport(ctx context.Context, dataExport *privacyDomain.DataExport) (*privacyDomain.DataExport, error)
+	FindDataExport(ctx context.Context, id int) (*privacyDomain.DataExport, error)
+	FindDataExportsByUserID(ctx context.Context, userID int) ([]*privacyDomain.DataExport, error)
+	UpdateDataExport(ctx context.Context, dataExport *privacyDomain.DataExport) (*privacyDomain.DataExport, error)
+}
@@ -0,0 +1,12 @@
+package repo
+
+import (
+	"context"
+
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+)
+
+type Repository interface {
+	// Search returns at most limit users and orders matching text, ordered by rank.
+	Search(ctx context.Context, text string, limit int) ([]*searchDomain.Result, error)
+}
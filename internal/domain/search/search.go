@@ -0,0 +1,29 @@
+package search
+
+import (
+	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+)
+
+type ResultType string
+
+const (
+	ResultTypeUser  ResultType = "user"
+	ResultTypeOrder ResultType = "order"
+)
+
+// Result is a single search hit, exactly one of User and Order is set according to Type.
+type Result struct {
+	Type  ResultType
+	Rank  float64
+	User  *userDomain.User
+	Order *orderDomain.Order
+	// Document holds the searchable text of the hit by field name, it is what highlights are built from.
+	Document   map[string]string
+	Highlights []Highlight
+}
+
+type Highlight struct {
+	Field   string
+	Snippet string
+}
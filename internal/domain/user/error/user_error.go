@@ -15,4 +15,6 @@ var (
 	UserNotFound       = appError.NewError(fmt.Sprintf("%s_1001", serviceName), "user not found", http.StatusNotFound)
 	UserAlreadyExists  = appError.NewError(fmt.Sprintf("%s_1002", serviceName), "user already exists", http.StatusBadRequest)
 	UserUpdateConflict = appError.NewError(fmt.Sprintf("%s_1003", serviceName), "user update conflict - version mismatch", http.StatusConflict)
+	InvalidCursor      = appError.NewError(fmt.Sprintf("%s_1004", serviceName), "invalid pagination cursor", http.StatusBadRequest)
+	DuplicateBatchUser = appError.NewError(fmt.Sprintf("%s_1005", serviceName), "user email appears more than once in the batch", http.StatusBadRequest)
 )
@@ -4,14 +4,22 @@ import (
 	"context"
 
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
 )
 
 type Repository interface {
 	FindUser(ctx context.Context, id int) (*userDomain.User, error)
-	FindUsers(ctx context.Context, p pagination.Pagination) ([]*userDomain.User, *pagination.PaginationMetadata, error)
+	FindUsers(ctx context.Context, p pagination.Pagination, q listing.Query) ([]*userDomain.User, *pagination.PaginationMetadata, error)
+	FindUsersByKeyset(ctx context.Context, p pagination.Keyset, q listing.Query) (*pagination.KeysetResult[*userDomain.User], error)
+	// StreamUsers calls fn with batches of the users matching q, in q's order, until all are read or fn fails.
+	StreamUsers(ctx context.Context, q listing.Query, fn func([]*userDomain.User) error) error
 	CreateUser(ctx context.Context, user *userDomain.User) (*userDomain.User, error)
+	CreateUsers(ctx context.Context, users []*userDomain.User) ([]*userDomain.User, error)
 	UpdateUser(ctx context.Context, id int, user *userDomain.User) (*userDomain.User, error)
 	IsUserEmailExists(ctx context.Context, email string) (bool, error)
+	FindExistingEmails(ctx context.Context, emails []string) ([]string, error)
+	// FindExistingUserIDs returns the ids among ids that belong to users who have not been erased.
+	FindExistingUserIDs(ctx context.Context, ids []int) ([]int, error)
 	FindUserWithOrders(ctx context.Context, id int) (*userDomain.UserWithOrder, error)
 }
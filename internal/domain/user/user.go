@@ -8,9 +8,10 @@ import (
 
 type User struct {
 	ID        int
-	Email     string
+	Email     string `log:"redact"`
 	Age       int
 	Version   optimisticlock.Version
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	ErasedAt  *time.Time // set once the user's personal data has been erased
 }
@@ -3,3 +3,8 @@ package ctx
 type transactionKey struct{}
 
 var TransactionCtxKey = transactionKey{}
+
+type afterCommitKey struct{}
+
+// AfterCommitCtxKey holds the functions to run once the transaction is committed, see gorm.AfterCommit.
+var AfterCommitCtxKey = afterCommitKey{}
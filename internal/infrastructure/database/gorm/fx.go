@@ -1,9 +1,14 @@
 package gorm
 
 import (
+	auditRepo "github.com/umefy/go-web-app-template/internal/domain/audit/repo"
+	dataImportRepo "github.com/umefy/go-web-app-template/internal/domain/dataimport/repo"
 	orderRepo "github.com/umefy/go-web-app-template/internal/domain/order/repo"
+	privacyRepo "github.com/umefy/go-web-app-template/internal/domain/privacy/repo"
+	searchRepo "github.com/umefy/go-web-app-template/internal/domain/search/repo"
 	userRepo "github.com/umefy/go-web-app-template/internal/domain/user/repo"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/health"
 	"go.uber.org/fx"
 )
 
@@ -11,6 +16,10 @@ var Module = fx.Module("gorm",
 	fx.Provide(
 		NewDB,
 		NewDBQuery,
+		fx.Annotate(
+			NewHealthCheckers,
+			fx.ResultTags(health.FX_TAG_GROUP_HEALTH_CHECKERS),
+		),
 		fx.Annotate(
 			repo.NewUserRepository,
 			fx.As(new(userRepo.Repository)),
@@ -19,5 +28,21 @@ var Module = fx.Module("gorm",
 			repo.NewOrderRepository,
 			fx.As(new(orderRepo.Repository)),
 		),
+		fx.Annotate(
+			repo.NewAuditRepository,
+			fx.As(new(auditRepo.Repository)),
+		),
+		fx.Annotate(
+			repo.NewPrivacyRepository,
+			fx.As(new(privacyRepo.Repository)),
+		),
+		fx.Annotate(
+			repo.NewSearchRepository,
+			fx.As(new(searchRepo.Repository)),
+		),
+		fx.Annotate(
+			repo.NewDataImportRepository,
+			fx.As(new(dataImportRepo.Repository)),
+		),
 	),
 )
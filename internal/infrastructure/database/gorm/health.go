@@ -0,0 +1,16 @@
+package gorm
+
+import (
+	db "github.com/umefy/go-web-app-template/pkg/db/gormdb"
+	"github.com/umefy/go-web-app-template/pkg/health"
+)
+
+// NewHealthCheckers makes the database critical, nothing can be served without it.
+func NewHealthCheckers(db *db.DB) ([]health.Checker, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	return []health.Checker{health.Ping("database", true, sqlDB)}, nil
+}
@@ -0,0 +1,36 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/umefy/go-web-app-template/pkg/redact"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+// redactLogger redacts the SQL params bound to sensitive columns, they are only logged with show_sql_params.
+type redactLogger struct {
+	gormLogger.Interface
+	redactor *redact.Redactor
+}
+
+var _ interface {
+	gormLogger.Interface
+	gorm.ParamsFilter
+} = (*redactLogger)(nil)
+
+func newRedactLogger(logger gormLogger.Interface, redactor *redact.Redactor) *redactLogger {
+	return &redactLogger{Interface: logger, redactor: redactor}
+}
+
+// LogMode keeps the redaction on the loggers of db.Debug() and of the sessions.
+func (l *redactLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	return newRedactLogger(l.Interface.LogMode(level), l.redactor)
+}
+
+func (l *redactLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if filter, ok := l.Interface.(gorm.ParamsFilter); ok {
+		sql, params = filter.ParamsFilter(ctx, sql, params...)
+	}
+	return sql, l.redactor.SQLParams(sql, params)
+}
@@ -0,0 +1,59 @@
+package repo
+
+import (
+	"context"
+	"log/slog"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	auditRepo "github.com/umefy/go-web-app-template/internal/domain/audit/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/null"
+	"github.com/umefy/godash/sliceskit"
+)
+
+type AuditRepo struct {
+	Logger  logger.Logger
+	dbQuery *query.Query
+}
+
+var _ auditRepo.Repository = (*AuditRepo)(nil)
+
+func NewAuditRepository(dbQuery *query.Query, logger logger.Logger) *AuditRepo {
+	return &AuditRepo{Logger: logger, dbQuery: dbQuery}
+}
+
+// CreateAuditLog joins the request transaction when there is one, so the audit entry is only kept
+// if the audited change is committed.
+func (r *AuditRepo) CreateAuditLog(ctx context.Context, auditLog *auditDomain.AuditLog) (*auditDomain.AuditLog, error) {
+	auditLogQuery := queryFromContext(ctx, r.dbQuery).AuditLog
+
+	dbModel, err := mapping.DomainAuditLogToDbModel(auditLog)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "AuditRepository.CreateAuditLog", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := auditLogQuery.WithContext(ctx).Create(dbModel); err != nil {
+		r.Logger.ErrorContext(ctx, "AuditRepository.CreateAuditLog", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return mapping.DbModelToDomainAuditLog(dbModel), nil
+}
+
+func (r *AuditRepo) FindAuditLogsByEntity(ctx context.Context, entityType string, entityID int) ([]*auditDomain.AuditLog, error) {
+	auditLogQuery := r.dbQuery.AuditLog
+	auditLogs, err := auditLogQuery.WithContext(ctx).
+		Where(auditLogQuery.EntityType.Eq(null.ValueFrom(entityType)), auditLogQuery.EntityID.Eq(null.ValueFrom(entityID))).
+		Order(auditLogQuery.ID.Asc()).
+		Find()
+
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "AuditRepository.FindAuditLogsByEntity", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sliceskit.Map(auditLogs, mapping.DbModelToDomainAuditLog), nil
+}
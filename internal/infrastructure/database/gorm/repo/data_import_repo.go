@@ -0,0 +1,74 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	dataImportRepo "github.com/umefy/go-web-app-template/internal/domain/dataimport/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+
+	db "github.com/umefy/go-web-app-template/pkg/db/gormdb"
+)
+
+type DataImportRepo struct {
+	Logger  logger.Logger
+	dbQuery *query.Query
+}
+
+var _ dataImportRepo.Repository = (*DataImportRepo)(nil)
+
+func NewDataImportRepository(dbQuery *query.Query, logger logger.Logger) *DataImportRepo {
+	return &DataImportRepo{Logger: logger, dbQuery: dbQuery}
+}
+
+// CreateDataImport always writes outside of the request transaction, for the same reason as
+// PrivacyRepo.CreateDataExport: the background job must see the row right away.
+func (r *DataImportRepo) CreateDataImport(ctx context.Context, dataImport *dataImportDomain.DataImport) (*dataImportDomain.DataImport, error) {
+	dataImportQuery := r.dbQuery.DataImport
+	dbModel := mapping.DomainDataImportToDbModel(dataImport)
+
+	if err := dataImportQuery.WithContext(ctx).Create(dbModel); err != nil {
+		r.Logger.ErrorContext(ctx, "DataImportRepository.CreateDataImport", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return mapping.DbModelToDomainDataImport(dbModel), nil
+}
+
+func (r *DataImportRepo) FindDataImport(ctx context.Context, id int) (*dataImportDomain.DataImport, error) {
+	dataImportQuery := r.dbQuery.DataImport
+	dataImport, err := dataImportQuery.WithContext(ctx).Where(dataImportQuery.ID.Eq(id)).First()
+
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "DataImportRepository.FindDataImport", slog.String("error", err.Error()))
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, dataImportError.DataImportNotFound
+		}
+		return nil, err
+	}
+
+	return mapping.DbModelToDomainDataImport(dataImport), nil
+}
+
+// UpdateDataImport writes outside of any transaction so progress is visible while a batch is still open.
+func (r *DataImportRepo) UpdateDataImport(ctx context.Context, dataImport *dataImportDomain.DataImport) (*dataImportDomain.DataImport, error) {
+	dataImportQuery := r.dbQuery.DataImport
+	dbModel := mapping.DomainDataImportToDbModel(dataImport)
+
+	info, err := dataImportQuery.WithContext(ctx).Where(dataImportQuery.ID.Eq(dataImport.ID)).Updates(dbModel)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "DataImportRepository.UpdateDataImport", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if info.RowsAffected == 0 {
+		return nil, dataImportError.DataImportNotFound
+	}
+
+	return mapping.DbModelToDomainDataImport(dbModel), nil
+}
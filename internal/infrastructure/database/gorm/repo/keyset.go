@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/umefy/go-web-app-template/pkg/pagination"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// keysetColumn is one column of a keyset ordering.
+type keysetColumn[M any] struct {
+	// key is the sort key exposed to clients, e.g. "createdAt"
+	key    string
+	column field.Expr
+	desc   bool
+	// value returns the column value of a row, it is what cursors hold
+	value func(m *M) any
+	// scan returns a pointer to decode a cursor value of the column into
+	scan func() any
+}
+
+// keyset is an ordering of table over columns. The last column must be unique (usually the
+// primary key) so that the ordering is total and a cursor points at exactly one row.
+type keyset[M any] struct {
+	table   string
+	columns []keysetColumn[M]
+}
+
+// keys returns the sort keys of the ordering, descending keys are prefixed with "-".
+func (k keyset[M]) keys() []string {
+	keys := make([]string, len(k.columns))
+	for i, column := range k.columns {
+		keys[i] = column.key
+		if column.desc {
+			keys[i] = "-" + column.key
+		}
+	}
+	return keys
+}
+
+func (k keyset[M]) order(backward bool) []field.Expr {
+	order := make([]field.Expr, len(k.columns))
+	for i, column := range k.columns {
+		if column.desc != backward {
+			order[i] = k.field(i).Desc()
+		} else {
+			order[i] = k.field(i).Asc()
+		}
+	}
+	return order
+}
+
+func (k keyset[M]) cursor(m *M) (pagination.Cursor, error) {
+	values := make([]any, len(k.columns))
+	for i, column := range k.columns {
+		values[i] = column.value(m)
+	}
+	return pagination.NewCursor(k.keys(), values...)
+}
+
+// seek returns the condition selecting the rows after the cursor, or before it when backward.
+// For columns (a, b) ascending it is: a > ? OR (a = ? AND b > ?).
+func (k keyset[M]) seek(cursor pagination.Cursor, backward bool) (gen.Condition, error) {
+	if !cursor.Matches(k.keys()) {
+		return nil, pagination.ErrInvalidCursor
+	}
+
+	values := make([]any, len(k.columns))
+	for i, column := range k.columns {
+		values[i] = column.scan()
+	}
+	if err := cursor.Scan(values...); err != nil {
+		return nil, err
+	}
+
+	var or []field.Expr
+	for i, column := range k.columns {
+		and := make([]field.Expr, 0, i+1)
+		for j := range i {
+			and = append(and, k.field(j).Eq(sqlValue{deref(values[j])}))
+		}
+
+		if column.desc != backward {
+			and = append(and, k.field(i).Lt(sqlValue{deref(values[i])}))
+		} else {
+			and = append(and, k.field(i).Gt(sqlValue{deref(values[i])}))
+		}
+		or = append(or, field.And(and...))
+	}
+
+	return field.Or(or...), nil
+}
+
+func (k keyset[M]) field(i int) field.Field {
+	return field.NewField(k.table, string(k.columns[i].column.ColumnName()))
+}
+
+// find fetches a page of p with fetch, which must apply the given conditions, order and limit.
+func (k keyset[M]) find(p pagination.Keyset, fetch func(conds []gen.Condition, order []field.Expr, limit int) ([]*M, error)) (*pagination.KeysetResult[*M], error) {
+	backward := p.Before != nil
+
+	var conds []gen.Condition
+	for _, cursor := range []*pagination.Cursor{p.After, p.Before} {
+		if cursor == nil {
+			continue
+		}
+		cond, err := k.seek(*cursor, backward)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+
+	rows, err := fetch(conds, k.order(backward), p.PageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(rows) > p.PageSize
+	if hasMore {
+		rows = rows[:p.PageSize]
+	}
+
+	result := &pagination.KeysetResult[*M]{
+		Items:       rows,
+		Cursors:     make([]pagination.Cursor, len(rows)),
+		HasNext:     hasMore,
+		HasPrevious: p.After != nil,
+	}
+
+	// a backward page is fetched in reverse order
+	if backward {
+		slices.Reverse(rows)
+		result.HasNext = true
+		result.HasPrevious = hasMore
+	}
+
+	for i, row := range rows {
+		if result.Cursors[i], err = k.cursor(row); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func deref(ptr any) any {
+	return reflect.ValueOf(ptr).Elem().Interface()
+}
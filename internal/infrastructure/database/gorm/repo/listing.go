@@ -0,0 +1,94 @@
+package repo
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/pkg/listing"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+)
+
+// listingColumns maps the listing fields of an entity to columns. The key and desc of the
+// keysetColumns are set from the sort of the listing query.
+type listingColumns[M any] map[string]keysetColumn[M]
+
+// conditions translates a checked filter into conditions on table.
+func (c listingColumns[M]) conditions(table string, filter []listing.Condition) ([]gen.Condition, error) {
+	conds := make([]gen.Condition, 0, len(filter))
+
+	for _, condition := range filter {
+		column, ok := c[condition.Field]
+		if !ok {
+			return nil, fmt.Errorf("listing: no column for field %q", condition.Field)
+		}
+		f := field.NewField(table, string(column.column.ColumnName()))
+
+		switch condition.Operator {
+		case listing.OperatorEq:
+			conds = append(conds, f.Eq(sqlValue{condition.Value}))
+		case listing.OperatorNeq:
+			conds = append(conds, f.Neq(sqlValue{condition.Value}))
+		case listing.OperatorGt:
+			conds = append(conds, f.Gt(sqlValue{condition.Value}))
+		case listing.OperatorGte:
+			conds = append(conds, f.Gte(sqlValue{condition.Value}))
+		case listing.OperatorLt:
+			conds = append(conds, f.Lt(sqlValue{condition.Value}))
+		case listing.OperatorLte:
+			conds = append(conds, f.Lte(sqlValue{condition.Value}))
+		case listing.OperatorIn:
+			values, _ := condition.Value.([]any)
+			valuers := make([]driver.Valuer, len(values))
+			for i, value := range values {
+				valuers[i] = sqlValue{value}
+			}
+			conds = append(conds, f.In(valuers...))
+		case listing.OperatorContains:
+			conds = append(conds, f.Like(sqlValue{"%" + likeEscaper.Replace(fmt.Sprint(condition.Value)) + "%"}))
+		default:
+			return nil, fmt.Errorf("listing: unsupported operator %q", condition.Operator)
+		}
+	}
+
+	return conds, nil
+}
+
+// keyset orders table by the sort of a listing query, tiebreaker (a unique field) is appended
+// when it isn't sorted on already so that the ordering is total.
+func (c listingColumns[M]) keyset(table string, sort []listing.SortKey, tiebreaker string) (keyset[M], error) {
+	k := keyset[M]{table: table}
+
+	hasTiebreaker := false
+	for _, sortKey := range sort {
+		column, ok := c[sortKey.Field]
+		if !ok {
+			return keyset[M]{}, fmt.Errorf("listing: no column for field %q", sortKey.Field)
+		}
+		column.key = sortKey.Field
+		column.desc = sortKey.Desc
+		k.columns = append(k.columns, column)
+
+		hasTiebreaker = hasTiebreaker || sortKey.Field == tiebreaker
+	}
+
+	if !hasTiebreaker {
+		column := c[tiebreaker]
+		column.key = tiebreaker
+		k.columns = append(k.columns, column)
+	}
+
+	return k, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// sqlValue passes a plain Go value to a gorm-gen condition.
+type sqlValue struct {
+	v any
+}
+
+func (v sqlValue) Value() (driver.Value, error) {
+	return driver.DefaultParameterConverter.ConvertValue(v.v)
+}
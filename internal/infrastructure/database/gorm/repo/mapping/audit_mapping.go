@@ -0,0 +1,48 @@
+package mapping
+
+import (
+	"encoding/json"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
+	"github.com/umefy/go-web-app-template/pkg/null"
+)
+
+func DbModelToDomainAuditLog(auditLog *dbModel.AuditLog) *auditDomain.AuditLog {
+	var metadata map[string]any
+	if auditLog.Metadata.Valid {
+		// metadata is always written by DomainAuditLogToDbModel, ignore rows edited by hand
+		_ = json.Unmarshal([]byte(auditLog.Metadata.ValueOrZero()), &metadata)
+	}
+
+	return &auditDomain.AuditLog{
+		ID:         auditLog.ID,
+		Action:     auditLog.Action.ValueOrZero(),
+		EntityType: auditLog.EntityType.ValueOrZero(),
+		EntityID:   auditLog.EntityID.ValueOrZero(),
+		RequestID:  auditLog.RequestID.ValueOrZero(),
+		Metadata:   metadata,
+		CreatedAt:  auditLog.CreatedAt,
+	}
+}
+
+func DomainAuditLogToDbModel(auditLog *auditDomain.AuditLog) (*dbModel.AuditLog, error) {
+	model := &dbModel.AuditLog{
+		ID:         auditLog.ID,
+		Action:     null.ValueFrom(auditLog.Action),
+		EntityType: null.ValueFrom(auditLog.EntityType),
+		EntityID:   null.ValueFrom(auditLog.EntityID),
+		RequestID:  null.NewValue(auditLog.RequestID, auditLog.RequestID != ""),
+		CreatedAt:  auditLog.CreatedAt,
+	}
+
+	if len(auditLog.Metadata) > 0 {
+		metadata, err := json.Marshal(auditLog.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		model.Metadata = null.ValueFrom(string(metadata))
+	}
+
+	return model, nil
+}
@@ -0,0 +1,43 @@
+package mapping
+
+import (
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
+	"github.com/umefy/go-web-app-template/pkg/null"
+)
+
+func DbModelToDomainDataImport(dataImport *dbModel.DataImport) *dataImportDomain.DataImport {
+	return &dataImportDomain.DataImport{
+		ID:            dataImport.ID,
+		Entity:        dataImportDomain.Entity(dataImport.Entity.ValueOrZero()),
+		Status:        dataImportDomain.Status(dataImport.Status.ValueOrZero()),
+		SourceKey:     dataImport.SourceKey.ValueOrZero(),
+		ReportKey:     dataImport.ReportKey.ValueOrZero(),
+		TotalRows:     dataImport.TotalRows.ValueOrZero(),
+		ProcessedRows: dataImport.ProcessedRows.ValueOrZero(),
+		ImportedRows:  dataImport.ImportedRows.ValueOrZero(),
+		FailedRows:    dataImport.FailedRows.ValueOrZero(),
+		Error:         dataImport.Error.ValueOrZero(),
+		CompletedAt:   dataImport.CompletedAt.Ptr(),
+		CreatedAt:     dataImport.CreatedAt,
+		UpdatedAt:     dataImport.UpdatedAt,
+	}
+}
+
+func DomainDataImportToDbModel(dataImport *dataImportDomain.DataImport) *dbModel.DataImport {
+	return &dbModel.DataImport{
+		ID:            dataImport.ID,
+		Entity:        null.ValueFrom(string(dataImport.Entity)),
+		Status:        null.ValueFrom(string(dataImport.Status)),
+		SourceKey:     null.NewValue(dataImport.SourceKey, dataImport.SourceKey != ""),
+		ReportKey:     null.NewValue(dataImport.ReportKey, dataImport.ReportKey != ""),
+		TotalRows:     null.ValueFrom(dataImport.TotalRows),
+		ProcessedRows: null.ValueFrom(dataImport.ProcessedRows),
+		ImportedRows:  null.ValueFrom(dataImport.ImportedRows),
+		FailedRows:    null.ValueFrom(dataImport.FailedRows),
+		Error:         null.NewValue(dataImport.Error, dataImport.Error != ""),
+		CompletedAt:   null.ValueFromPtr(dataImport.CompletedAt),
+		CreatedAt:     dataImport.CreatedAt,
+		UpdatedAt:     dataImport.UpdatedAt,
+	}
+}
@@ -3,6 +3,7 @@ package mapping
 import (
 	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
 	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
+	"github.com/umefy/go-web-app-template/pkg/null"
 )
 
 func DbModelToDomainOrder(order *dbModel.Order) *orderDomain.Order {
@@ -14,3 +15,13 @@ func DbModelToDomainOrder(order *dbModel.Order) *orderDomain.Order {
 		UpdatedAt:   order.UpdatedAt,
 	}
 }
+
+func DomainOrderToDbModel(order *orderDomain.Order) *dbModel.Order {
+	return &dbModel.Order{
+		ID:          order.ID,
+		UserID:      order.UserID,
+		AmountCents: null.ValueFrom(order.AmountCents),
+		CreatedAt:   order.CreatedAt,
+		UpdatedAt:   order.UpdatedAt,
+	}
+}
@@ -0,0 +1,35 @@
+package mapping
+
+import (
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
+	"github.com/umefy/go-web-app-template/pkg/null"
+)
+
+func DbModelToDomainDataExport(dataExport *dbModel.DataExport) *privacyDomain.DataExport {
+	return &privacyDomain.DataExport{
+		ID:          dataExport.ID,
+		UserID:      dataExport.UserID,
+		Format:      privacyDomain.DataExportFormat(dataExport.Format.ValueOrZero()),
+		Status:      privacyDomain.DataExportStatus(dataExport.Status.ValueOrZero()),
+		StorageKey:  dataExport.StorageKey.ValueOrZero(),
+		Error:       dataExport.Error.ValueOrZero(),
+		CompletedAt: dataExport.CompletedAt.Ptr(),
+		CreatedAt:   dataExport.CreatedAt,
+		UpdatedAt:   dataExport.UpdatedAt,
+	}
+}
+
+func DomainDataExportToDbModel(dataExport *privacyDomain.DataExport) *dbModel.DataExport {
+	return &dbModel.DataExport{
+		ID:          dataExport.ID,
+		UserID:      dataExport.UserID,
+		Format:      null.ValueFrom(string(dataExport.Format)),
+		Status:      null.ValueFrom(string(dataExport.Status)),
+		StorageKey:  null.NewValue(dataExport.StorageKey, dataExport.StorageKey != ""),
+		Error:       null.NewValue(dataExport.Error, dataExport.Error != ""),
+		CompletedAt: null.ValueFromPtr(dataExport.CompletedAt),
+		CreatedAt:   dataExport.CreatedAt,
+		UpdatedAt:   dataExport.UpdatedAt,
+	}
+}
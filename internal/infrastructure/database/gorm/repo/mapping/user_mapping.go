@@ -16,6 +16,7 @@ func DbModelToDomainUser(user *dbModel.User) *userDomain.User {
 		Version:   user.Version,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		ErasedAt:  user.ErasedAt.Ptr(),
 	}
 }
 
@@ -27,6 +28,7 @@ func DomainUserToDbModel(user *userDomain.User) *dbModel.User {
 		Version:   user.Version,
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
+		ErasedAt:  null.ValueFromPtr(user.ErasedAt),
 	}
 }
 
@@ -0,0 +1,25 @@
+package repo
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+const metricsScope = "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo"
+
+// optimisticLockConflicts counts the updates refused because the version changed since it was read, by entity.
+// It uses the global meter provider like the gorm plugin, the metrics module sets it when enabled.
+var optimisticLockConflicts = newOptimisticLockConflicts()
+
+func newOptimisticLockConflicts() metric.Int64Counter {
+	counter, err := otel.Meter(metricsScope).Int64Counter("db.optimistic_lock.conflicts",
+		metric.WithDescription("Number of updates refused by optimistic locking, by entity."),
+		metric.WithUnit("{conflict}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+		return noop.Int64Counter{}
+	}
+	return counter
+}
@@ -4,17 +4,23 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
 	orderError "github.com/umefy/go-web-app-template/internal/domain/order/error"
 	orderRepo "github.com/umefy/go-web-app-template/internal/domain/order/repo"
+	dbContext "github.com/umefy/go-web-app-template/internal/infrastructure/database/ctx"
+	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/godash/sliceskit"
 	"gorm.io/gorm"
 )
 
+const createOrdersBatchSize = 100
+
 type OrderRepo struct {
 	Logger  logger.Logger
 	dbQuery *query.Query
@@ -50,3 +56,77 @@ func (r *OrderRepo) FindOrdersByUserIDs(ctx context.Context, userIDs []int) ([]*
 
 	return sliceskit.Map(orders, mapping.DbModelToDomainOrder), nil
 }
+
+// CreateOrders inserts orders in batches of createOrdersBatchSize rows, it must run in a transaction.
+func (r *OrderRepo) CreateOrders(ctx context.Context, orders []*orderDomain.Order) ([]*orderDomain.Order, error) {
+	tx := ctx.Value(dbContext.TransactionCtxKey).(*query.QueryTx)
+	orderQuery := tx.Order
+	dbModels := sliceskit.Map(orders, mapping.DomainOrderToDbModel)
+
+	if err := orderQuery.WithContext(ctx).CreateInBatches(dbModels, createOrdersBatchSize); err != nil {
+		r.Logger.ErrorContext(ctx, "OrderRepository.CreateOrders", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sliceskit.Map(dbModels, mapping.DbModelToDomainOrder), nil
+}
+
+func (r *OrderRepo) StreamOrders(ctx context.Context, q listing.Query, fn func([]*orderDomain.Order) error) error {
+	orderQuery := r.dbQuery.Order
+	columns := r.orderListingColumns()
+
+	conds, err := columns.conditions(orderQuery.TableName(), q.Filter)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "OrderRepository.StreamOrders", slog.String("error", err.Error()))
+		return err
+	}
+
+	orderKeyset, err := columns.keyset(orderQuery.TableName(), q.Sort, "id")
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "OrderRepository.StreamOrders", slog.String("error", err.Error()))
+		return err
+	}
+
+	err = streamRows(ctx, r.dbQuery, func(tx *query.Query) *gorm.DB {
+		return tx.Order.WithContext(ctx).Where(conds...).Order(orderKeyset.order(false)...).UnderlyingDB()
+	}, func(orders []*dbModel.Order) error {
+		return fn(sliceskit.Map(orders, mapping.DbModelToDomainOrder))
+	})
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "OrderRepository.StreamOrders", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+func (r *OrderRepo) orderListingColumns() listingColumns[dbModel.Order] {
+	orderQuery := r.dbQuery.Order
+	return listingColumns[dbModel.Order]{
+		"id": {
+			column: orderQuery.ID,
+			value:  func(order *dbModel.Order) any { return order.ID },
+			scan:   func() any { return new(int) },
+		},
+		"userId": {
+			column: orderQuery.UserID,
+			value:  func(order *dbModel.Order) any { return order.UserID },
+			scan:   func() any { return new(int) },
+		},
+		"amountCents": {
+			column: orderQuery.AmountCents,
+			value:  func(order *dbModel.Order) any { return order.AmountCents },
+			scan:   func() any { return new(int64) },
+		},
+		"createdAt": {
+			column: orderQuery.CreatedAt,
+			value:  func(order *dbModel.Order) any { return order.CreatedAt },
+			scan:   func() any { return new(time.Time) },
+		},
+		"updatedAt": {
+			column: orderQuery.UpdatedAt,
+			value:  func(order *dbModel.Order) any { return order.UpdatedAt },
+			scan:   func() any { return new(time.Time) },
+		},
+	}
+}
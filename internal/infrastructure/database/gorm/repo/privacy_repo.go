@@ -0,0 +1,86 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	privacyError "github.com/umefy/go-web-app-template/internal/domain/privacy/error"
+	privacyRepo "github.com/umefy/go-web-app-template/internal/domain/privacy/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/godash/sliceskit"
+
+	db "github.com/umefy/go-web-app-template/pkg/db/gormdb"
+)
+
+type PrivacyRepo struct {
+	Logger  logger.Logger
+	dbQuery *query.Query
+}
+
+var _ privacyRepo.Repository = (*PrivacyRepo)(nil)
+
+func NewPrivacyRepository(dbQuery *query.Query, logger logger.Logger) *PrivacyRepo {
+	return &PrivacyRepo{Logger: logger, dbQuery: dbQuery}
+}
+
+// CreateDataExport always writes outside of the request transaction: the export is processed by a
+// background job right away, which must be able to see the row before the request finishes.
+func (r *PrivacyRepo) CreateDataExport(ctx context.Context, dataExport *privacyDomain.DataExport) (*privacyDomain.DataExport, error) {
+	dataExportQuery := r.dbQuery.DataExport
+	dbModel := mapping.DomainDataExportToDbModel(dataExport)
+
+	if err := dataExportQuery.WithContext(ctx).Create(dbModel); err != nil {
+		r.Logger.ErrorContext(ctx, "PrivacyRepository.CreateDataExport", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return mapping.DbModelToDomainDataExport(dbModel), nil
+}
+
+func (r *PrivacyRepo) FindDataExport(ctx context.Context, id int) (*privacyDomain.DataExport, error) {
+	dataExportQuery := r.dbQuery.DataExport
+	dataExport, err := dataExportQuery.WithContext(ctx).Where(dataExportQuery.ID.Eq(id)).First()
+
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "PrivacyRepository.FindDataExport", slog.String("error", err.Error()))
+		if errors.Is(err, db.ErrRecordNotFound) {
+			return nil, privacyError.DataExportNotFound
+		}
+		return nil, err
+	}
+
+	return mapping.DbModelToDomainDataExport(dataExport), nil
+}
+
+func (r *PrivacyRepo) FindDataExportsByUserID(ctx context.Context, userID int) ([]*privacyDomain.DataExport, error) {
+	dataExportQuery := queryFromContext(ctx, r.dbQuery).DataExport
+	dataExports, err := dataExportQuery.WithContext(ctx).Where(dataExportQuery.UserID.Eq(userID)).Order(dataExportQuery.ID.Asc()).Find()
+
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "PrivacyRepository.FindDataExportsByUserID", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sliceskit.Map(dataExports, mapping.DbModelToDomainDataExport), nil
+}
+
+func (r *PrivacyRepo) UpdateDataExport(ctx context.Context, dataExport *privacyDomain.DataExport) (*privacyDomain.DataExport, error) {
+	dataExportQuery := queryFromContext(ctx, r.dbQuery).DataExport
+	dbModel := mapping.DomainDataExportToDbModel(dataExport)
+
+	info, err := dataExportQuery.WithContext(ctx).Where(dataExportQuery.ID.Eq(dataExport.ID)).Updates(dbModel)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "PrivacyRepository.UpdateDataExport", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if info.RowsAffected == 0 {
+		return nil, privacyError.DataExportNotFound
+	}
+
+	return mapping.DbModelToDomainDataExport(dbModel), nil
+}
@@ -0,0 +1,17 @@
+package repo
+
+import (
+	"context"
+
+	dbContext "github.com/umefy/go-web-app-template/internal/infrastructure/database/ctx"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+)
+
+// queryFromContext returns the query bound to the transaction in ctx, or dbQuery when there is none.
+// It is for writes that are valid both inside and outside of a request transaction.
+func queryFromContext(ctx context.Context, dbQuery *query.Query) *query.Query {
+	if tx, ok := ctx.Value(dbContext.TransactionCtxKey).(*query.QueryTx); ok {
+		return tx.Query
+	}
+	return dbQuery
+}
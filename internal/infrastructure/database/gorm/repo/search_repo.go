@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"strconv"
+
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+	searchRepo "github.com/umefy/go-web-app-template/internal/domain/search/repo"
+	dbModel "github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/model"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+)
+
+// Both tables carry a search_vector column kept up to date by triggers, see the add_search_vectors migration.
+// Emails additionally fall back to trigram word similarity, which catches partial words and typos.
+// Erased users are never matched, their orders only by their own id and amount.
+const (
+	searchUsersSQL = `with search as (select websearch_to_tsquery('simple', @text) as query)
+select users.id, users.email, users.age, users.version, users.created_at, users.updated_at, users.erased_at,
+	greatest(ts_rank(users.search_vector, search.query), word_similarity(@text, users.email)) as rank
+from users, search
+where users.erased_at is null
+	and (users.search_vector @@ search.query or @text <% users.email)
+order by rank desc, users.id
+limit @limit`
+
+	// orders matched through their owner rank at half the owner's rank
+	searchOrdersSQL = `with search as (select websearch_to_tsquery('simple', @text) as query)
+select orders.id, orders.user_id, orders.amount_cents, orders.version, orders.created_at, orders.updated_at,
+	case when users.erased_at is null then users.email end as user_email,
+	greatest(
+		ts_rank(orders.search_vector, search.query),
+		case when users.erased_at is null
+			then greatest(ts_rank(users.search_vector, search.query), word_similarity(@text, users.email)) * 0.5
+			else 0
+		end
+	) as rank
+from orders join users on users.id = orders.user_id, search
+where orders.search_vector @@ search.query
+	or (users.erased_at is null and (users.search_vector @@ search.query or @text <% users.email))
+order by rank desc, orders.id
+limit @limit`
+)
+
+type userSearchRow struct {
+	dbModel.User
+	Rank float64
+}
+
+type orderSearchRow struct {
+	dbModel.Order
+	UserEmail *string
+	Rank      float64
+}
+
+type SearchRepo struct {
+	Logger  logger.Logger
+	dbQuery *query.Query
+}
+
+var _ searchRepo.Repository = (*SearchRepo)(nil)
+
+func NewSearchRepository(dbQuery *query.Query, logger logger.Logger) *SearchRepo {
+	return &SearchRepo{Logger: logger, dbQuery: dbQuery}
+}
+
+func (r *SearchRepo) Search(ctx context.Context, text string, limit int) ([]*searchDomain.Result, error) {
+	args := map[string]any{"text": text, "limit": limit}
+
+	var userRows []userSearchRow
+	if err := r.dbQuery.User.WithContext(ctx).UnderlyingDB().Raw(searchUsersSQL, args).Scan(&userRows).Error; err != nil {
+		r.Logger.ErrorContext(ctx, "SearchRepository.Search users", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	var orderRows []orderSearchRow
+	if err := r.dbQuery.Order.WithContext(ctx).UnderlyingDB().Raw(searchOrdersSQL, args).Scan(&orderRows).Error; err != nil {
+		r.Logger.ErrorContext(ctx, "SearchRepository.Search orders", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	results := make([]*searchDomain.Result, 0, len(userRows)+len(orderRows))
+	for i := range userRows {
+		user := mapping.DbModelToDomainUser(&userRows[i].User)
+		results = append(results, &searchDomain.Result{
+			Type:     searchDomain.ResultTypeUser,
+			Rank:     userRows[i].Rank,
+			User:     user,
+			Document: map[string]string{"email": user.Email},
+		})
+	}
+	for i := range orderRows {
+		order := mapping.DbModelToDomainOrder(&orderRows[i].Order)
+		document := map[string]string{
+			"id":          strconv.Itoa(order.ID),
+			"amountCents": strconv.FormatInt(order.AmountCents, 10),
+		}
+		if orderRows[i].UserEmail != nil {
+			document["userEmail"] = *orderRows[i].UserEmail
+		}
+		results = append(results, &searchDomain.Result{
+			Type:     searchDomain.ResultTypeOrder,
+			Rank:     orderRows[i].Rank,
+			Order:    order,
+			Document: document,
+		})
+	}
+
+	// users before orders on equal rank, so a page is stable across requests
+	slices.SortStableFunc(results, func(a, b *searchDomain.Result) int {
+		return cmp.Compare(b.Rank, a.Rank)
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
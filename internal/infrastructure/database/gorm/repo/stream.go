@@ -0,0 +1,41 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
+	"gorm.io/gorm"
+)
+
+const streamFetchSize = 1000
+
+// streamRows reads the rows selected by build through a server-side cursor in a read-only transaction of its own,
+// calling fn with batches of at most streamFetchSize rows, so memory stays flat whatever the size of the result.
+func streamRows[M any](ctx context.Context, dbQuery *query.Query, build func(tx *query.Query) *gorm.DB, fn func([]*M) error) error {
+	db := dbQuery.User.WithContext(ctx).UnderlyingDB().Session(&gorm.Session{NewDB: true})
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DECLARE stream_cursor NO SCROLL CURSOR FOR ?", build(query.Use(tx))).Error; err != nil {
+			return err
+		}
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM stream_cursor", streamFetchSize)
+		for {
+			var rows []*M
+			if err := tx.Raw(fetch).Scan(&rows).Error; err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				return nil
+			}
+			if err := fn(rows); err != nil {
+				return err
+			}
+			if len(rows) < streamFetchSize {
+				return nil
+			}
+		}
+	}, &sql.TxOptions{ReadOnly: true})
+}
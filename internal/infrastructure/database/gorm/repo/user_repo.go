@@ -2,8 +2,10 @@ package repo
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"log/slog"
+	"time"
 
 	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
@@ -13,14 +15,22 @@ import (
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/repo/mapping"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/go-web-app-template/pkg/null"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
 	"github.com/umefy/godash/sliceskit"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
 
 	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
 	db "github.com/umefy/go-web-app-template/pkg/db/gormdb"
 )
 
+const createUsersBatchSize = 100
+
 type UserRepo struct {
 	Logger  logger.Logger
 	dbQuery *query.Query
@@ -63,9 +73,23 @@ func (r *UserRepo) FindUserTx(ctx context.Context, id int) (*userDomain.User, er
 	return mapping.DbModelToDomainUser(user), nil
 }
 
-func (r *UserRepo) FindUsers(ctx context.Context, p pagination.Pagination) ([]*userDomain.User, *pagination.PaginationMetadata, error) {
+func (r *UserRepo) FindUsers(ctx context.Context, p pagination.Pagination, q listing.Query) ([]*userDomain.User, *pagination.PaginationMetadata, error) {
 	userQuery := r.dbQuery.User
-	users, err := userQuery.WithContext(ctx).Order(userQuery.ID.Asc()).Offset(p.Offset).Limit(p.PageSize + 1).Find()
+	columns := r.userListingColumns()
+
+	conds, err := columns.conditions(userQuery.TableName(), q.Filter)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.GetUsers", slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	userKeyset, err := columns.keyset(userQuery.TableName(), q.Sort, "id")
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.GetUsers", slog.String("error", err.Error()))
+		return nil, nil, err
+	}
+
+	users, err := userQuery.WithContext(ctx).Where(conds...).Order(userKeyset.order(false)...).Offset(p.Offset).Limit(p.PageSize + 1).Find()
 
 	if err != nil {
 		r.Logger.ErrorContext(ctx, "UserRepository.GetUsers", slog.String("error", err.Error()))
@@ -84,7 +108,7 @@ func (r *UserRepo) FindUsers(ctx context.Context, p pagination.Pagination) ([]*u
 	var total *int64 = nil
 	metadata := pagination.NewPaginationMetadata(p.Offset, p.PageSize, len(users), hasMore, total)
 	if p.IncludeTotal {
-		totalCount, err := userQuery.WithContext(ctx).Count()
+		totalCount, err := userQuery.WithContext(ctx).Where(conds...).Count()
 		if err != nil {
 			return nil, nil, err
 		}
@@ -96,6 +120,106 @@ func (r *UserRepo) FindUsers(ctx context.Context, p pagination.Pagination) ([]*u
 	}), &metadata, nil
 }
 
+func (r *UserRepo) FindUsersByKeyset(ctx context.Context, p pagination.Keyset, q listing.Query) (*pagination.KeysetResult[*userDomain.User], error) {
+	userQuery := r.dbQuery.User
+	columns := r.userListingColumns()
+
+	conds, err := columns.conditions(userQuery.TableName(), q.Filter)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.FindUsersByKeyset", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	userKeyset, err := columns.keyset(userQuery.TableName(), q.Sort, "id")
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.FindUsersByKeyset", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	result, err := userKeyset.find(p, func(seek []gen.Condition, order []field.Expr, limit int) ([]*dbModel.User, error) {
+		return userQuery.WithContext(ctx).Where(conds...).Where(seek...).Order(order...).Limit(limit).Find()
+	})
+
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.FindUsersByKeyset", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if p.IncludeTotal {
+		totalCount, err := userQuery.WithContext(ctx).Where(conds...).Count()
+		if err != nil {
+			return nil, err
+		}
+		result.Total = &totalCount
+	}
+
+	return pagination.MapKeysetResult(result, mapping.DbModelToDomainUser), nil
+}
+
+// StreamUsers reads the users through a server-side cursor in a read-only transaction of its own, see streamRows,
+// so that an export holds one batch in memory at a time and sees a consistent snapshot. It doesn't use the
+// request transaction.
+func (r *UserRepo) StreamUsers(ctx context.Context, q listing.Query, fn func([]*userDomain.User) error) error {
+	userQuery := r.dbQuery.User
+	columns := r.userListingColumns()
+
+	conds, err := columns.conditions(userQuery.TableName(), q.Filter)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.StreamUsers", slog.String("error", err.Error()))
+		return err
+	}
+
+	userKeyset, err := columns.keyset(userQuery.TableName(), q.Sort, "id")
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.StreamUsers", slog.String("error", err.Error()))
+		return err
+	}
+
+	err = streamRows(ctx, r.dbQuery, func(tx *query.Query) *gorm.DB {
+		return tx.User.WithContext(ctx).Where(conds...).Order(userKeyset.order(false)...).UnderlyingDB()
+	}, func(users []*dbModel.User) error {
+		return fn(sliceskit.Map(users, mapping.DbModelToDomainUser))
+	})
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.StreamUsers", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// userListingColumns maps the fields of userSrv.UserListSchema to columns.
+func (r *UserRepo) userListingColumns() listingColumns[dbModel.User] {
+	userQuery := r.dbQuery.User
+	return listingColumns[dbModel.User]{
+		"id": {
+			column: userQuery.ID,
+			value:  func(user *dbModel.User) any { return user.ID },
+			scan:   func() any { return new(int) },
+		},
+		"email": {
+			column: userQuery.Email,
+			value:  func(user *dbModel.User) any { return user.Email },
+			scan:   func() any { return new(string) },
+		},
+		"age": {
+			column: userQuery.Age,
+			value:  func(user *dbModel.User) any { return user.Age },
+			scan:   func() any { return new(int) },
+		},
+		"createdAt": {
+			column: userQuery.CreatedAt,
+			value:  func(user *dbModel.User) any { return user.CreatedAt },
+			scan:   func() any { return new(time.Time) },
+		},
+		"updatedAt": {
+			column: userQuery.UpdatedAt,
+			value:  func(user *dbModel.User) any { return user.UpdatedAt },
+			scan:   func() any { return new(time.Time) },
+		},
+	}
+}
+
 func (r *UserRepo) FindUsersTx(ctx context.Context) ([]*userDomain.User, error) {
 	tx := ctx.Value(dbContext.TransactionCtxKey).(*query.QueryTx)
 	userQuery := tx.User
@@ -132,6 +256,20 @@ func (r *UserRepo) CreateUser(ctx context.Context, user *userDomain.User) (*user
 	return mapping.DbModelToDomainUser(dbModel), nil
 }
 
+// CreateUsers inserts users in batches of createUsersBatchSize rows, it must run in a transaction.
+func (r *UserRepo) CreateUsers(ctx context.Context, users []*userDomain.User) ([]*userDomain.User, error) {
+	tx := ctx.Value(dbContext.TransactionCtxKey).(*query.QueryTx)
+	userQuery := tx.User
+	dbModels := sliceskit.Map(users, mapping.DomainUserToDbModel)
+
+	if err := userQuery.WithContext(ctx).CreateInBatches(dbModels, createUsersBatchSize); err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.CreateUsers", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return sliceskit.Map(dbModels, mapping.DbModelToDomainUser), nil
+}
+
 func (r *UserRepo) UpdateUser(ctx context.Context, id int, user *userDomain.User) (*userDomain.User, error) {
 
 	tx := ctx.Value(dbContext.TransactionCtxKey).(*query.QueryTx)
@@ -151,6 +289,7 @@ func (r *UserRepo) UpdateUser(ctx context.Context, id int, user *userDomain.User
 		// Since we're using optimistic locking, assume it's a version conflict
 		// But service level should already handle 1st case, so we don't need to return user not found error
 		r.Logger.ErrorContext(ctx, "UserRepository.UpdateUser", slog.String("error", "user update conflict - version mismatch"))
+		optimisticLockConflicts.Add(ctx, 1, metric.WithAttributes(attribute.String("entity", "user")))
 		return nil, userError.UserUpdateConflict
 	}
 
@@ -168,6 +307,38 @@ func (r *UserRepo) IsUserEmailExists(ctx context.Context, email string) (bool, e
 	return count > 0, nil
 }
 
+// FindExistingEmails returns the emails that already belong to a user.
+func (r *UserRepo) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	userQuery := queryFromContext(ctx, r.dbQuery).User
+
+	var existing []string
+	err := userQuery.WithContext(ctx).
+		Where(userQuery.Email.In(sliceskit.Map(emails, func(email string) driver.Valuer { return null.ValueFrom(email) })...)).
+		Pluck(userQuery.Email, &existing)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.FindExistingEmails", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// FindExistingUserIDs returns the ids among ids that belong to users who have not been erased.
+func (r *UserRepo) FindExistingUserIDs(ctx context.Context, ids []int) ([]int, error) {
+	userQuery := queryFromContext(ctx, r.dbQuery).User
+
+	var existing []int
+	err := userQuery.WithContext(ctx).
+		Where(userQuery.ID.In(ids...), userQuery.ErasedAt.IsNull()).
+		Pluck(userQuery.ID, &existing)
+	if err != nil {
+		r.Logger.ErrorContext(ctx, "UserRepository.FindExistingUserIDs", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	return existing, nil
+}
+
 func (r *UserRepo) FindUserWithOrders(ctx context.Context, id int) (*userDomain.UserWithOrder, error) {
 	orderQuery := r.dbQuery.Order
 	u, err := r.FindUser(ctx, id)
@@ -11,12 +11,13 @@ import (
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
 
 	db "github.com/umefy/go-web-app-template/pkg/db/gormdb"
+	"github.com/umefy/go-web-app-template/pkg/redact"
 	"gorm.io/gorm"
 	gormLogger "gorm.io/gorm/logger"
 	gormTracing "gorm.io/plugin/opentelemetry/tracing"
 )
 
-func NewDB(config config.Config) (*db.DB, error) {
+func NewDB(config config.Config, redactor *redact.Redactor) (*db.DB, error) {
 	dbConfig := config.GetDBConfig()
 	dbLoggerConfig := dbConfig.GetLoggerConfig()
 
@@ -32,7 +33,7 @@ func NewDB(config config.Config) (*db.DB, error) {
 	)
 
 	gormConfig := gorm.Config{
-		Logger: logger,
+		Logger: newRedactLogger(logger, redactor),
 	}
 
 	db, err := db.NewDB(db.DBConfig{
@@ -47,8 +48,10 @@ func NewDB(config config.Config) (*db.DB, error) {
 		return nil, err
 	}
 
-	// opentelemetry gorm plugin
-	if err := db.Use(gormTracing.NewPlugin()); err != nil {
+	// opentelemetry gorm plugin, it also reports the sql.DBStats pool gauges (go.sql.connections_*) through the
+	// global meter provider set by the metrics module. Spans keep the $ placeholders, the params would reach the
+	// trace backend without going through the redactor.
+	if err := db.Use(gormTracing.NewPlugin(gormTracing.WithoutQueryVariables())); err != nil {
 		return nil, err
 	}
 
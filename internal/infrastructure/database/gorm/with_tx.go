@@ -3,12 +3,16 @@ package gorm
 import (
 	"context"
 	"log/slog"
+	"sync"
 
+	dbCtx "github.com/umefy/go-web-app-template/internal/infrastructure/database/ctx"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/database/gorm/generated/query"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
 )
 
 func WithTx[T any](ctx context.Context, dbQuery *query.Query, logger logger.Logger, fn func(context.Context, *query.QueryTx) (T, error)) (T, error) {
+	ctx, hooks := withAfterCommit(ctx)
+
 	tx := dbQuery.Begin()
 	logger.InfoContext(ctx, "Transaction started")
 	var err error
@@ -27,6 +31,7 @@ func WithTx[T any](ctx context.Context, dbQuery *query.Query, logger logger.Logg
 			return
 		}
 		logger.InfoContext(ctx, "Transaction committed")
+		hooks.run(ctx)
 	}()
 
 	v, err := fn(ctx, tx)
@@ -34,5 +39,45 @@ func WithTx[T any](ctx context.Context, dbQuery *query.Query, logger logger.Logg
 		return v, err
 	}
 
-	return v, tx.Commit()
+	err = tx.Commit()
+	return v, err
+}
+
+// AfterCommit runs fn once the transaction of WithTx that ctx is in is committed, and not at all when it is
+// rolled back. It is meant for side effects that can't be rolled back, such as deleting files. Outside of a
+// transaction fn runs right away. fn gets a context detached from the transaction.
+func AfterCommit(ctx context.Context, fn func(context.Context)) {
+	hooks, ok := ctx.Value(dbCtx.AfterCommitCtxKey).(*afterCommitHooks)
+	if !ok {
+		fn(ctx)
+		return
+	}
+
+	hooks.mu.Lock()
+	defer hooks.mu.Unlock()
+	hooks.fns = append(hooks.fns, fn)
+}
+
+type afterCommitHooks struct {
+	mu  sync.Mutex
+	fns []func(context.Context)
+}
+
+func withAfterCommit(ctx context.Context) (context.Context, *afterCommitHooks) {
+	hooks := &afterCommitHooks{}
+	return context.WithValue(ctx, dbCtx.AfterCommitCtxKey, hooks), hooks
+}
+
+// run calls the hooks in the order they were added.
+func (h *afterCommitHooks) run(ctx context.Context) {
+	ctx = context.WithValue(ctx, dbCtx.TransactionCtxKey, nil)
+	ctx = context.WithValue(ctx, dbCtx.AfterCommitCtxKey, nil)
+
+	h.mu.Lock()
+	fns := h.fns
+	h.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(ctx)
+	}
 }
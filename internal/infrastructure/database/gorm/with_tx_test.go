@@ -0,0 +1,50 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	dbCtx "github.com/umefy/go-web-app-template/internal/infrastructure/database/ctx"
+)
+
+type AfterCommitSuite struct {
+	suite.Suite
+}
+
+func (s *AfterCommitSuite) TestOutsideTransaction() {
+	var calls int
+	AfterCommit(context.Background(), func(ctx context.Context) {
+		calls++
+	})
+
+	s.Equal(1, calls)
+}
+
+func (s *AfterCommitSuite) TestInTransaction() {
+	ctx, hooks := withAfterCommit(context.Background())
+	ctx = context.WithValue(ctx, dbCtx.TransactionCtxKey, "tx")
+
+	var calls []string
+	AfterCommit(ctx, func(ctx context.Context) {
+		calls = append(calls, "first")
+		// the hooks don't run in the committed transaction, nor register hooks on it
+		s.Nil(ctx.Value(dbCtx.TransactionCtxKey))
+		AfterCommit(ctx, func(ctx context.Context) {
+			calls = append(calls, "nested")
+		})
+	})
+	AfterCommit(ctx, func(ctx context.Context) {
+		calls = append(calls, "second")
+	})
+
+	// rolled back transactions never call run
+	s.Empty(calls)
+
+	hooks.run(ctx)
+	s.Equal([]string{"first", "nested", "second"}, calls)
+}
+
+func TestAfterCommitSuite(t *testing.T) {
+	suite.Run(t, new(AfterCommitSuite))
+}
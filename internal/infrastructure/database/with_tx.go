@@ -13,7 +13,18 @@ func WithTx[T any](ctx context.Context, dbQuery *query.Query, logger logger.Logg
 	return gorm.WithTx(ctx, dbQuery, logger, fn)
 }
 
+// AfterCommit runs fn once the transaction of ctx is committed, see gorm.AfterCommit.
+func AfterCommit(ctx context.Context, fn func(context.Context)) {
+	gorm.AfterCommit(ctx, fn)
+}
+
 type QueryTx = query.QueryTx
 type Query = query.Query
 
 var TransactionCtxKey = ctx.TransactionCtxKey
+
+// WithoutTx detaches ctx from the transaction it carries, e.g. for work that outlives the request.
+func WithoutTx(parent context.Context) context.Context {
+	detached := context.WithValue(parent, ctx.TransactionCtxKey, nil)
+	return context.WithValue(detached, ctx.AfterCommitCtxKey, nil)
+}
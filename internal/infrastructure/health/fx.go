@@ -0,0 +1,11 @@
+package health
+
+import "go.uber.org/fx"
+
+// FX_TAG_GROUP_HEALTH_CHECKERS is the tag of the providers of []health.Checker, replica lag or message broker
+// checks are registered by providing them in this group.
+const FX_TAG_GROUP_HEALTH_CHECKERS = `group:"healthCheckers,flatten"`
+
+var Module = fx.Module("health",
+	fx.Provide(NewRegistry),
+)
@@ -0,0 +1,28 @@
+package health
+
+import (
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/health"
+	"go.uber.org/fx"
+)
+
+type RegistryParams struct {
+	fx.In
+
+	Config   config.Config
+	Logger   logger.Logger
+	Checkers []health.Checker `group:"healthCheckers"`
+}
+
+func NewRegistry(params RegistryParams) *health.Registry {
+	healthConfig := params.Config.GetHealthConfig()
+
+	return health.NewRegistry(health.Options{
+		Timeout:  time.Duration(healthConfig.CheckTimeoutInSeconds) * time.Second,
+		CacheTTL: time.Duration(healthConfig.CacheTtlInSeconds) * time.Second,
+		Logger:   params.Logger.GetLogger().Logger,
+	}, params.Checkers...)
+}
@@ -0,0 +1,7 @@
+package job
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("job",
+	fx.Provide(NewRunner),
+)
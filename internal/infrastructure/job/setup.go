@@ -0,0 +1,27 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/job"
+	"go.uber.org/fx"
+)
+
+func NewRunner(lc fx.Lifecycle, config config.Config, logger logger.Logger) *job.Runner {
+	runner := job.NewRunner(logger.GetLogger())
+	timeout := time.Duration(config.GetShutdownConfig().JobsTimeoutInSeconds) * time.Second
+
+	// the runner is created before the servers, so it is stopped after them and no request submits jobs anymore
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			return runner.Shutdown(ctx)
+		},
+	})
+
+	return runner
+}
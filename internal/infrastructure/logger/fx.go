@@ -3,5 +3,8 @@ package logger
 import "go.uber.org/fx"
 
 var Module = fx.Module("logger",
-	fx.Provide(NewLogger),
+	fx.Provide(
+		NewLogger,
+		NewRedactor,
+	),
 )
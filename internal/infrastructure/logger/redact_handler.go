@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/redact"
+)
+
+// RedactHandler redacts the attributes of every record, those of the context and of the span events
+// included, before they reach the wrapped handler.
+type RedactHandler struct {
+	slog.Handler
+	redactor *redact.Redactor
+}
+
+var _ slog.Handler = (*RedactHandler)(nil)
+
+func NewRedactHandler(handler slog.Handler, redactor *redact.Redactor) *RedactHandler {
+	return &RedactHandler{Handler: handler, redactor: redactor}
+}
+
+func (h *RedactHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(h.redactor.Attr(attr))
+		return true
+	})
+	return h.Handler.Handle(ctx, redacted)
+}
+
+func (h *RedactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = h.redactor.Attr(attr)
+	}
+	return &RedactHandler{Handler: h.Handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+func (h *RedactHandler) WithGroup(name string) slog.Handler {
+	return &RedactHandler{Handler: h.Handler.WithGroup(name), redactor: h.redactor}
+}
+
+// NewRedactor is shared by the application logger and the database one.
+func NewRedactor(config config.Config) *redact.Redactor {
+	redactConfig := config.GetLoggingConfig().Redact
+	return redact.New(redact.Options{
+		Keys:    redactConfig.Keys,
+		Hash:    redactConfig.Mode == "hash",
+		HashKey: redactConfig.HashKey,
+	})
+}
@@ -7,10 +7,11 @@ import (
 	"strings"
 
 	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/redact"
 	"github.com/umefy/godash/logger"
 )
 
-func NewLogger(config config.Config) Logger {
+func NewLogger(config config.Config, redactor *redact.Redactor) Logger {
 	loggingConfig := config.GetLoggingConfig()
 
 	loggerOpts := logger.NewLoggerOps(
@@ -22,9 +23,10 @@ func NewLogger(config config.Config) Logger {
 		4,
 	)
 	logger := logger.New(loggerOpts, func(handler slog.Handler) slog.Handler {
-		return handler.WithAttrs([]slog.Attr{
+		// redacted first, the span events must not get the sensitive values either
+		return NewRedactHandler(NewTraceHandler(handler.WithAttrs([]slog.Attr{
 			slog.Int("pid", os.Getpid()),
-		})
+		}), loggingConfig.SpanEvents), redactor)
 	})
 
 	return NewAppLogger(logger)
@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHandler adds the trace_id and span_id of the span in the context to every record, so that logs can be
+// found from a trace and the other way around. Like every record attribute, they go in the groups opened with
+// WithGroup. With spanEvents, error records are also added as events to the span, they show up in the trace
+// next to the operation that failed.
+type TraceHandler struct {
+	slog.Handler
+	spanEvents bool
+	// attrs and groups are kept for the span events, the wrapped handler already has them
+	attrs  []slog.Attr
+	groups []string
+}
+
+var _ slog.Handler = (*TraceHandler)(nil)
+
+func NewTraceHandler(handler slog.Handler, spanEvents bool) *TraceHandler {
+	return &TraceHandler{Handler: handler, spanEvents: spanEvents}
+}
+
+func (h *TraceHandler) Handle(ctx context.Context, r slog.Record) error {
+	span := trace.SpanFromContext(ctx)
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return h.Handler.Handle(ctx, r)
+	}
+
+	if h.spanEvents && r.Level >= slog.LevelError && span.IsRecording() {
+		span.AddEvent(r.Message, trace.WithTimestamp(r.Time), trace.WithAttributes(h.eventAttributes(r)...))
+	}
+
+	r = r.Clone()
+	r.AddAttrs(
+		slog.String("trace_id", spanContext.TraceID().String()),
+		slog.String("span_id", spanContext.SpanID().String()),
+	)
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *TraceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithAttrs(attrs)
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), h.prefixed(attrs)...)
+	return &clone
+}
+
+func (h *TraceHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithGroup(name)
+	clone.groups = append(append([]string{}, h.groups...), name)
+	return &clone
+}
+
+func (h *TraceHandler) eventAttributes(r slog.Record) []attribute.KeyValue {
+	attrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
+	attrs = append(attrs, h.attrs...)
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, h.prefixed([]slog.Attr{attr})...)
+		return true
+	})
+
+	result := []attribute.KeyValue{attribute.String("log.severity", r.Level.String())}
+	for _, attr := range attrs {
+		result = appendAttribute(result, attr.Key, attr.Value)
+	}
+	return result
+}
+
+// prefixed qualifies the keys with the groups opened so far, the way the JSON handler nests them.
+func (h *TraceHandler) prefixed(attrs []slog.Attr) []slog.Attr {
+	if len(h.groups) == 0 {
+		return attrs
+	}
+
+	prefix := ""
+	for _, group := range h.groups {
+		prefix += group + "."
+	}
+
+	result := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		result[i] = slog.Attr{Key: prefix + attr.Key, Value: attr.Value}
+	}
+	return result
+}
+
+func appendAttribute(result []attribute.KeyValue, key string, value slog.Value) []attribute.KeyValue {
+	value = value.Resolve()
+	switch value.Kind() {
+	case slog.KindGroup:
+		for _, attr := range value.Group() {
+			nested := attr.Key
+			if key != "" {
+				nested = key + "." + attr.Key
+			}
+			result = appendAttribute(result, nested, attr.Value)
+		}
+		return result
+	case slog.KindBool:
+		return append(result, attribute.Bool(key, value.Bool()))
+	case slog.KindInt64:
+		return append(result, attribute.Int64(key, value.Int64()))
+	case slog.KindFloat64:
+		return append(result, attribute.Float64(key, value.Float64()))
+	case slog.KindString:
+		return append(result, attribute.String(key, value.String()))
+	default:
+		return append(result, attribute.String(key, fmt.Sprint(value.Any())))
+	}
+}
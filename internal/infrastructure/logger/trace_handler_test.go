@@ -0,0 +1,215 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/pkg/redact"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TraceHandlerSuite struct {
+	suite.Suite
+	buf      bytes.Buffer
+	recorder *tracetest.SpanRecorder
+	tracer   trace.Tracer
+}
+
+func (s *TraceHandlerSuite) SetupTest() {
+	s.buf.Reset()
+	s.recorder = tracetest.NewSpanRecorder()
+	s.tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.recorder)).Tracer("test")
+}
+
+// jsonHandler writes records to buf without their time, which changes from run to run.
+func (s *TraceHandlerSuite) jsonHandler() slog.Handler {
+	return slog.NewJSONHandler(&s.buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+}
+
+// logged returns the last record written to buf.
+func (s *TraceHandlerSuite) logged() map[string]any {
+	lines := bytes.Split(bytes.TrimSpace(s.buf.Bytes()), []byte("\n"))
+	var result map[string]any
+	s.Require().NoError(json.Unmarshal(lines[len(lines)-1], &result))
+	return result
+}
+
+// events ends span and returns the attributes of its events, by event name.
+func (s *TraceHandlerSuite) events(span trace.Span) map[string]map[string]any {
+	span.End()
+	ended := s.recorder.Ended()
+	s.Require().NotEmpty(ended)
+
+	result := map[string]map[string]any{}
+	for _, event := range ended[len(ended)-1].Events() {
+		result[event.Name] = attributes(event.Attributes)
+	}
+	return result
+}
+
+func attributes(kvs []attribute.KeyValue) map[string]any {
+	result := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		result[string(kv.Key)] = kv.Value.AsInterface()
+	}
+	return result
+}
+
+func (s *TraceHandlerSuite) TestTraceIDs() {
+	remote := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	recording, span := s.tracer.Start(s.T().Context(), "operation")
+	defer span.End()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected trace.SpanContext
+	}{
+		{name: "no span", ctx: s.T().Context()},
+		{name: "invalid span context", ctx: trace.ContextWithSpanContext(s.T().Context(), trace.SpanContext{})},
+		{name: "remote span context", ctx: trace.ContextWithRemoteSpanContext(s.T().Context(), remote), expected: remote},
+		{name: "recording span", ctx: recording, expected: span.SpanContext()},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			slog.New(NewTraceHandler(s.jsonHandler(), false)).InfoContext(test.ctx, "hello")
+
+			record := s.logged()
+			if !test.expected.IsValid() {
+				s.NotContains(record, "trace_id")
+				s.NotContains(record, "span_id")
+				return
+			}
+			s.Equal(test.expected.TraceID().String(), record["trace_id"])
+			s.Equal(test.expected.SpanID().String(), record["span_id"])
+		})
+	}
+}
+
+func (s *TraceHandlerSuite) TestSpanEvents() {
+	tests := []struct {
+		name       string
+		spanEvents bool
+		level      slog.Level
+		expected   bool
+	}{
+		{name: "disabled", spanEvents: false, level: slog.LevelError},
+		{name: "below error", spanEvents: true, level: slog.LevelWarn},
+		{name: "error", spanEvents: true, level: slog.LevelError, expected: true},
+		{name: "above error", spanEvents: true, level: slog.LevelError + 4, expected: true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			ctx, span := s.tracer.Start(s.T().Context(), "operation")
+			slog.New(NewTraceHandler(s.jsonHandler(), test.spanEvents)).Log(ctx, test.level, "failed", "attempt", 2)
+
+			events := s.events(span)
+			if !test.expected {
+				s.Empty(events)
+				return
+			}
+			s.Equal(map[string]map[string]any{
+				"failed": {"log.severity": test.level.String(), "attempt": int64(2)},
+			}, events)
+			// the record is still logged
+			s.Equal("failed", s.logged()[slog.MessageKey])
+		})
+	}
+
+	// spans that are not recorded get no events, but their records still get the ids
+	ctx := trace.ContextWithRemoteSpanContext(s.T().Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	}))
+	s.NotPanics(func() {
+		slog.New(NewTraceHandler(s.jsonHandler(), true)).ErrorContext(ctx, "failed")
+	})
+	s.Contains(s.logged(), "trace_id")
+}
+
+func (s *TraceHandlerSuite) TestAttrsAndGroups() {
+	ctx, span := s.tracer.Start(s.T().Context(), "operation")
+
+	logger := slog.New(NewTraceHandler(s.jsonHandler(), true)).
+		With("user_id", 1).
+		WithGroup("request").
+		With("method", "GET").
+		WithGroup("response")
+	logger.ErrorContext(ctx, "failed", "status", 500, slog.Group("error", slog.String("code", "E1"), slog.Bool("retry", false)))
+
+	s.Equal(map[string]map[string]any{
+		"failed": {
+			"log.severity":                 "ERROR",
+			"user_id":                      int64(1),
+			"request.method":               "GET",
+			"request.response.status":      int64(500),
+			"request.response.error.code":  "E1",
+			"request.response.error.retry": false,
+		},
+	}, s.events(span))
+
+	// the ids are record attributes, they go in the groups opened so far like the others
+	record := s.logged()
+	s.Equal(float64(1), record["user_id"])
+	request := record["request"].(map[string]any)
+	s.Equal("GET", request["method"])
+	response := request["response"].(map[string]any)
+	s.Equal(float64(500), response["status"])
+	s.Equal(span.SpanContext().TraceID().String(), response["trace_id"])
+
+	// WithAttrs and WithGroup return new handlers, the parent ones are left as they were
+	ctx, span = s.tracer.Start(s.T().Context(), "operation")
+	slog.New(NewTraceHandler(s.jsonHandler(), true)).With("user_id", 1).ErrorContext(ctx, "failed")
+	s.Equal(map[string]map[string]any{
+		"failed": {"log.severity": "ERROR", "user_id": int64(1)},
+	}, s.events(span))
+}
+
+// TestRedacted builds the handlers in the order NewLogger does, the span events must not get the sensitive
+// values either.
+func (s *TraceHandlerSuite) TestRedacted() {
+	redactor := redact.New(redact.Options{Keys: []string{"*email*", "*token*"}})
+	handler := NewRedactHandler(NewTraceHandler(s.jsonHandler(), true), redactor)
+
+	ctx, span := s.tracer.Start(s.T().Context(), "operation")
+	slog.New(handler).
+		With("email", "john@example.com").
+		WithGroup("request").
+		ErrorContext(ctx, "failed", "access_token", "abc", "user", slog.GroupValue(slog.String("email", "jane@example.com")))
+
+	s.Equal(map[string]map[string]any{
+		"failed": {
+			"log.severity":         "ERROR",
+			"email":                redact.Mask,
+			"request.access_token": redact.Mask,
+			"request.user.email":   redact.Mask,
+		},
+	}, s.events(span))
+
+	s.NotContains(s.buf.String(), "john@example.com")
+	s.NotContains(s.buf.String(), "jane@example.com")
+	s.NotContains(s.buf.String(), `"abc"`)
+}
+
+func TestTraceHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TraceHandlerSuite))
+}
@@ -0,0 +1,24 @@
+package metrics
+
+import "go.uber.org/fx"
+
+const (
+	FX_TAG_NAME_METRICS_HANDLER = `name:"metricsHandler"`
+)
+
+var Module = fx.Module("metrics",
+	fx.Provide(
+		NewRegistry,
+		NewMeterProvider,
+		fx.Annotate(
+			NewHandler,
+			fx.ResultTags(FX_TAG_NAME_METRICS_HANDLER),
+		),
+	),
+	fx.Invoke(
+		fx.Annotate(
+			startAdminServer,
+			fx.ParamTags(``, ``, ``, FX_TAG_NAME_METRICS_HANDLER),
+		),
+	),
+)
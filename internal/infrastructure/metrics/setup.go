@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"go.opentelemetry.io/otel"
+	otelPrometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.uber.org/fx"
+)
+
+// NewRegistry holds the OpenTelemetry metrics along with the Go runtime and process ones.
+func NewRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// NewMeterProvider exports the metrics to registry and becomes the global provider, for the instrumentations
+// that only use the global one. It is a no-op provider when metrics are disabled.
+func NewMeterProvider(ctx context.Context, config config.Config, registry *prometheus.Registry, lc fx.Lifecycle) (metric.MeterProvider, error) {
+	if !config.GetMetricsConfig().Enabled {
+		return noop.NewMeterProvider(), nil
+	}
+
+	exporter, err := otelPrometheus.New(otelPrometheus.WithRegisterer(registry))
+	if err != nil {
+		return nil, err
+	}
+
+	tracingConfig := config.GetTracingConfig()
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceNameKey.String(tracingConfig.ServiceName),
+			semconv.ServiceVersionKey.String(config.GetAppConfig().Version),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	meterProvider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(meterProvider)
+
+	lc.Append(fx.Hook{
+		OnStop: meterProvider.Shutdown,
+	})
+
+	return meterProvider, nil
+}
+
+// NewHandler serves the registry in the Prometheus format, it returns nil when metrics are disabled.
+func NewHandler(config config.Config, registry *prometheus.Registry) http.Handler {
+	if !config.GetMetricsConfig().Enabled {
+		return nil
+	}
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// startAdminServer serves the metrics on their own port when one is set, the HTTP server mounts them otherwise.
+// It is stopped after the HTTP and gRPC servers, so that the shutdown can still be watched.
+func startAdminServer(config config.Config, logger logger.Logger, lc fx.Lifecycle, handler http.Handler) {
+	metricsConfig := config.GetMetricsConfig()
+	if !metricsConfig.Enabled || metricsConfig.Port == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsConfig.Endpoint, handler)
+	server := &http.Server{
+		Addr:              fmt.Sprintf("0.0.0.0:%d", metricsConfig.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// listening here makes a port already in use fail the start
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return err
+			}
+
+			logger.InfoContext(ctx, "Starting metrics server", slog.String("address", server.Addr))
+			go func() {
+				if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.ErrorContext(ctx, "Metrics server error", slog.String("error", err.Error()))
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		},
+	})
+}
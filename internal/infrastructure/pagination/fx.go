@@ -0,0 +1,7 @@
+package pagination
+
+import "go.uber.org/fx"
+
+var Module = fx.Module("pagination",
+	fx.Provide(NewCursorCodec),
+)
@@ -0,0 +1,10 @@
+package pagination
+
+import (
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/pagination"
+)
+
+func NewCursorCodec(config config.Config) *pagination.CursorCodec {
+	return pagination.NewCursorCodec([]byte(config.GetPaginationConfig().CursorSecret))
+}
@@ -0,0 +1,9 @@
+package ratelimit
+
+import (
+	"net/http"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+)
+
+var RateLimitExceeded = appError.NewError("rateLimit_1001", "rate limit exceeded, retry later", http.StatusTooManyRequests)
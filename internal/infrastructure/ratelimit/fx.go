@@ -0,0 +1,16 @@
+package ratelimit
+
+import (
+	"github.com/umefy/go-web-app-template/internal/infrastructure/health"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("rateLimit",
+	fx.Provide(
+		NewLimiter,
+		fx.Annotate(
+			NewHealthCheckers,
+			fx.ResultTags(health.FX_TAG_GROUP_HEALTH_CHECKERS),
+		),
+	),
+)
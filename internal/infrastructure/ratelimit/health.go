@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/health"
+)
+
+// NewHealthCheckers pings the Redis store. It is not critical since the limiter lets requests through while
+// Redis is unavailable.
+func NewHealthCheckers(limiter *Limiter) []health.Checker {
+	if limiter == nil {
+		return nil
+	}
+
+	pinger, ok := limiter.store.(health.Pinger)
+	if !ok {
+		return nil
+	}
+	return []health.Checker{health.Ping("rate_limit_store", false, pinger)}
+}
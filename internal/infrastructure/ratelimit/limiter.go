@@ -0,0 +1,229 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/ratelimit"
+)
+
+// Request is what the policies are matched and keyed on.
+type Request struct {
+	// Route is the HTTP path or the gRPC full method
+	Route string
+	// Operation is the GraphQL operation name, only set once the operation is parsed
+	Operation string
+	Principal string
+	// APIKey is the verified API key, see auth.WithAPIKey. Keys sent by clients but not verified must not be
+	// used, a client would get new counters by sending a new key on each request
+	APIKey string
+	IP     string
+}
+
+// Limiter applies the rate limit policies of the config.
+type Limiter struct {
+	store    ratelimit.Store
+	policies []config.RateLimitPolicyConfig
+	logger   logger.Logger
+}
+
+func newLimiter(store ratelimit.Store, rateLimitConfig config.RateLimitConfig, logger logger.Logger) *Limiter {
+	return &Limiter{
+		store:    store,
+		policies: rateLimitConfig.Policies,
+		logger:   logger,
+	}
+}
+
+// Check counts req against every policy matching it. Policies with GraphQL operations only match requests
+// with an Operation, and the others only requests without one, so that a GraphQL request is not counted
+// twice by the same policy.
+//
+// The result is the one of the most restrictive policy, it reports false when no policy matched. Store
+// errors are logged and let the request through, an unavailable Redis must not take the API down.
+func (l *Limiter) Check(ctx context.Context, req Request) (ratelimit.Result, bool) {
+	return l.check(ctx, req, nil)
+}
+
+// check is Check, skipping the policies that already counted the request checked by counted, when set.
+func (l *Limiter) check(ctx context.Context, req Request, counted *httpCheck) (ratelimit.Result, bool) {
+	var decision ratelimit.Result
+	var matched bool
+
+	for _, policy := range l.policies {
+		routeGroup, ok := policy.MatchRoute(req.Route)
+		if !ok || !matchOperation(policy, req.Operation) {
+			continue
+		}
+		if counted != nil && !counted.count(policy.Name) {
+			continue
+		}
+
+		window := time.Duration(policy.WindowInSeconds) * time.Second
+		result, err := l.store.Hit(ctx, policyKey(policy, req, routeGroup), policy.Limit, window)
+		if err != nil {
+			l.logger.WarnContext(ctx, "Rate limit store failed", slog.String("policy", policy.Name), slog.String("error", err.Error()))
+			continue
+		}
+
+		if !matched || moreRestrictive(result, decision) {
+			decision = result
+		}
+		matched = true
+	}
+
+	return decision, matched
+}
+
+func matchOperation(policy config.RateLimitPolicyConfig, operation string) bool {
+	if len(policy.GraphqlOperations) == 0 {
+		return operation == ""
+	}
+	return slices.Contains(policy.GraphqlOperations, operation)
+}
+
+// moreRestrictive tells whether a is to be reported over b: refusals first, the longest wait among them,
+// and otherwise the fewest remaining requests.
+func moreRestrictive(a, b ratelimit.Result) bool {
+	if a.Allowed != b.Allowed {
+		return !a.Allowed
+	}
+	if !a.Allowed {
+		return a.Reset > b.Reset
+	}
+	return a.Remaining < b.Remaining
+}
+
+func policyKey(policy config.RateLimitPolicyConfig, req Request, routeGroup string) string {
+	parts := []string{policy.Name}
+	for _, keyBy := range policy.KeyBy {
+		switch keyBy {
+		case "ip":
+			parts = append(parts, "ip="+req.IP)
+		case "principal":
+			parts = append(parts, clientKey(req, true))
+		case "api_key":
+			parts = append(parts, clientKey(req, false))
+		case "route_group":
+			parts = append(parts, "route="+routeGroup)
+		case "graphql_operation":
+			parts = append(parts, "operation="+req.Operation)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// clientKey identifies the client by principal when withPrincipal is set, or else by verified API key, or else
+// by IP.
+// API keys are hashed so that they are not stored as is.
+func clientKey(req Request, withPrincipal bool) string {
+	switch {
+	case withPrincipal && req.Principal != "":
+		return "principal=" + req.Principal
+	case req.APIKey != "":
+		sum := sha256.Sum256([]byte(req.APIKey))
+		return "api_key=" + hex.EncodeToString(sum[:8])
+	default:
+		return "ip=" + req.IP
+	}
+}
+
+// CheckHTTP checks an HTTP request and sets its rate limit headers, it returns RateLimitExceeded when the
+// request is refused. The returned context keeps the request for CheckOperation and CheckMethod.
+func (l *Limiter) CheckHTTP(ctx context.Context, req Request, header http.Header) (context.Context, error) {
+	check := &httpCheck{request: req, header: header, counted: map[string]bool{}}
+	check.result, check.matched = l.check(ctx, req, check)
+	if check.matched {
+		ratelimit.SetHeaders(header, check.result)
+	}
+
+	ctx = context.WithValue(ctx, httpCheckCtxKey{}, check)
+	if check.matched && !check.result.Allowed {
+		return ctx, RateLimitExceeded
+	}
+	return ctx, nil
+}
+
+// CheckOperation checks a GraphQL operation of the HTTP request checked by CheckHTTP, for the same client.
+// The rate limit headers are replaced when the operation is more restricted than the request, as long as
+// the response is not sent, which is not the case for operations over WebSocket.
+func (l *Limiter) CheckOperation(ctx context.Context, operation string) error {
+	check, ok := ctx.Value(httpCheckCtxKey{}).(*httpCheck)
+	if !ok {
+		return nil
+	}
+
+	req := check.request
+	req.Operation = operation
+	result, matched := l.Check(ctx, req)
+	return check.update(result, matched)
+}
+
+// CheckMethod checks a gRPC method called in-process while handling the HTTP request checked by CheckHTTP, as
+// the REST gateway does, for the same client. The policies that counted the HTTP request are skipped, so that
+// a policy matching both the path and the method counts the request once. It reports false outside of an
+// HTTP request, the method is then to be checked with Check.
+func (l *Limiter) CheckMethod(ctx context.Context, method string) (bool, error) {
+	check, ok := ctx.Value(httpCheckCtxKey{}).(*httpCheck)
+	if !ok {
+		return false, nil
+	}
+
+	req := check.request
+	req.Route = method
+	result, matched := l.check(ctx, req, check)
+	return true, check.update(result, matched)
+}
+
+type httpCheckCtxKey struct{}
+
+// httpCheck is guarded by mu since the operations of a WebSocket connection run concurrently.
+type httpCheck struct {
+	mu      sync.Mutex
+	request Request
+	header  http.Header
+	result  ratelimit.Result
+	matched bool
+	// counted holds the names of the policies that counted the request, GraphQL operations aside
+	counted map[string]bool
+}
+
+// count records that policy counts the request, it reports false when it already did.
+func (c *httpCheck) count(policy string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counted[policy] {
+		return false
+	}
+	c.counted[policy] = true
+	return true
+}
+
+// update replaces the rate limit headers with result when it is more restrictive, and returns
+// RateLimitExceeded when result refuses the request.
+func (c *httpCheck) update(result ratelimit.Result, matched bool) error {
+	if !matched {
+		return nil
+	}
+
+	c.mu.Lock()
+	if !c.matched || moreRestrictive(result, c.result) {
+		c.result, c.matched = result, true
+		ratelimit.SetHeaders(c.header, result)
+	}
+	c.mu.Unlock()
+
+	if !result.Allowed {
+		return RateLimitExceeded
+	}
+	return nil
+}
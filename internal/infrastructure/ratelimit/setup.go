@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/ratelimit"
+	"go.uber.org/fx"
+)
+
+type LimiterParams struct {
+	fx.In
+
+	Config    config.Config
+	Logger    logger.Logger
+	Lifecycle fx.Lifecycle
+}
+
+// NewLimiter returns nil when rate limiting is disabled.
+func NewLimiter(params LimiterParams) (*Limiter, error) {
+	rateLimitConfig := params.Config.GetRateLimitConfig()
+	if !rateLimitConfig.Enabled {
+		return nil, nil
+	}
+
+	store, err := newStore(rateLimitConfig, params.Lifecycle)
+	if err != nil {
+		return nil, err
+	}
+
+	return newLimiter(store, rateLimitConfig, params.Logger), nil
+}
+
+func newStore(rateLimitConfig config.RateLimitConfig, lc fx.Lifecycle) (ratelimit.Store, error) {
+	switch rateLimitConfig.Store {
+	case "memory":
+		return ratelimit.NewMemoryStore(), nil
+	case "redis":
+		options, err := redis.ParseURL(rateLimitConfig.Redis.Url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit redis url: %w", err)
+		}
+		client := redis.NewClient(options)
+
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				defer cancel()
+				return client.Ping(ctx).Err()
+			},
+			OnStop: func(ctx context.Context) error {
+				return client.Close()
+			},
+		})
+
+		return ratelimit.NewRedisStore(client, rateLimitConfig.Redis.KeyPrefix), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s", rateLimitConfig.Store)
+	}
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	appHealth "github.com/umefy/go-web-app-template/pkg/health"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// healthChecker keeps the grpc.health.v1 statuses in line with readiness: every registered service, and the
+// overall "" entry, is SERVING while no critical check fails and NOT_SERVING otherwise.
+type healthChecker struct {
+	server   *health.Server
+	registry *appHealth.Registry
+	logger   logger.Logger
+	interval time.Duration
+	services []string
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func newHealthChecker(registry *appHealth.Registry, logger logger.Logger, interval time.Duration) *healthChecker {
+	return &healthChecker{
+		server:   health.NewServer(),
+		registry: registry,
+		logger:   logger,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// register adds the health service to grpcServer. It must run after all other services are registered,
+// since those are the ones reported on.
+func (h *healthChecker) register(grpcServer *grpc.Server) {
+	h.services = []string{""}
+	for name := range grpcServer.GetServiceInfo() {
+		h.services = append(h.services, name)
+	}
+	h.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	healthpb.RegisterHealthServer(grpcServer, h.server)
+}
+
+func (h *healthChecker) start(ctx context.Context) {
+	h.check(ctx)
+
+	go func() {
+		defer close(h.done)
+
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-h.registry.Done():
+				// readiness fails as soon as the shutdown starts, clients move away during the drain delay
+				h.server.Shutdown()
+				return
+			case <-ticker.C:
+				h.check(context.WithoutCancel(ctx))
+			}
+		}
+	}()
+}
+
+// shutdown stops the checks and reports NOT_SERVING from now on, so clients move away before the server stops.
+func (h *healthChecker) shutdown() {
+	close(h.stop)
+	<-h.done
+	h.server.Shutdown()
+}
+
+// check relies on the registry for the check timeouts and caching, the HTTP probes share the same results.
+func (h *healthChecker) check(ctx context.Context) {
+	report := h.registry.Check(ctx)
+	if report.Status == appHealth.StatusFailing {
+		for name, result := range report.Checks {
+			if result.Status == appHealth.StatusFailing && result.Critical {
+				h.logger.WarnContext(ctx, "gRPC health check failed", slog.String("check", name), slog.String("error", result.Error))
+			}
+		}
+		h.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		return
+	}
+
+	h.setStatus(healthpb.HealthCheckResponse_SERVING)
+}
+
+func (h *healthChecker) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
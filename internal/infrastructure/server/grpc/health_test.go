@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	appHealth "github.com/umefy/go-web-app-template/pkg/health"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type HealthCheckerSuite struct {
+	suite.Suite
+	mu       sync.Mutex
+	checkErr error
+	checker  *healthChecker
+	client   healthpb.HealthClient
+}
+
+func (s *HealthCheckerSuite) SetupTest() {
+	s.checkErr = errors.New("connection refused")
+	registry := appHealth.NewRegistry(appHealth.Options{}, appHealth.Checker{
+		Name:     "database",
+		Critical: true,
+		Check: func(ctx context.Context) error {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return s.checkErr
+		},
+	})
+
+	logger := loggerMocks.NewMockLogger(s.T())
+	logger.EXPECT().WarnContext(mock.Anything, "gRPC health check failed", mock.Anything, mock.Anything).Maybe()
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterGreeterServer(grpcServer, pb.UnimplementedGreeterServer{})
+	s.checker = newHealthChecker(registry, logger, 10*time.Millisecond)
+	s.checker.register(grpcServer)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go grpcServer.Serve(listener) //nolint:errcheck
+	s.T().Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() }) //nolint:errcheck
+	s.client = healthpb.NewHealthClient(conn)
+}
+
+func (s *HealthCheckerSuite) setCheckErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkErr = err
+}
+
+func (s *HealthCheckerSuite) status(service string) healthpb.HealthCheckResponse_ServingStatus {
+	resp, err := s.client.Check(s.T().Context(), &healthpb.HealthCheckRequest{Service: service})
+	s.Require().NoError(err)
+	return resp.Status
+}
+
+// eventually waits for the next checks to report status for the server and the greeter service.
+func (s *HealthCheckerSuite) eventually(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.Eventually(func() bool {
+		return s.status("") == status && s.status(pb.Greeter_ServiceDesc.ServiceName) == status
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *HealthCheckerSuite) TestFollowsReadiness() {
+	// nothing is served before the first check
+	s.Equal(healthpb.HealthCheckResponse_NOT_SERVING, s.status(""))
+
+	s.checker.start(s.T().Context())
+	s.eventually(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	s.setCheckErr(nil)
+	s.eventually(healthpb.HealthCheckResponse_SERVING)
+
+	s.setCheckErr(errors.New("connection refused"))
+	s.eventually(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	s.setCheckErr(nil)
+	s.eventually(healthpb.HealthCheckResponse_SERVING)
+
+	s.checker.shutdown()
+	s.eventually(healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func (s *HealthCheckerSuite) TestUnknownService() {
+	_, err := s.client.Check(s.T().Context(), &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+	s.Error(err)
+}
+
+func TestHealthCheckerSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckerSuite))
+}
@@ -5,17 +5,27 @@ import (
 	"fmt"
 	"log/slog"
 	"net"
+	"time"
 
 	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/delivery/grpc/interceptor"
+	userHandler "github.com/umefy/go-web-app-template/internal/delivery/grpc/user"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"github.com/umefy/go-web-app-template/pkg/health"
 	"github.com/umefy/go-web-app-template/pkg/server/grpcserver"
 	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"google.golang.org/grpc"
+	channelzService "google.golang.org/grpc/channelz/service"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
 )
 
@@ -26,36 +36,95 @@ type GrpcServerParams struct {
 	Config         config.Config
 	Logger         logger.Logger
 	GreeterServer  pb.GreeterServer
+	UserServer     pb.UserServiceServer
 	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	DbQuery        *database.Query
+	HealthRegistry *health.Registry
+	RateLimiter    *ratelimit.Limiter
+	Lifecycle      fx.Lifecycle
 }
 
 func registerServices(grpcServer *grpc.Server, params GrpcServerParams) {
 	pb.RegisterGreeterServer(grpcServer, params.GreeterServer)
+	pb.RegisterUserServiceServer(grpcServer, params.UserServer)
+}
+
+// registerOperationalServices adds health, channelz and reflection, each toggled in the grpc_server config.
+// Health goes after the application services since it reports on everything registered so far.
+func registerOperationalServices(grpcServer *grpc.Server, params GrpcServerParams) {
+	grpcConfig := params.Config.GetGrpcServerConfig()
+
+	if grpcConfig.HealthCheck {
+		checker := newHealthChecker(params.HealthRegistry, params.Logger, time.Duration(grpcConfig.HealthCheckIntervalInSeconds)*time.Second)
+		checker.register(grpcServer)
+
+		params.Lifecycle.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				checker.start(ctx)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				checker.shutdown()
+				return nil
+			},
+		})
+	}
+
+	if grpcConfig.Channelz {
+		channelzService.RegisterChannelzServiceToServer(grpcServer)
+	}
+
+	if grpcConfig.Reflection {
+		reflection.Register(grpcServer)
+	}
 }
 
 func NewServer(params GrpcServerParams) (*grpcserver.GrpcServer, error) {
 
-	if !params.Config.GetGrpcServerConfig().Enabled {
+	grpcConfig := params.Config.GetGrpcServerConfig()
+	if !grpcConfig.Enabled {
 		return nil, nil
 	}
 
-	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", params.Config.GetGrpcServerConfig().Port))
+	unaryMetrics, err := interceptor.UnaryMetrics(params.MeterProvider)
+	if err != nil {
+		return nil, err
+	}
+	streamMetrics, err := interceptor.StreamMetrics(params.MeterProvider)
 	if err != nil {
 		return nil, err
 	}
 
-	grpcServer := grpc.NewServer(
+	serverOptions := []grpc.ServerOption{
 		grpc.StatsHandler(
 			otelgrpc.NewServerHandler(
 				otelgrpc.WithTracerProvider(params.TracerProvider),
 				otelgrpc.WithPropagators(otel.GetTextMapPropagator()),
 			),
 		),
-		grpc.ChainUnaryInterceptor(unaryRecoveryInterceptor(params.Logger)),
-		grpc.ChainStreamInterceptor(streamRecoveryInterceptor(params.Logger)),
-	)
+		grpc.ChainUnaryInterceptor(unaryInterceptors(params, unaryMetrics)...),
+		grpc.ChainStreamInterceptor(streamInterceptors(params, streamMetrics)...),
+	}
+
+	if grpcConfig.TLS.Enabled {
+		tlsOption, err := tlsServerOption(grpcConfig.TLS, params.Logger, params.Lifecycle)
+		if err != nil {
+			return nil, err
+		}
+		serverOptions = append(serverOptions, tlsOption)
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcConfig.Port))
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer := grpc.NewServer(serverOptions...)
 
 	registerServices(grpcServer, params)
+	registerOperationalServices(grpcServer, params)
+
 	server := grpcserver.New(listener, grpcServer, params.Logger.GetLogger())
 	return server, nil
 }
@@ -69,7 +138,7 @@ func unaryRecoveryInterceptor(logger logger.Logger) grpc.UnaryServerInterceptor
 	) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.Error("Recovered from panic in %s: %v", slog.String("method", info.FullMethod), slog.Any("error", r))
+				logger.ErrorContext(ctx, "Recovered from panic", slog.String("method", info.FullMethod), slog.Any("error", r))
 				err = status.Errorf(codes.Internal, "Internal server error")
 			}
 		}()
@@ -86,10 +155,48 @@ func streamRecoveryInterceptor(logger logger.Logger) grpc.StreamServerIntercepto
 	) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				logger.Error("Recovered from panic in %s: %v", slog.String("method", info.FullMethod), slog.Any("error", r))
-				err = status.Errorf(codes.Internal, "Panic in %s: %v", info.FullMethod, r)
+				logger.ErrorContext(stream.Context(), "Recovered from panic", slog.String("method", info.FullMethod), slog.Any("error", r))
+				err = status.Errorf(codes.Internal, "Internal server error")
 			}
 		}()
 		return handler(srv, stream)
 	}
 }
+
+// unaryInterceptors are the ones of the REST gateway plus the metrics and the panic recovery, which the HTTP
+// server has its own of.
+func unaryInterceptors(params GrpcServerParams, metrics grpc.UnaryServerInterceptor) []grpc.UnaryServerInterceptor {
+	grpcConfig := params.Config.GetGrpcServerConfig()
+	authConfig := params.Config.GetAuthConfig()
+
+	return interceptor.UnaryChain(interceptor.UnaryChainParams{
+		Logger:             params.Logger,
+		Principals:         grpcConfig.TLS.PrincipalsBySubject(),
+		APIKeyHeader:       authConfig.APIKeyHeader,
+		APIKeys:            auth.NewAPIKeys(authConfig.PrincipalsByKeyHash()),
+		RateLimiter:        params.RateLimiter,
+		DbQuery:            params.DbQuery,
+		TransactionMethods: userHandler.TransactionalMethods,
+		Metrics:            metrics,
+		Recovery:           unaryRecoveryInterceptor(params.Logger),
+	})
+}
+
+// streamInterceptors follow the order of interceptor.UnaryChain.
+func streamInterceptors(params GrpcServerParams, metrics grpc.StreamServerInterceptor) []grpc.StreamServerInterceptor {
+	grpcConfig := params.Config.GetGrpcServerConfig()
+	authConfig := params.Config.GetAuthConfig()
+
+	interceptors := []grpc.StreamServerInterceptor{
+		interceptor.StreamRequestID(params.Logger),
+		interceptor.StreamPrincipal(grpcConfig.TLS.PrincipalsBySubject()),
+		interceptor.StreamLogging(params.Logger),
+		metrics,
+		interceptor.StreamError(params.Logger),
+		interceptor.StreamAPIKey(authConfig.APIKeyHeader, auth.NewAPIKeys(authConfig.PrincipalsByKeyHash())),
+	}
+	if params.RateLimiter != nil {
+		interceptors = append(interceptors, interceptor.StreamRateLimit(params.RateLimiter))
+	}
+	return append(interceptors, streamRecoveryInterceptor(params.Logger))
+}
@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	appHealth "github.com/umefy/go-web-app-template/pkg/health"
+	pb "github.com/umefy/go-web-app-template/protogen/grpc/service"
+	"go.uber.org/fx/fxtest"
+	"google.golang.org/grpc"
+)
+
+type OperationalServicesSuite struct {
+	suite.Suite
+}
+
+func (s *OperationalServicesSuite) TestRegisteredByConfig() {
+	const (
+		health     = "grpc.health.v1.Health"
+		reflection = "grpc.reflection.v1.ServerReflection"
+		channelz   = "grpc.channelz.v1.Channelz"
+	)
+
+	tests := []struct {
+		name     string
+		config   config.GrpcServerConfig
+		expected []string
+	}{
+		{
+			name:     "all off",
+			config:   config.GrpcServerConfig{},
+			expected: nil,
+		},
+		{
+			name:     "health check",
+			config:   config.GrpcServerConfig{HealthCheck: true, HealthCheckIntervalInSeconds: 5},
+			expected: []string{health},
+		},
+		{
+			name:     "reflection",
+			config:   config.GrpcServerConfig{Reflection: true},
+			expected: []string{reflection},
+		},
+		{
+			name:     "channelz",
+			config:   config.GrpcServerConfig{Channelz: true},
+			expected: []string{channelz},
+		},
+		{
+			name:     "all on",
+			config:   config.GrpcServerConfig{HealthCheck: true, HealthCheckIntervalInSeconds: 5, Reflection: true, Channelz: true},
+			expected: []string{channelz, health, reflection},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			grpcServer := grpc.NewServer()
+			pb.RegisterGreeterServer(grpcServer, pb.UnimplementedGreeterServer{})
+
+			registerOperationalServices(grpcServer, GrpcServerParams{
+				Config:         config.NewAppConfig(config.AppConfig{GrpcServer: test.config}),
+				Logger:         loggerMocks.NewMockLogger(s.T()),
+				HealthRegistry: appHealth.NewRegistry(appHealth.Options{}),
+				Lifecycle:      fxtest.NewLifecycle(s.T()),
+			})
+
+			var registered []string
+			for _, name := range slices.Sorted(maps.Keys(grpcServer.GetServiceInfo())) {
+				if slices.Contains([]string{health, reflection, channelz}, name) {
+					registered = append(registered, name)
+				}
+			}
+			s.Equal(test.expected, registered)
+		})
+	}
+}
+
+func TestOperationalServicesSuite(t *testing.T) {
+	suite.Run(t, new(OperationalServicesSuite))
+}
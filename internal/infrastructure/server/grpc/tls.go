@@ -0,0 +1,20 @@
+package grpc
+
+import (
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/server/servertls"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// tlsServerOption serves TLS, or mutual TLS when a client CA is set, from the configured files.
+func tlsServerOption(tlsConfig config.TLSConfig, logger logger.Logger, lc fx.Lifecycle) (grpc.ServerOption, error) {
+	reloader, err := servertls.NewReloader(tlsConfig, []string{"h2"}, logger, lc)
+	if err != nil {
+		return nil, err
+	}
+
+	return grpc.Creds(credentials.NewTLS(reloader.TLSConfig())), nil
+}
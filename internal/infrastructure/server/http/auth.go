@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
+	"github.com/umefy/godash/jsonkit"
+)
+
+// authenticate sets the auth principal of requests made with a verified client certificate or a known API key,
+// and their verified API key, see auth.PrincipalFromContext and auth.APIKeyFromContext. Requests with an unknown
+// API key are refused with 401 and the auth_1001 error.
+func authenticate(authConfig config.AuthConfig, principals map[string]string) func(next http.Handler) http.Handler {
+	apiKeys := auth.NewAPIKeys(authConfig.PrincipalsByKeyHash())
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if principal := auth.CertificatePrincipal(r.TLS, principals); principal != nil {
+				ctx = auth.WithPrincipal(ctx, principal)
+			}
+
+			ctx, err := apiKeys.Authenticate(ctx, r.Header.Get(authConfig.APIKeyHeader))
+			if err != nil {
+				statusCode, errMap := errutil.FormatError(err)
+				// nolint: errcheck
+				jsonkit.JSONResponse(w, statusCode, errMap)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
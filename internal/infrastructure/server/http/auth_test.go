@@ -0,0 +1,129 @@
+package http
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	"go.uber.org/fx/fxtest"
+)
+
+func keyHash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type AuthSuite struct {
+	suite.Suite
+	handler http.Handler
+	// principal and apiKey are the ones the last request reached the handler with
+	principal *auth.Principal
+	apiKey    string
+}
+
+func (s *AuthSuite) SetupTest() {
+	appConfig := config.NewAppConfig(config.AppConfig{
+		RateLimit: config.RateLimitConfig{
+			Enabled: true,
+			Store:   "memory",
+			Policies: []config.RateLimitPolicyConfig{
+				{Name: "client", Limit: 1, WindowInSeconds: 60, KeyBy: []string{"principal"}},
+			},
+		},
+		Auth: config.AuthConfig{
+			APIKeyHeader: "X-API-Key",
+			APIKeys: []config.APIKeyConfig{
+				{Principal: "billing", Sha256: keyHash("billing-key")},
+				{Principal: "reports", Sha256: keyHash("reports-key")},
+			},
+		},
+	})
+
+	limiter, err := ratelimit.NewLimiter(ratelimit.LimiterParams{
+		Config:    appConfig,
+		Logger:    loggerMocks.NewMockLogger(s.T()),
+		Lifecycle: fxtest.NewLifecycle(s.T()),
+	})
+	s.Require().NoError(err)
+
+	s.principal, s.apiKey = nil, ""
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.principal = auth.PrincipalFromContext(r.Context())
+		s.apiKey = auth.APIKeyFromContext(r.Context())
+	})
+	principals := map[string]string{"CN=billing-service,O=Acme": "billing-service"}
+	s.handler = authenticate(appConfig.GetAuthConfig(), principals)(rateLimit(limiter)(handler))
+}
+
+func (s *AuthSuite) serve(apiKey string, state *tls.ConnectionState) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.TLS = state
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func (s *AuthSuite) TestAPIKeysHaveTheirOwnCounters() {
+	s.Equal(http.StatusOK, s.serve("billing-key", nil).Code)
+	s.Equal(&auth.Principal{Name: "billing"}, s.principal)
+	s.Equal("billing-key", s.apiKey)
+	s.Equal(http.StatusTooManyRequests, s.serve("billing-key", nil).Code)
+
+	s.Equal(http.StatusOK, s.serve("reports-key", nil).Code)
+	s.Equal(&auth.Principal{Name: "reports"}, s.principal)
+	s.Equal(http.StatusTooManyRequests, s.serve("reports-key", nil).Code)
+
+	// requests without a key are counted by IP, apart from the keys sent from the same IP
+	s.Equal(http.StatusOK, s.serve("", nil).Code)
+	s.Nil(s.principal)
+	s.Empty(s.apiKey)
+	s.Equal(http.StatusTooManyRequests, s.serve("", nil).Code)
+}
+
+func (s *AuthSuite) TestUnknownAPIKey() {
+	for _, key := range []string{"rotated-1", "rotated-2"} {
+		rec := s.serve(key, nil)
+		s.Equal(http.StatusUnauthorized, rec.Code)
+
+		var body map[string]map[string]any
+		s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), &body))
+		s.Equal(auth.InvalidAPIKey.Code, body["error"]["code"])
+	}
+}
+
+func (s *AuthSuite) TestClientCertificate() {
+	state := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{
+		Subject: pkix.Name{CommonName: "billing-service", Organization: []string{"Acme"}},
+	}}}}
+
+	s.Equal(http.StatusOK, s.serve("", state).Code)
+	s.Equal(&auth.Principal{Name: "billing-service", Subject: "CN=billing-service,O=Acme"}, s.principal)
+
+	// the certificate principal is kept over the one of the API key
+	s.Equal(http.StatusTooManyRequests, s.serve("reports-key", state).Code)
+	s.Equal(http.StatusOK, s.serve("reports-key", nil).Code)
+
+	// certificates that were not verified are ignored
+	s.Equal(http.StatusOK, s.serve("", &tls.ConnectionState{}).Code)
+	s.Nil(s.principal)
+}
+
+func TestAuthSuite(t *testing.T) {
+	suite.Run(t, new(AuthSuite))
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"github.com/umefy/go-web-app-template/internal/delivery/graphql"
+	v2 "github.com/umefy/go-web-app-template/internal/delivery/restful/gateway/v2"
 	v1 "github.com/umefy/go-web-app-template/internal/delivery/restful/openapi/v1"
 	"go.uber.org/fx"
 )
@@ -9,5 +10,6 @@ import (
 var Module = fx.Module("httpServer",
 	fx.Provide(NewServer),
 	v1.Module,
+	v2.Module,
 	graphql.Module,
 )
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/umefy/go-web-app-template/internal/delivery/auth"
+	"github.com/umefy/go-web-app-template/internal/delivery/errutil"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	"github.com/umefy/godash/jsonkit"
+)
+
+// rateLimit refuses the requests over the rate limit policies with 429 and the rateLimit_1001 error.
+func rateLimit(limiter *ratelimit.Limiter) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := ratelimit.Request{
+				Route:  r.URL.Path,
+				APIKey: auth.APIKeyFromContext(r.Context()),
+				IP:     middleware.ExtractIP(r),
+			}
+			if principal := auth.PrincipalFromContext(r.Context()); principal != nil {
+				req.Principal = principal.Name
+			}
+
+			ctx, err := limiter.CheckHTTP(r.Context(), req, w.Header())
+			if err != nil {
+				statusCode, errMap := errutil.FormatError(err)
+				// nolint: errcheck
+				jsonkit.JSONResponse(w, statusCode, errMap)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
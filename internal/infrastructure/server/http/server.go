@@ -4,12 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/umefy/go-web-app-template/internal/core/config"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/ratelimit"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/server/servertls"
+	"github.com/umefy/go-web-app-template/pkg/health"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 )
@@ -23,37 +29,175 @@ type ServerParams struct {
 	TracerProvider trace.TracerProvider
 	GraphqlRouter  http.Handler `name:"graphqlRouter"`
 	ApiV1Router    http.Handler `name:"apiV1Router"`
+	ApiV2Router    http.Handler `name:"apiV2Router"`
+	RateLimiter    *ratelimit.Limiter
+	HealthRegistry *health.Registry
+	MeterProvider  metric.MeterProvider
+	MetricsHandler http.Handler `name:"metricsHandler"`
+	Lifecycle      fx.Lifecycle
 }
 
 func NewServer(params ServerParams) (*httpserver.Server, error) {
 
-	if !params.Config.GetHttpServerConfig().Enabled {
+	httpConfig := params.Config.GetHttpServerConfig()
+	if !httpConfig.Enabled {
 		return nil, nil
 	}
 
+	handler, err := newHttpHandler(params)
+	if err != nil {
+		return nil, err
+	}
+
 	sever := &http.Server{
-		Addr:    fmt.Sprintf("0.0.0.0:%d", params.Config.GetHttpServerConfig().Port),
-		Handler: newHttpHandler(params),
+		Addr:              fmt.Sprintf("0.0.0.0:%d", httpConfig.Port),
+		Handler:           handler,
+		Protocols:         newProtocols(httpConfig),
+		ReadHeaderTimeout: time.Duration(httpConfig.ReadHeaderTimeoutInSeconds) * time.Second,
+		ReadTimeout:       time.Duration(httpConfig.ReadTimeoutInSeconds) * time.Second,
+		WriteTimeout:      time.Duration(httpConfig.WriteTimeoutInSeconds) * time.Second,
+		IdleTimeout:       time.Duration(httpConfig.IdleTimeoutInSeconds) * time.Second,
+		MaxHeaderBytes:    httpConfig.MaxHeaderBytes,
+	}
+
+	var opts []httpserver.Option
+	if httpConfig.TLS.Enabled {
+		reloader, err := servertls.NewReloader(httpConfig.TLS, []string{"h2", "http/1.1"}, params.Logger, params.Lifecycle)
+		if err != nil {
+			return nil, err
+		}
+		sever.TLSConfig = reloader.TLSConfig()
+		opts = append(opts, httpserver.WithTLS())
+
+		if httpConfig.RedirectPort != 0 {
+			opts = append(opts, httpserver.WithRedirectServer(&http.Server{
+				Addr:              fmt.Sprintf("0.0.0.0:%d", httpConfig.RedirectPort),
+				Handler:           httpserver.RedirectToHTTPS(httpConfig.Port),
+				ReadHeaderTimeout: 10 * time.Second,
+			}))
+		}
 	}
 
 	s := httpserver.New(
 		sever,
 		params.Logger.GetLogger(),
+		opts...,
 	)
 
 	return s, nil
 }
 
-func newHttpHandler(params ServerParams) http.Handler {
-	r := router.NewRootRouter(params.Logger.GetLogger())
+// newProtocols adds HTTP/2 without TLS (h2c) when enabled, HTTP/2 over TLS is always on.
+func newProtocols(httpConfig config.HttpServerConfig) *http.Protocols {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(httpConfig.H2C)
+	return protocols
+}
+
+func newHttpHandler(params ServerParams) (http.Handler, error) {
+	httpConfig := params.Config.GetHttpServerConfig()
+	r := router.NewRootRouter(
+		params.Logger.GetLogger(),
+		newTracingMiddleware(httpConfig, params.TracerProvider),
+		time.Duration(httpConfig.RequestTimeoutInSeconds)*time.Second,
+		httpConfig.MaxBodyBytes,
+		httpConfig.TrustedProxyPrefixes(),
+	)
 
 	appConfig := params.Config.GetAppConfig()
-	r.Use(middleware.Cors(params.Config.GetHttpServerConfig().AllowedOrigins))
-	r.Use(middleware.HealthCheck(params.Config.GetHttpServerConfig().HealthCheckEndpoint, string(appConfig.Env), appConfig.Version, params.Logger.GetLogger()))
-	r.Use(middleware.OTelTracing(params.Config.GetHttpServerConfig().ServerName, params.TracerProvider))
+	if httpConfig.SecurityHeaders.Enabled {
+		r.Use(middleware.SecureHeaders(newSecureHeadersOptions(httpConfig.SecurityHeaders)))
+	}
+	r.Use(newCorsMiddleware(httpConfig))
+	r.Use(middleware.HealthProbes(middleware.HealthProbesOptions{
+		LiveEndpoint:   httpConfig.LiveEndpoint,
+		ReadyEndpoint:  httpConfig.ReadyEndpoint,
+		ReportEndpoint: httpConfig.HealthEndpoint,
+		Env:            string(appConfig.Env),
+		Version:        appConfig.Version,
+	}, params.HealthRegistry, params.Logger.GetLogger()))
+	metrics, err := middleware.Metrics(params.MeterProvider)
+	if err != nil {
+		return nil, err
+	}
+	r.Use(metrics)
+	r.Use(authenticate(params.Config.GetAuthConfig(), httpConfig.TLS.PrincipalsBySubject()))
+	if params.RateLimiter != nil {
+		r.Use(rateLimit(params.RateLimiter))
+	}
 
 	r.Mount(params.Config.GetHttpServerConfig().ProfilerEndpoint, router.ProfilerHandler)
+	if metricsConfig := params.Config.GetMetricsConfig(); metricsConfig.Enabled && metricsConfig.Port == 0 {
+		r.Handle(metricsConfig.Endpoint, params.MetricsHandler)
+	}
 	r.Mount("/api/v1", params.ApiV1Router)
+	if params.Config.GetHttpServerConfig().RestGateway {
+		r.Mount("/api/v2", params.ApiV2Router)
+	}
 	r.Mount("/graphql", params.GraphqlRouter)
-	return r
+	return r, nil
+}
+
+// newTracingMiddleware leaves the health probes out of the traces, they would outnumber the real requests.
+func newTracingMiddleware(httpConfig config.HttpServerConfig, tracerProvider trace.TracerProvider) func(next http.Handler) http.Handler {
+	probes := []string{httpConfig.LiveEndpoint, httpConfig.ReadyEndpoint, httpConfig.HealthEndpoint}
+	tracing := middleware.OTelTracing(httpConfig.ServerName, tracerProvider)
+
+	return func(next http.Handler) http.Handler {
+		traced := tracing(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(probes, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			traced.ServeHTTP(w, r)
+		})
+	}
+}
+
+func newCorsMiddleware(httpConfig config.HttpServerConfig) func(next http.Handler) http.Handler {
+	routes := make([]middleware.CorsRoute, 0, len(httpConfig.Cors.Routes))
+	for _, route := range httpConfig.Cors.Routes {
+		routes = append(routes, middleware.CorsRoute{
+			PathPrefix: route.PathPrefix,
+			Options:    newCorsOptions(httpConfig.CorsPolicy(route.PathPrefix)),
+		})
+	}
+
+	return middleware.Cors(newCorsOptions(httpConfig.CorsPolicy("/")), routes...)
+}
+
+func newCorsOptions(policy config.CorsPolicy) middleware.CorsOptions {
+	return middleware.CorsOptions{
+		AllowedOrigins:   policy.AllowedOrigins,
+		AllowedMethods:   policy.AllowedMethods,
+		AllowedHeaders:   policy.AllowedHeaders,
+		ExposedHeaders:   policy.ExposedHeaders,
+		AllowCredentials: policy.AllowCredentials,
+		MaxAge:           time.Duration(policy.MaxAgeInSeconds) * time.Second,
+	}
+}
+
+func newSecureHeadersOptions(securityConfig config.SecurityHeadersConfig) middleware.SecureHeadersOptions {
+	opts := middleware.SecureHeadersOptions{
+		HSTSMaxAge:            time.Duration(securityConfig.HSTSMaxAgeInSeconds) * time.Second,
+		HSTSIncludeSubdomains: securityConfig.HSTSIncludeSubdomains,
+		HSTSPreload:           securityConfig.HSTSPreload,
+		FrameOptions:          securityConfig.FrameOptions,
+		ReferrerPolicy:        securityConfig.ReferrerPolicy,
+		PermissionsPolicy:     securityConfig.PermissionsPolicy,
+		CSPReportOnly:         securityConfig.CSPReportOnly,
+	}
+
+	if len(securityConfig.ContentSecurityPolicy) > 0 {
+		policy := middleware.NewContentSecurityPolicy()
+		for directive, sources := range securityConfig.ContentSecurityPolicy {
+			policy.Directive(directive, sources...)
+		}
+		opts.ContentSecurityPolicy = policy.Nonce(securityConfig.CSPNonceDirectives...)
+	}
+
+	return opts
 }
@@ -0,0 +1,65 @@
+package servertls
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/server/tlsreload"
+	"go.uber.org/fx"
+)
+
+// strictCipherSuites are the TLS 1.2 suites with ECDHE key exchange and an AEAD cipher.
+var strictCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+}
+
+// NewReloader serves the certificate files of tlsConfig, reloading them as they change for as long as the app runs.
+// nextProtos are the ALPN protocols of the server, e.g. "h2".
+func NewReloader(tlsConfig config.TLSConfig, nextProtos []string, logger logger.Logger, lc fx.Lifecycle) (*tlsreload.Reloader, error) {
+	reloader, err := tlsreload.New(tlsreload.Config{
+		CertFile:     tlsConfig.CertFile,
+		KeyFile:      tlsConfig.KeyFile,
+		ClientCAFile: tlsConfig.ClientCAFile,
+		Interval:     time.Duration(tlsConfig.ReloadIntervalInSeconds) * time.Second,
+	}, baseConfig(tlsConfig, nextProtos), logger.GetLogger())
+	if err != nil {
+		return nil, err
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			reloader.Start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			reloader.Stop()
+			return nil
+		},
+	})
+
+	return reloader, nil
+}
+
+func baseConfig(tlsConfig config.TLSConfig, nextProtos []string) *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+	}
+
+	if tlsConfig.MinVersion == "1.3" {
+		base.MinVersion = tls.VersionTLS13
+	}
+	if tlsConfig.CipherPolicy == "strict" {
+		base.CipherSuites = strictCipherSuites
+	}
+
+	return base
+}
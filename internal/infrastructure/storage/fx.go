@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"github.com/umefy/go-web-app-template/internal/infrastructure/health"
+	"go.uber.org/fx"
+)
+
+var Module = fx.Module("storage",
+	fx.Provide(
+		NewStorage,
+		fx.Annotate(
+			NewHealthCheckers,
+			fx.ResultTags(health.FX_TAG_GROUP_HEALTH_CHECKERS),
+		),
+	),
+)
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/health"
+)
+
+// NewHealthCheckers watches the free space of the local driver, uploads and exports fail once it runs out.
+func NewHealthCheckers(config config.Config) []health.Checker {
+	storageConfig := config.GetStorageConfig()
+	minFreeDiskBytes := config.GetHealthConfig().MinFreeDiskBytes
+	if storageConfig.Driver != "local" || minFreeDiskBytes == 0 {
+		return nil
+	}
+
+	return []health.Checker{health.DiskSpace("storage_disk", storageConfig.LocalDir, minFreeDiskBytes)}
+}
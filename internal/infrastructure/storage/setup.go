@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/storage"
+)
+
+func NewStorage(config config.Config) (storage.Storage, error) {
+	storageConfig := config.GetStorageConfig()
+
+	switch strings.ToLower(storageConfig.Driver) {
+	case "local":
+		return storage.NewLocalStorage(storageConfig.LocalDir)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", storageConfig.Driver)
+	}
+}
@@ -2,46 +2,176 @@ package opentelemetry
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
 
 	"github.com/umefy/go-web-app-template/internal/core/config"
+	"github.com/umefy/go-web-app-template/pkg/tracing"
+	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
 	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/fx"
+	"google.golang.org/grpc/credentials"
 )
 
-func NewTracerProvider(ctx context.Context, config config.Config) (trace.TracerProvider, error) {
+// NewTracerProvider becomes the global provider and propagator, it is a no-op provider when tracing is
+// disabled. The spans still buffered are flushed when the application stops.
+func NewTracerProvider(ctx context.Context, config config.Config, lc fx.Lifecycle) (trace.TracerProvider, error) {
 	traceConfig := config.GetTracingConfig()
 	if !traceConfig.Enabled {
 		return noop.NewTracerProvider(), nil
 	}
 
-	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(traceConfig.JaegerEndpoint), otlptracehttp.WithInsecure())
+	exporter, err := newExporter(ctx, traceConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceNameKey.String(traceConfig.ServiceName),
-			semconv.ServiceVersionKey.String(traceConfig.ServiceVersion),
-		),
-	)
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(traceConfig.ServiceName),
+		semconv.ServiceVersionKey.String(traceConfig.ServiceVersion),
+	}
+	for _, pair := range traceConfig.ResourceAttributes {
+		key, value, _ := strings.Cut(pair, "=")
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(attrs...))
 	if err != nil {
 		return nil, err
 	}
 
-	traceProvider := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(newSampler(traceConfig.Sampler)),
 		sdktrace.WithResource(res),
-	)
+	}
+	// without exporter spans are only used to correlate logs and propagate the trace downstream
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	traceProvider := sdktrace.NewTracerProvider(options...)
 
 	otel.SetTracerProvider(traceProvider)
-	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetTextMapPropagator(newPropagator(traceConfig.Propagators))
+
+	lc.Append(fx.Hook{
+		OnStop: traceProvider.Shutdown,
+	})
 
 	return traceProvider, nil
 }
+
+// newExporter returns nil for the "none" exporter.
+func newExporter(ctx context.Context, traceConfig config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch traceConfig.Exporter {
+	case "none":
+		return nil, nil
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	}
+
+	tlsConfig, err := newTLSConfig(traceConfig.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	switch traceConfig.Exporter {
+	case "otlp_grpc":
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(traceConfig.Endpoint),
+			otlptracegrpc.WithHeaders(traceConfig.Headers),
+		}
+		if tlsConfig == nil {
+			options = append(options, otlptracegrpc.WithInsecure())
+		} else {
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+		return otlptracegrpc.New(ctx, options...)
+	case "otlp_http":
+		options := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(traceConfig.Endpoint),
+			otlptracehttp.WithHeaders(traceConfig.Headers),
+		}
+		if tlsConfig == nil {
+			options = append(options, otlptracehttp.WithInsecure())
+		} else {
+			options = append(options, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+		return otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", traceConfig.Exporter)
+	}
+}
+
+// newTLSConfig returns nil when the export is insecure.
+func newTLSConfig(tlsConfig config.TracingTLSConfig) (*tls.Config, error) {
+	if tlsConfig.Insecure {
+		return nil, nil
+	}
+
+	result := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if tlsConfig.CAFile != "" {
+		ca, err := os.ReadFile(tlsConfig.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read tracing CA file: %w", err)
+		}
+		result.RootCAs = x509.NewCertPool()
+		if !result.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in tracing CA file %s", tlsConfig.CAFile)
+		}
+	}
+
+	if tlsConfig.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsConfig.CertFile, tlsConfig.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load tracing client certificate: %w", err)
+		}
+		result.Certificates = []tls.Certificate{cert}
+	}
+
+	return result, nil
+}
+
+func newSampler(samplerConfig config.TracingSamplerConfig) sdktrace.Sampler {
+	switch samplerConfig.Type {
+	case "never":
+		return sdktrace.NeverSample()
+	case "ratio":
+		return sdktrace.TraceIDRatioBased(samplerConfig.Ratio)
+	case "parent_based":
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplerConfig.Ratio))
+	case "rate_limited":
+		return sdktrace.ParentBased(tracing.RateLimited(samplerConfig.TracesPerSecond))
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
+func newPropagator(names []string) propagation.TextMapPropagator {
+	propagators := make([]propagation.TextMapPropagator, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "tracecontext":
+			propagators = append(propagators, propagation.TraceContext{})
+		case "baggage":
+			propagators = append(propagators, propagation.Baggage{})
+		case "b3":
+			propagators = append(propagators, b3.New())
+		case "b3multi":
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	"github.com/umefy/go-web-app-template/internal/domain/audit/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+)
+
+type Service interface {
+	// Record stores an audit entry. The request id is taken from ctx when it is not set.
+	Record(ctx context.Context, auditLog *auditDomain.AuditLog) error
+	GetEntityAuditLogs(ctx context.Context, entityType string, entityID int) ([]*auditDomain.AuditLog, error)
+}
+
+type auditService struct {
+	logger    logger.Logger
+	auditRepo repo.Repository
+}
+
+var _ Service = (*auditService)(nil)
+
+func NewService(logger logger.Logger, auditRepo repo.Repository) *auditService {
+	return &auditService{
+		logger:    logger,
+		auditRepo: auditRepo,
+	}
+}
+
+// Record implements Service.
+func (s *auditService) Record(ctx context.Context, auditLog *auditDomain.AuditLog) error {
+	if auditLog.RequestID == "" {
+		auditLog.RequestID = middleware.GetReqID(ctx)
+	}
+
+	if _, err := s.auditRepo.CreateAuditLog(ctx, auditLog); err != nil {
+		s.logger.ErrorContext(ctx, "AuditService.Record",
+			slog.String("action", auditLog.Action),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	return nil
+}
+
+// GetEntityAuditLogs implements Service.
+func (s *auditService) GetEntityAuditLogs(ctx context.Context, entityType string, entityID int) ([]*auditDomain.AuditLog, error) {
+	auditLogs, err := s.auditRepo.FindAuditLogsByEntity(ctx, entityType, entityID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "AuditService.GetEntityAuditLogs", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return auditLogs, nil
+}
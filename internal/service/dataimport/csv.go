@@ -0,0 +1,80 @@
+package dataimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+// csvRow is a data row keyed by header column. line is the line of the file the row starts on,
+// counting the header as line 1.
+type csvRow struct {
+	line   int
+	values map[string]string
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+// newCSVReader reads the header of r and checks that it has every column in required.
+// Header columns are matched case-insensitively, unknown columns are ignored.
+func newCSVReader(r io.Reader, required []string) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, dataImportError.EmptyFile
+	}
+	if err != nil {
+		return nil, dataImportError.InvalidCSV
+	}
+
+	// spreadsheet applications like to prefix UTF-8 files with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	present := make(map[string]bool, len(header))
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+		present[header[i]] = true
+	}
+
+	errs := validation.Errors{}
+	for _, column := range required {
+		if !present[column] {
+			errs[column] = validation.NewError("validation_column_missing", "column is missing from the header")
+		}
+	}
+	if err := validation.NewValidateStructError(errs); err != nil {
+		return nil, err
+	}
+
+	return &csvReader{reader: reader, header: header}, nil
+}
+
+// next returns the next row, or io.EOF after the last one. A row that is not valid CSV is returned
+// together with its parse error, reading can go on after it.
+func (c *csvReader) next() (*csvRow, error) {
+	record, err := c.reader.Read()
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &csvRow{line: parseErr.StartLine}, parseErr.Err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	line, _ := c.reader.FieldPos(0)
+	values := make(map[string]string, len(c.header))
+	for i, column := range c.header {
+		values[column] = strings.TrimSpace(record[i])
+	}
+
+	return &csvRow{line: line, values: values}, nil
+}
@@ -0,0 +1,132 @@
+package dataimport
+
+import (
+	"encoding/csv"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type CSVSuite struct {
+	suite.Suite
+}
+
+func (s *CSVSuite) TestHeader() {
+	tests := []struct {
+		name           string
+		content        string
+		expected       []string
+		missingColumns []string
+		err            error
+	}{
+		{
+			name:     "columns are lowercased and trimmed",
+			content:  "Email , AGE\n",
+			expected: []string{"email", "age"},
+		},
+		{
+			name:     "byte order mark is stripped",
+			content:  "\ufeffEmail,Age\n",
+			expected: []string{"email", "age"},
+		},
+		{
+			name:     "unknown columns are kept",
+			content:  "nickname,email\n",
+			expected: []string{"nickname", "email"},
+		},
+		{
+			name:           "required column is missing",
+			content:        "\ufeffuser_id,note\n",
+			missingColumns: []string{"amount_cents"},
+		},
+		{
+			name:           "all required columns are missing",
+			content:        "note\n",
+			missingColumns: []string{"amount_cents", "user_id"},
+		},
+		{
+			name:    "empty file",
+			content: "",
+			err:     dataImportError.EmptyFile,
+		},
+		{
+			name:    "invalid header",
+			content: "\"email\n",
+			err:     dataImportError.InvalidCSV,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			required := []string{"email"}
+			if test.missingColumns != nil {
+				required = []string{"user_id", "amount_cents"}
+			}
+
+			reader, err := newCSVReader(strings.NewReader(test.content), required)
+
+			switch {
+			case test.err != nil:
+				s.ErrorIs(err, test.err)
+			case test.missingColumns != nil:
+				var validateErr *validation.ValidateStructError
+				s.Require().ErrorAs(err, &validateErr)
+				s.Equal(test.missingColumns, slices.Sorted(maps.Keys(validateErr.Errors)))
+			default:
+				s.Require().NoError(err)
+				s.Equal(test.expected, reader.header)
+			}
+		})
+	}
+}
+
+func (s *CSVSuite) TestRows() {
+	content := "email,age\n" +
+		"john@example.com, 30\n" +
+		"jane@example.com\n" +
+		"\"multi\nline@example.com\",40\n" +
+		"bob@example.com,50,extra\n" +
+		"\"broken,60\n"
+
+	reader, err := newCSVReader(strings.NewReader(content), []string{"email"})
+	s.Require().NoError(err)
+
+	type result struct {
+		line   int
+		values map[string]string
+		err    error
+	}
+	expected := []result{
+		{line: 2, values: map[string]string{"email": "john@example.com", "age": "30"}},
+		{line: 3, err: csv.ErrFieldCount},
+		{line: 4, values: map[string]string{"email": "multi\nline@example.com", "age": "40"}},
+		{line: 6, err: csv.ErrFieldCount},
+		{line: 7, err: csv.ErrQuote},
+	}
+
+	for _, want := range expected {
+		row, err := reader.next()
+		s.Require().NotNil(row, "line %d", want.line)
+		s.Equal(want.line, row.line)
+		if want.err != nil {
+			s.ErrorIs(err, want.err, "line %d", want.line)
+			continue
+		}
+		s.Require().NoError(err, "line %d", want.line)
+		s.Equal(want.values, row.values)
+	}
+
+	row, err := reader.next()
+	s.Nil(row)
+	s.ErrorIs(err, io.EOF)
+}
+
+func TestCSVSuite(t *testing.T) {
+	suite.Run(t, new(CSVSuite))
+}
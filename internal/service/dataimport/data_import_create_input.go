@@ -0,0 +1,21 @@
+package dataimport
+
+import (
+	"io"
+
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type DataImportCreateInput struct {
+	Entity dataImportDomain.Entity
+	// Source is the CSV file, it is read once and stored before the import starts.
+	Source io.Reader
+}
+
+func (d *DataImportCreateInput) Validate() error {
+	return validation.ValidateStruct(d,
+		validation.Field(&d.Entity, validation.Required, validation.In(dataImportDomain.EntityUsers, dataImportDomain.EntityOrders)),
+		validation.Field(&d.Source, validation.NotNil),
+	)
+}
@@ -0,0 +1,203 @@
+package dataimport
+
+import (
+	"context"
+	"strconv"
+
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	orderSvc "github.com/umefy/go-web-app-template/internal/service/order"
+	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
+)
+
+// importer ingests the rows of one entity. An importer is used for a single file, so it can remember
+// rows across batches.
+type importer interface {
+	// columns lists the header columns a file must have.
+	columns() []string
+	// importRows creates the valid rows in one transaction and reports the invalid ones.
+	importRows(ctx context.Context, rows []*csvRow) (int, []*RowError, error)
+}
+
+var userColumns = map[string]string{"Email": "email", "Age": "age"}
+
+type userImporter struct {
+	service *dataImportService
+	// seenEmails dedupes emails across the whole file, not only within a batch
+	seenEmails map[string]bool
+}
+
+func (i *userImporter) columns() []string {
+	return []string{"email"}
+}
+
+func (i *userImporter) importRows(ctx context.Context, rows []*csvRow) (int, []*RowError, error) {
+	var rowErrors []*RowError
+	lineByEmail := make(map[string]int, len(rows))
+	users := make([]*userDomain.User, 0, len(rows))
+
+	for _, row := range rows {
+		input := &userSvc.UserCreateInput{Email: row.values["email"]}
+		if age := row.values["age"]; age != "" {
+			n, err := strconv.Atoi(age)
+			if err != nil {
+				rowErrors = append(rowErrors, notIntegerRowError(row.line, "age"))
+				continue
+			}
+			input.Age = n
+		}
+
+		if err := input.Validate(); err != nil {
+			errs, err := validationRowErrors(row.line, err, userColumns)
+			if err != nil {
+				return 0, nil, err
+			}
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		if i.seenEmails[input.Email] {
+			rowErrors = append(rowErrors, domainRowError(row.line, "email", dataImportError.DuplicateEmail))
+			continue
+		}
+		i.seenEmails[input.Email] = true
+
+		lineByEmail[input.Email] = row.line
+		users = append(users, input.MapToDomainUser())
+	}
+
+	if len(users) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	emails := make([]string, 0, len(users))
+	for _, user := range users {
+		emails = append(emails, user.Email)
+	}
+	existingEmails, err := i.service.userRepository.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	exists := make(map[string]bool, len(existingEmails))
+	for _, email := range existingEmails {
+		exists[email] = true
+		rowErrors = append(rowErrors, domainRowError(lineByEmail[email], "email", userError.UserAlreadyExists))
+	}
+	newUsers := make([]*userDomain.User, 0, len(users))
+	for _, user := range users {
+		if !exists[user.Email] {
+			newUsers = append(newUsers, user)
+		}
+	}
+
+	if len(newUsers) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	_, err = withTx(ctx, i.service, func(ctx context.Context) ([]*userDomain.User, error) {
+		return i.service.userRepository.CreateUsers(ctx, newUsers)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(newUsers), rowErrors, nil
+}
+
+var orderColumns = map[string]string{"UserID": "user_id", "AmountCents": "amount_cents"}
+
+type orderImporter struct {
+	service *dataImportService
+}
+
+func (i *orderImporter) columns() []string {
+	return []string{"user_id", "amount_cents"}
+}
+
+func (i *orderImporter) importRows(ctx context.Context, rows []*csvRow) (int, []*RowError, error) {
+	var rowErrors []*RowError
+	lines := make([]int, 0, len(rows))
+	orders := make([]*orderDomain.Order, 0, len(rows))
+
+	for _, row := range rows {
+		input := &orderSvc.OrderCreateInput{}
+		if userID := row.values["user_id"]; userID != "" {
+			n, err := strconv.Atoi(userID)
+			if err != nil {
+				rowErrors = append(rowErrors, notIntegerRowError(row.line, "user_id"))
+				continue
+			}
+			input.UserID = n
+		}
+		if amountCents := row.values["amount_cents"]; amountCents != "" {
+			n, err := strconv.ParseInt(amountCents, 10, 64)
+			if err != nil {
+				rowErrors = append(rowErrors, notIntegerRowError(row.line, "amount_cents"))
+				continue
+			}
+			input.AmountCents = n
+		}
+
+		if err := input.Validate(); err != nil {
+			errs, err := validationRowErrors(row.line, err, orderColumns)
+			if err != nil {
+				return 0, nil, err
+			}
+			rowErrors = append(rowErrors, errs...)
+			continue
+		}
+
+		lines = append(lines, row.line)
+		orders = append(orders, input.MapToDomainOrder())
+	}
+
+	if len(orders) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	userIDs := make([]int, 0, len(orders))
+	for _, order := range orders {
+		userIDs = append(userIDs, order.UserID)
+	}
+	existingUserIDs, err := i.service.userRepository.FindExistingUserIDs(ctx, userIDs)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	exists := make(map[int]bool, len(existingUserIDs))
+	for _, id := range existingUserIDs {
+		exists[id] = true
+	}
+	newOrders := make([]*orderDomain.Order, 0, len(orders))
+	for j, order := range orders {
+		if !exists[order.UserID] {
+			rowErrors = append(rowErrors, domainRowError(lines[j], "user_id", userError.UserNotFound))
+			continue
+		}
+		newOrders = append(newOrders, order)
+	}
+
+	if len(newOrders) == 0 {
+		return 0, rowErrors, nil
+	}
+
+	_, err = withTx(ctx, i.service, func(ctx context.Context) ([]*orderDomain.Order, error) {
+		return i.service.orderRepo.CreateOrders(ctx, newOrders)
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return len(newOrders), rowErrors, nil
+}
+
+// withTx runs fn in a transaction of its own, the job context carries none.
+func withTx[T any](ctx context.Context, s *dataImportService, fn func(context.Context) (T, error)) (T, error) {
+	return database.WithTx(ctx, s.dbQuery, s.logger, func(ctx context.Context, tx *database.QueryTx) (T, error) {
+		return fn(context.WithValue(ctx, database.TransactionCtxKey, tx))
+	})
+}
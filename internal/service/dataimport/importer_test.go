@@ -0,0 +1,252 @@
+package dataimport
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	dataImportRepo "github.com/umefy/go-web-app-template/internal/domain/dataimport/repo"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	userRepo "github.com/umefy/go-web-app-template/internal/domain/user/repo"
+	"github.com/umefy/go-web-app-template/pkg/storage"
+)
+
+// existingEmailsRepository knows of existing users only, so that importRows never reaches the transaction.
+type existingEmailsRepository struct {
+	userRepo.Repository
+	existing map[string]bool
+}
+
+func (r *existingEmailsRepository) FindExistingEmails(_ context.Context, emails []string) ([]string, error) {
+	var result []string
+	for _, email := range emails {
+		if r.existing[email] {
+			result = append(result, email)
+		}
+	}
+	return result, nil
+}
+
+// recordingRepository keeps the updates of a data import.
+type recordingRepository struct {
+	dataImportRepo.Repository
+	updates []dataImportDomain.DataImport
+}
+
+func (r *recordingRepository) UpdateDataImport(_ context.Context, dataImport *dataImportDomain.DataImport) (*dataImportDomain.DataImport, error) {
+	r.updates = append(r.updates, *dataImport)
+	return dataImport, nil
+}
+
+// batchImporter imports every row but the ones on failingLines, and remembers the size of each batch.
+type batchImporter struct {
+	failingLines map[int]bool
+	batches      []int
+}
+
+func (i *batchImporter) columns() []string {
+	return []string{"email"}
+}
+
+func (i *batchImporter) importRows(_ context.Context, rows []*csvRow) (int, []*RowError, error) {
+	i.batches = append(i.batches, len(rows))
+
+	var rowErrors []*RowError
+	for _, row := range rows {
+		if i.failingLines[row.line] {
+			rowErrors = append(rowErrors,
+				&RowError{Line: row.line, Column: "email", Code: RowErrorCodeValidation, Message: "is invalid"},
+				&RowError{Line: row.line, Column: "age", Code: RowErrorCodeValidation, Message: "is invalid"},
+			)
+		}
+	}
+	return len(rows) - len(rowErrors)/2, rowErrors, nil
+}
+
+type ImporterSuite struct {
+	suite.Suite
+	storage    *storage.LocalStorage
+	repository *recordingRepository
+	service    *dataImportService
+}
+
+func (s *ImporterSuite) SetupTest() {
+	var err error
+	s.storage, err = storage.NewLocalStorage(s.T().TempDir())
+	s.Require().NoError(err)
+
+	s.repository = &recordingRepository{}
+	s.service = &dataImportService{dataImportRepo: s.repository, storage: s.storage}
+}
+
+// importSource stores lines as the source file, after an email header, and imports it.
+func (s *ImporterSuite) importSource(importer importer, lines []string) *dataImportDomain.DataImport {
+	content := "email\n" + strings.Join(lines, "\n") + "\n"
+	s.Require().NoError(s.storage.Put(s.T().Context(), "source.csv", strings.NewReader(content)))
+
+	dataImport := &dataImportDomain.DataImport{ID: 1, Entity: dataImportDomain.EntityUsers, SourceKey: "source.csv"}
+	s.Require().NoError(s.service.importSource(s.T().Context(), dataImport, importer))
+	return dataImport
+}
+
+func (s *ImporterSuite) TestUserImporter() {
+	tests := []struct {
+		name     string
+		batches  [][]string
+		existing []string
+		expected []string
+	}{
+		{
+			name:     "invalid values",
+			batches:  [][]string{{"john@example.com,thirty", "not an email,30", ",", "jane@example.com,200"}},
+			expected: []string{"2 age VALIDATION_ERROR", "3 email VALIDATION_ERROR", "4 email VALIDATION_ERROR", "5 age VALIDATION_ERROR"},
+		},
+		{
+			name:     "email already exists",
+			batches:  [][]string{{"john@example.com,30", "jane@example.com,40"}},
+			existing: []string{"john@example.com", "jane@example.com"},
+			expected: []string{"2 email " + userError.UserAlreadyExists.Code, "3 email " + userError.UserAlreadyExists.Code},
+		},
+		{
+			name:     "duplicate email in a batch",
+			batches:  [][]string{{"john@example.com,30", "john@example.com,40", "john@example.com,50"}},
+			existing: []string{"john@example.com"},
+			expected: []string{
+				"2 email " + userError.UserAlreadyExists.Code,
+				"3 email " + dataImportError.DuplicateEmail.Code,
+				"4 email " + dataImportError.DuplicateEmail.Code,
+			},
+		},
+		{
+			name:     "duplicate email across batches",
+			batches:  [][]string{{"john@example.com,30"}, {"jane@example.com,40", "john@example.com,50"}},
+			existing: []string{"john@example.com", "jane@example.com"},
+			expected: []string{
+				"2 email " + userError.UserAlreadyExists.Code,
+				"3 email " + userError.UserAlreadyExists.Code,
+				"4 email " + dataImportError.DuplicateEmail.Code,
+			},
+		},
+		{
+			name:     "invalid rows don't count as seen",
+			batches:  [][]string{{"john@example.com,thirty", "john@example.com,30"}},
+			existing: []string{"john@example.com"},
+			expected: []string{"2 age VALIDATION_ERROR", "3 email " + userError.UserAlreadyExists.Code},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			repository := &existingEmailsRepository{existing: map[string]bool{}}
+			for _, email := range test.existing {
+				repository.existing[email] = true
+			}
+			importer := (&dataImportService{userRepository: repository}).newImporter(dataImportDomain.EntityUsers)
+
+			var rowErrors []*RowError
+			line := 2
+			for _, batch := range test.batches {
+				rows := make([]*csvRow, len(batch))
+				for i, record := range batch {
+					email, age, _ := strings.Cut(record, ",")
+					rows[i] = &csvRow{line: line, values: map[string]string{"email": email, "age": age}}
+					line++
+				}
+
+				imported, errs, err := importer.importRows(s.T().Context(), rows)
+				s.Require().NoError(err)
+				s.Zero(imported)
+				rowErrors = append(rowErrors, errs...)
+			}
+
+			sortRowErrors(rowErrors)
+			result := make([]string, len(rowErrors))
+			for i, rowError := range rowErrors {
+				result[i] = fmt.Sprintf("%d %s %s", rowError.Line, rowError.Column, rowError.Code)
+			}
+			s.Equal(test.expected, result)
+		})
+	}
+}
+
+func (s *ImporterSuite) TestBatches() {
+	tests := []struct {
+		rows     int
+		expected []int
+	}{
+		{rows: 1, expected: []int{1}},
+		{rows: ImportBatchSize - 1, expected: []int{ImportBatchSize - 1}},
+		{rows: ImportBatchSize, expected: []int{ImportBatchSize}},
+		{rows: ImportBatchSize + 1, expected: []int{ImportBatchSize, 1}},
+		{rows: 2 * ImportBatchSize, expected: []int{ImportBatchSize, ImportBatchSize}},
+	}
+
+	for _, test := range tests {
+		s.Run(fmt.Sprintf("%d rows", test.rows), func() {
+			lines := make([]string, test.rows)
+			for i := range lines {
+				lines[i] = fmt.Sprintf("user%d@example.com", i)
+			}
+
+			importer := &batchImporter{}
+			dataImport := s.importSource(importer, lines)
+
+			s.Equal(test.expected, importer.batches)
+			s.Equal(test.rows, dataImport.TotalRows)
+			s.Equal(test.rows, dataImport.ProcessedRows)
+			s.Equal(test.rows, dataImport.ImportedRows)
+			s.Zero(dataImport.FailedRows)
+		})
+	}
+}
+
+func (s *ImporterSuite) TestReport() {
+	lines := make([]string, ImportBatchSize+10)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("user%d@example.com", i)
+	}
+	// rows that are not valid CSV count towards the batch they are in without reaching the importer
+	lines[3] = "user3@example.com,extra"
+	lines[ImportBatchSize+1] = "user,extra"
+	// the header is line 1, so line ImportBatchSize+2 is the first row of the second batch
+	importer := &batchImporter{failingLines: map[int]bool{2: true, ImportBatchSize + 2: true}}
+
+	dataImport := s.importSource(importer, lines)
+
+	s.Equal([]int{ImportBatchSize - 1, 9}, importer.batches)
+	s.Equal(len(lines), dataImport.TotalRows)
+	s.Equal(len(lines), dataImport.ProcessedRows)
+	s.Equal(len(lines)-4, dataImport.ImportedRows)
+	s.Equal(4, dataImport.FailedRows)
+	s.Equal("data-imports/1/report.csv", dataImport.ReportKey)
+
+	// progress is saved after the total is counted and after every batch
+	s.Len(s.repository.updates, 3)
+	s.Equal(ImportBatchSize, s.repository.updates[1].ProcessedRows)
+	s.Equal(2, s.repository.updates[1].FailedRows)
+
+	content, err := s.storage.Get(s.T().Context(), dataImport.ReportKey)
+	s.Require().NoError(err)
+	defer content.Close() //nolint:errcheck
+	records, err := csv.NewReader(content).ReadAll()
+	s.Require().NoError(err)
+
+	s.Equal([][]string{
+		reportHeader,
+		{"2", "email", RowErrorCodeValidation, "is invalid"},
+		{"2", "age", RowErrorCodeValidation, "is invalid"},
+		{"5", "", dataImportError.InvalidCSV.Code, "wrong number of fields"},
+		{"502", "email", RowErrorCodeValidation, "is invalid"},
+		{"502", "age", RowErrorCodeValidation, "is invalid"},
+		{"503", "", dataImportError.InvalidCSV.Code, "wrong number of fields"},
+	}, records)
+}
+
+func TestImporterSuite(t *testing.T) {
+	suite.Run(t, new(ImporterSuite))
+}
@@ -0,0 +1,130 @@
+package dataimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	appError "github.com/umefy/go-web-app-template/internal/domain/error"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+// RowErrorCodeValidation matches the code validation errors get in API responses.
+const RowErrorCodeValidation = "VALIDATION_ERROR"
+
+var reportHeader = []string{"line", "column", "code", "message"}
+
+// RowError is one line of the import report. A row gets one error per invalid column, Column is empty
+// for errors about the row as a whole.
+type RowError struct {
+	Line    int
+	Column  string
+	Code    string
+	Message string
+}
+
+func domainRowError(line int, column string, err *appError.Error) *RowError {
+	return &RowError{Line: line, Column: column, Code: err.Code, Message: err.Message}
+}
+
+func notIntegerRowError(line int, column string) *RowError {
+	return &RowError{Line: line, Column: column, Code: RowErrorCodeValidation, Message: "must be an integer"}
+}
+
+// validationRowErrors turns the error of an input's Validate into row errors, columns maps the
+// input's field names to header columns. Any other error is returned as is.
+func validationRowErrors(line int, err error, columns map[string]string) ([]*RowError, error) {
+	var validateErr *validation.ValidateStructError
+	if !errors.As(err, &validateErr) {
+		return nil, err
+	}
+
+	fields := slices.Sorted(maps.Keys(validateErr.Errors))
+	rowErrors := make([]*RowError, 0, len(fields))
+	for _, field := range fields {
+		column, ok := columns[field]
+		if !ok {
+			column = field
+		}
+		rowErrors = append(rowErrors, &RowError{
+			Line:    line,
+			Column:  column,
+			Code:    RowErrorCodeValidation,
+			Message: validateErr.Errors[field].Error(),
+		})
+	}
+
+	return rowErrors, nil
+}
+
+// failedRows counts the distinct rows among rowErrors, which must be sorted by line.
+func failedRows(rowErrors []*RowError) int {
+	count := 0
+	for i, rowError := range rowErrors {
+		if i == 0 || rowErrors[i-1].Line != rowError.Line {
+			count++
+		}
+	}
+	return count
+}
+
+func sortRowErrors(rowErrors []*RowError) {
+	slices.SortStableFunc(rowErrors, func(a, b *RowError) int {
+		return a.Line - b.Line
+	})
+}
+
+// report collects row errors in a temporary file, so that imports with many invalid rows don't have to
+// hold the report in memory until it is stored.
+type report struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newReport() (*report, error) {
+	file, err := os.CreateTemp("", "data-import-report-*.csv")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := csv.NewWriter(file)
+	if err := writer.Write(reportHeader); err != nil {
+		file.Close()           //nolint:errcheck
+		os.Remove(file.Name()) //nolint:errcheck
+		return nil, err
+	}
+
+	return &report{file: file, writer: writer}, nil
+}
+
+func (r *report) write(rowErrors []*RowError) error {
+	for _, rowError := range rowErrors {
+		record := []string{strconv.Itoa(rowError.Line), rowError.Column, rowError.Code, rowError.Message}
+		if err := r.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reader flushes the report and returns it from the start.
+func (r *report) reader() (io.Reader, error) {
+	r.writer.Flush()
+	if err := r.writer.Error(); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return r.file, nil
+}
+
+func (r *report) close() {
+	r.file.Close()           //nolint:errcheck
+	os.Remove(r.file.Name()) //nolint:errcheck
+}
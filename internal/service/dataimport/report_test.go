@@ -0,0 +1,131 @@
+package dataimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	orderSvc "github.com/umefy/go-web-app-template/internal/service/order"
+	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
+)
+
+type ReportSuite struct {
+	suite.Suite
+}
+
+func (s *ReportSuite) TestFailedRows() {
+	tests := []struct {
+		name     string
+		lines    []int
+		expected int
+	}{
+		{name: "no errors", lines: nil, expected: 0},
+		{name: "one error", lines: []int{2}, expected: 1},
+		{name: "errors of one row", lines: []int{2, 2, 2}, expected: 1},
+		{name: "errors of several rows", lines: []int{2, 2, 3, 5, 5, 9}, expected: 4},
+		{name: "row errors sorted from mixed lines", lines: []int{7, 3, 7, 3, 4}, expected: 3},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			rowErrors := make([]*RowError, len(test.lines))
+			for i, line := range test.lines {
+				rowErrors[i] = &RowError{Line: line}
+			}
+			sortRowErrors(rowErrors)
+
+			s.Equal(test.expected, failedRows(rowErrors))
+		})
+	}
+}
+
+func (s *ReportSuite) TestSortRowErrorsKeepsColumnOrder() {
+	rowErrors := []*RowError{
+		{Line: 4, Column: "email"},
+		{Line: 2, Column: "email"},
+		{Line: 4, Column: "age"},
+		{Line: 2, Column: "age"},
+	}
+	sortRowErrors(rowErrors)
+
+	s.Equal([]*RowError{
+		{Line: 2, Column: "email"},
+		{Line: 2, Column: "age"},
+		{Line: 4, Column: "email"},
+		{Line: 4, Column: "age"},
+	}, rowErrors)
+}
+
+func (s *ReportSuite) TestValidationRowErrors() {
+	tests := []struct {
+		name     string
+		err      error
+		columns  map[string]string
+		expected []string
+	}{
+		{
+			name:     "fields are mapped to columns",
+			err:      (&userSvc.UserCreateInput{Email: "not an email", Age: 200}).Validate(),
+			columns:  userColumns,
+			expected: []string{"age", "email"},
+		},
+		{
+			name:     "fields without a column keep their name",
+			err:      (&orderSvc.OrderCreateInput{}).Validate(),
+			columns:  map[string]string{},
+			expected: []string{"AmountCents", "UserID"},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Require().Error(test.err)
+
+			rowErrors, err := validationRowErrors(3, test.err, test.columns)
+			s.Require().NoError(err)
+
+			columns := make([]string, len(rowErrors))
+			for i, rowError := range rowErrors {
+				s.Equal(3, rowError.Line)
+				s.Equal(RowErrorCodeValidation, rowError.Code)
+				s.NotEmpty(rowError.Message)
+				columns[i] = rowError.Column
+			}
+			s.Equal(test.expected, columns)
+		})
+	}
+
+	other := errors.New("database is down")
+	rowErrors, err := validationRowErrors(3, other, userColumns)
+	s.Nil(rowErrors)
+	s.Equal(other, err)
+}
+
+func (s *ReportSuite) TestReport() {
+	report, err := newReport()
+	s.Require().NoError(err)
+	defer report.close()
+
+	s.Require().NoError(report.write([]*RowError{
+		{Line: 2, Column: "email", Code: RowErrorCodeValidation, Message: "must be a valid email address"},
+		notIntegerRowError(5, "age"),
+	}))
+	s.Require().NoError(report.write([]*RowError{{Line: 9, Code: "INVALID", Message: "bare \" in non-quoted field"}}))
+
+	content, err := report.reader()
+	s.Require().NoError(err)
+	records, err := csv.NewReader(content).ReadAll()
+	s.Require().NoError(err)
+
+	s.Equal([][]string{
+		reportHeader,
+		{"2", "email", RowErrorCodeValidation, "must be a valid email address"},
+		{"5", "age", RowErrorCodeValidation, "must be an integer"},
+		{"9", "", "INVALID", "bare \" in non-quoted field"},
+	}, records)
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportSuite))
+}
@@ -0,0 +1,354 @@
+package dataimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	dataImportDomain "github.com/umefy/go-web-app-template/internal/domain/dataimport"
+	dataImportError "github.com/umefy/go-web-app-template/internal/domain/dataimport/error"
+	dataImportRepo "github.com/umefy/go-web-app-template/internal/domain/dataimport/repo"
+	orderRepo "github.com/umefy/go-web-app-template/internal/domain/order/repo"
+	userRepo "github.com/umefy/go-web-app-template/internal/domain/user/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/job"
+	"github.com/umefy/go-web-app-template/pkg/storage"
+)
+
+// ImportBatchSize is the number of rows created per transaction. Progress is saved after every batch.
+const ImportBatchSize = 500
+
+type Service interface {
+	// StartImport stores the CSV file and imports it in the background, poll GetImport for progress.
+	// The header is checked before returning, so a file of the wrong entity fails right away.
+	StartImport(ctx context.Context, input *DataImportCreateInput) (*dataImportDomain.DataImport, error)
+	// RunImport stores the CSV file and imports it before returning.
+	RunImport(ctx context.Context, input *DataImportCreateInput) (*dataImportDomain.DataImport, error)
+	GetImport(ctx context.Context, importID string) (*dataImportDomain.DataImport, error)
+	// OpenImportReport returns the row-level error report of a completed import, the caller must close it.
+	OpenImportReport(ctx context.Context, importID string) (*dataImportDomain.DataImport, io.ReadCloser, error)
+}
+
+type dataImportService struct {
+	logger         logger.Logger
+	dbQuery        *database.Query
+	userRepository userRepo.Repository
+	orderRepo      orderRepo.Repository
+	dataImportRepo dataImportRepo.Repository
+	storage        storage.Storage
+	jobRunner      *job.Runner
+}
+
+var _ Service = (*dataImportService)(nil)
+
+func NewService(
+	logger logger.Logger,
+	dbQuery *database.Query,
+	userRepository userRepo.Repository,
+	orderRepo orderRepo.Repository,
+	dataImportRepo dataImportRepo.Repository,
+	storage storage.Storage,
+	jobRunner *job.Runner,
+) *dataImportService {
+	return &dataImportService{
+		logger:         logger,
+		dbQuery:        dbQuery,
+		userRepository: userRepository,
+		orderRepo:      orderRepo,
+		dataImportRepo: dataImportRepo,
+		storage:        storage,
+		jobRunner:      jobRunner,
+	}
+}
+
+// StartImport implements Service.
+func (s *dataImportService) StartImport(ctx context.Context, input *DataImportCreateInput) (*dataImportDomain.DataImport, error) {
+	dataImport, err := s.createDataImport(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request, so it must not use the request transaction.
+	importID := dataImport.ID
+	err = s.jobRunner.Submit(database.WithoutTx(ctx), "data_import", func(ctx context.Context) error {
+		return s.processDataImport(ctx, importID)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "DataImportService.StartImport", slog.String("error", err.Error()))
+		return s.failDataImport(ctx, dataImport, err)
+	}
+
+	return dataImport, nil
+}
+
+// RunImport implements Service.
+func (s *dataImportService) RunImport(ctx context.Context, input *DataImportCreateInput) (*dataImportDomain.DataImport, error) {
+	dataImport, err := s.createDataImport(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.processDataImport(ctx, dataImport.ID); err != nil {
+		return nil, err
+	}
+
+	return s.dataImportRepo.FindDataImport(ctx, dataImport.ID)
+}
+
+// GetImport implements Service.
+func (s *dataImportService) GetImport(ctx context.Context, importID string) (*dataImportDomain.DataImport, error) {
+	id, err := strconv.Atoi(importID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "DataImportService.GetImport", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("invalid data import id")
+	}
+
+	return s.dataImportRepo.FindDataImport(ctx, id)
+}
+
+// OpenImportReport implements Service.
+func (s *dataImportService) OpenImportReport(ctx context.Context, importID string) (*dataImportDomain.DataImport, io.ReadCloser, error) {
+	dataImport, err := s.GetImport(ctx, importID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dataImport.Status != dataImportDomain.StatusCompleted {
+		return nil, nil, dataImportError.DataImportNotReady
+	}
+
+	r, err := s.storage.Get(ctx, dataImport.ReportKey)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "DataImportService.OpenImportReport", slog.String("error", err.Error()))
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, dataImportError.DataImportNotFound
+		}
+		return nil, nil, err
+	}
+
+	return dataImport, r, nil
+}
+
+// createDataImport stores the source file of a new import and checks its header.
+func (s *dataImportService) createDataImport(ctx context.Context, input *DataImportCreateInput) (*dataImportDomain.DataImport, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	dataImport, err := s.dataImportRepo.CreateDataImport(ctx, &dataImportDomain.DataImport{
+		Entity: input.Entity,
+		Status: dataImportDomain.StatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("data-imports/%d/source.csv", dataImport.ID)
+	if err := s.storage.Put(ctx, key, input.Source); err != nil {
+		s.logger.ErrorContext(ctx, "DataImportService.createDataImport", slog.String("error", err.Error()))
+		return s.failDataImport(ctx, dataImport, err)
+	}
+
+	dataImport.SourceKey = key
+	if dataImport, err = s.dataImportRepo.UpdateDataImport(ctx, dataImport); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkSource(ctx, dataImport); err != nil {
+		return s.failDataImport(ctx, dataImport, err)
+	}
+
+	return dataImport, nil
+}
+
+// checkSource reads the header and the first row of the source file.
+func (s *dataImportService) checkSource(ctx context.Context, dataImport *dataImportDomain.DataImport) error {
+	source, err := s.storage.Get(ctx, dataImport.SourceKey)
+	if err != nil {
+		return err
+	}
+	defer source.Close() //nolint:errcheck
+
+	reader, err := newCSVReader(source, s.newImporter(dataImport.Entity).columns())
+	if err != nil {
+		return err
+	}
+
+	if _, err := reader.next(); errors.Is(err, io.EOF) {
+		return dataImportError.EmptyFile
+	}
+	return nil
+}
+
+func (s *dataImportService) processDataImport(ctx context.Context, importID int) error {
+	dataImport, err := s.dataImportRepo.FindDataImport(ctx, importID)
+	if err != nil {
+		return err
+	}
+
+	dataImport.Status = dataImportDomain.StatusProcessing
+	if dataImport, err = s.dataImportRepo.UpdateDataImport(ctx, dataImport); err != nil {
+		return err
+	}
+
+	if err := s.importSource(ctx, dataImport, s.newImporter(dataImport.Entity)); err != nil {
+		_, failErr := s.failDataImport(ctx, dataImport, err)
+		return failErr
+	}
+
+	completedAt := time.Now()
+	dataImport.Status = dataImportDomain.StatusCompleted
+	dataImport.CompletedAt = &completedAt
+	_, err = s.dataImportRepo.UpdateDataImport(ctx, dataImport)
+	return err
+}
+
+// importSource imports the source file in batches of ImportBatchSize rows and stores the report.
+// Batches that were created stay created if a later batch fails.
+func (s *dataImportService) importSource(ctx context.Context, dataImport *dataImportDomain.DataImport, importer importer) error {
+	totalRows, err := s.countRows(ctx, dataImport, importer)
+	if err != nil {
+		return err
+	}
+	dataImport.TotalRows = totalRows
+	if _, err := s.dataImportRepo.UpdateDataImport(ctx, dataImport); err != nil {
+		return err
+	}
+
+	source, err := s.storage.Get(ctx, dataImport.SourceKey)
+	if err != nil {
+		return err
+	}
+	defer source.Close() //nolint:errcheck
+
+	reader, err := newCSVReader(source, importer.columns())
+	if err != nil {
+		return err
+	}
+
+	report, err := newReport()
+	if err != nil {
+		return err
+	}
+	defer report.close()
+
+	rows := make([]*csvRow, 0, ImportBatchSize)
+	var parseErrors []*RowError
+	importBatch := func() error {
+		imported, rowErrors, err := importer.importRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+
+		rowErrors = append(rowErrors, parseErrors...)
+		sortRowErrors(rowErrors)
+		if err := report.write(rowErrors); err != nil {
+			return err
+		}
+
+		dataImport.ProcessedRows += len(rows) + len(parseErrors)
+		dataImport.ImportedRows += imported
+		dataImport.FailedRows += failedRows(rowErrors)
+		if _, err := s.dataImportRepo.UpdateDataImport(ctx, dataImport); err != nil {
+			return err
+		}
+
+		rows = rows[:0]
+		parseErrors = parseErrors[:0]
+		return nil
+	}
+
+	for {
+		row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if row == nil {
+			return err
+		}
+
+		if err != nil {
+			parseErrors = append(parseErrors, &RowError{
+				Line:    row.line,
+				Code:    dataImportError.InvalidCSV.Code,
+				Message: err.Error(),
+			})
+		} else {
+			rows = append(rows, row)
+		}
+
+		if len(rows)+len(parseErrors) == ImportBatchSize {
+			if err := importBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(rows)+len(parseErrors) > 0 {
+		if err := importBatch(); err != nil {
+			return err
+		}
+	}
+
+	content, err := report.reader()
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("data-imports/%d/report.csv", dataImport.ID)
+	if err := s.storage.Put(ctx, key, content); err != nil {
+		return err
+	}
+	dataImport.ReportKey = key
+
+	return nil
+}
+
+// countRows reads the source file once up front, so that progress can be reported against a total.
+func (s *dataImportService) countRows(ctx context.Context, dataImport *dataImportDomain.DataImport, importer importer) (int, error) {
+	source, err := s.storage.Get(ctx, dataImport.SourceKey)
+	if err != nil {
+		return 0, err
+	}
+	defer source.Close() //nolint:errcheck
+
+	reader, err := newCSVReader(source, importer.columns())
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for {
+		row, err := reader.next()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if row == nil {
+			return 0, err
+		}
+		count++
+	}
+}
+
+func (s *dataImportService) newImporter(entity dataImportDomain.Entity) importer {
+	if entity == dataImportDomain.EntityOrders {
+		return &orderImporter{service: s}
+	}
+	return &userImporter{service: s, seenEmails: map[string]bool{}}
+}
+
+// failDataImport marks the import as failed and returns cause. The record is updated even if ctx was
+// cancelled, e.g. by a shutdown, so that the import doesn't look stuck.
+func (s *dataImportService) failDataImport(ctx context.Context, dataImport *dataImportDomain.DataImport, cause error) (*dataImportDomain.DataImport, error) {
+	dataImport.Status = dataImportDomain.StatusFailed
+	dataImport.Error = cause.Error()
+	if _, err := s.dataImportRepo.UpdateDataImport(context.WithoutCancel(ctx), dataImport); err != nil {
+		s.logger.ErrorContext(ctx, "DataImportService.failDataImport", slog.String("error", err.Error()))
+	}
+
+	return nil, cause
+}
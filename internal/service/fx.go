@@ -1,8 +1,12 @@
 package service
 
 import (
+	auditSvc "github.com/umefy/go-web-app-template/internal/service/audit"
+	dataImportSvc "github.com/umefy/go-web-app-template/internal/service/dataimport"
 	greeterSvc "github.com/umefy/go-web-app-template/internal/service/greeter"
 	orderSvc "github.com/umefy/go-web-app-template/internal/service/order"
+	privacySvc "github.com/umefy/go-web-app-template/internal/service/privacy"
+	searchSvc "github.com/umefy/go-web-app-template/internal/service/search"
 	userSvc "github.com/umefy/go-web-app-template/internal/service/user"
 	"go.uber.org/fx"
 )
@@ -21,5 +25,21 @@ var Module = fx.Module("service",
 			greeterSvc.NewService,
 			fx.As(new(greeterSvc.Service)),
 		),
+		fx.Annotate(
+			auditSvc.NewService,
+			fx.As(new(auditSvc.Service)),
+		),
+		fx.Annotate(
+			privacySvc.NewService,
+			fx.As(new(privacySvc.Service)),
+		),
+		fx.Annotate(
+			searchSvc.NewService,
+			fx.As(new(searchSvc.Service)),
+		),
+		fx.Annotate(
+			dataImportSvc.NewService,
+			fx.As(new(dataImportSvc.Service)),
+		),
 	),
 )
@@ -0,0 +1,25 @@
+package order
+
+import (
+	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type OrderCreateInput struct {
+	UserID      int
+	AmountCents int64
+}
+
+func (o *OrderCreateInput) Validate() error {
+	return validation.ValidateStruct(o,
+		validation.Field(&o.UserID, validation.Required, validation.Min(1)),
+		validation.Field(&o.AmountCents, validation.Required, validation.Min(int64(1))),
+	)
+}
+
+func (o *OrderCreateInput) MapToDomainOrder() *orderDomain.Order {
+	return &orderDomain.Order{
+		UserID:      o.UserID,
+		AmountCents: o.AmountCents,
+	}
+}
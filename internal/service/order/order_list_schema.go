@@ -0,0 +1,37 @@
+package order
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/listing"
+)
+
+// OrderListSchema whitelists the order fields list endpoints can filter and sort on.
+var OrderListSchema = listing.Schema{
+	Fields: map[string]listing.Field{
+		"id": {
+			Type:      listing.FieldTypeInt,
+			Operators: []listing.Operator{listing.OperatorEq, listing.OperatorIn},
+			Sortable:  true,
+		},
+		"userId": {
+			Type:      listing.FieldTypeInt,
+			Operators: []listing.Operator{listing.OperatorEq, listing.OperatorIn},
+			Sortable:  true,
+		},
+		"amountCents": {
+			Type:      listing.FieldTypeInt,
+			Operators: listing.ComparableOperators,
+			Sortable:  true,
+		},
+		"createdAt": {
+			Type:      listing.FieldTypeTime,
+			Operators: listing.RangeOperators,
+			Sortable:  true,
+		},
+		"updatedAt": {
+			Type:      listing.FieldTypeTime,
+			Operators: listing.RangeOperators,
+			Sortable:  true,
+		},
+	},
+	DefaultSort: []listing.SortKey{{Field: "id"}},
+}
@@ -7,11 +7,14 @@ import (
 	domainOrder "github.com/umefy/go-web-app-template/internal/domain/order"
 	"github.com/umefy/go-web-app-template/internal/domain/order/repo"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 )
 
 type Service interface {
 	GetOrdersByUserID(ctx context.Context, userID int) ([]*domainOrder.Order, error)
 	GetOrdersByUserIDs(ctx context.Context, userIDs []int) ([]*domainOrder.Order, error)
+	// ExportOrders streams every order matching q to fn in batches, for exports too large to hold in memory.
+	ExportOrders(ctx context.Context, q listing.RawQuery, fn func([]*domainOrder.Order) error) error
 }
 
 type orderService struct {
@@ -48,3 +51,17 @@ func (s *orderService) GetOrdersByUserIDs(ctx context.Context, userIDs []int) ([
 	}
 	return orders, nil
 }
+
+// ExportOrders implements Service.
+func (s *orderService) ExportOrders(ctx context.Context, q listing.RawQuery, fn func([]*domainOrder.Order) error) error {
+	listingQuery, err := OrderListSchema.Parse(q)
+	if err != nil {
+		return err
+	}
+
+	if err := s.orderRepo.StreamOrders(ctx, listingQuery, fn); err != nil {
+		s.logger.ErrorContext(ctx, "failed to export orders", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package privacy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	orderDomain "github.com/umefy/go-web-app-template/internal/domain/order"
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	"github.com/umefy/godash/sliceskit"
+)
+
+// archive is the document handed to the user. It is decoupled from the domain models so that
+// the export format stays stable when the models change.
+type archive struct {
+	Profile    archiveProfile    `json:"profile"`
+	Orders     []archiveOrder    `json:"orders"`
+	AuditLogs  []archiveAuditLog `json:"audit_logs"`
+	ExportedAt time.Time         `json:"exported_at"`
+}
+
+type archiveProfile struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Age       int       `json:"age"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type archiveOrder struct {
+	ID          int       `json:"id"`
+	AmountCents int64     `json:"amount_cents"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type archiveAuditLog struct {
+	Action    string         `json:"action"`
+	Metadata  map[string]any `json:"metadata,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func newArchive(user *userDomain.User, orders []*orderDomain.Order, auditLogs []*auditDomain.AuditLog) *archive {
+	return &archive{
+		Profile: archiveProfile{
+			ID:        user.ID,
+			Email:     user.Email,
+			Age:       user.Age,
+			CreatedAt: user.CreatedAt,
+			UpdatedAt: user.UpdatedAt,
+		},
+		Orders: sliceskit.Map(orders, func(order *orderDomain.Order) archiveOrder {
+			return archiveOrder{
+				ID:          order.ID,
+				AmountCents: order.AmountCents,
+				CreatedAt:   order.CreatedAt,
+				UpdatedAt:   order.UpdatedAt,
+			}
+		}),
+		AuditLogs: sliceskit.Map(auditLogs, func(auditLog *auditDomain.AuditLog) archiveAuditLog {
+			return archiveAuditLog{
+				Action:    auditLog.Action,
+				Metadata:  auditLog.Metadata,
+				CreatedAt: auditLog.CreatedAt,
+			}
+		}),
+		ExportedAt: time.Now(),
+	}
+}
+
+// encode renders the archive as a single JSON document, or as a ZIP with one JSON file per section.
+func (a *archive) encode(format privacyDomain.DataExportFormat) ([]byte, error) {
+	if format == privacyDomain.DataExportFormatJSON {
+		return json.MarshalIndent(a, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name    string
+		content any
+	}{
+		{"profile.json", a.Profile},
+		{"orders.json", a.Orders},
+		{"audit_logs.json", a.AuditLogs},
+	}
+
+	for _, file := range files {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: a.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func fileExtension(format privacyDomain.DataExportFormat) string {
+	if format == privacyDomain.DataExportFormatJSON {
+		return "json"
+	}
+	return "zip"
+}
@@ -0,0 +1,16 @@
+package privacy
+
+import (
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type DataExportCreateInput struct {
+	Format privacyDomain.DataExportFormat
+}
+
+func (d *DataExportCreateInput) Validate() error {
+	return validation.ValidateStruct(d,
+		validation.Field(&d.Format, validation.Required, validation.In(privacyDomain.DataExportFormatJSON, privacyDomain.DataExportFormatZIP)),
+	)
+}
@@ -0,0 +1,345 @@
+package privacy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	auditRepo "github.com/umefy/go-web-app-template/internal/domain/audit/repo"
+	orderRepo "github.com/umefy/go-web-app-template/internal/domain/order/repo"
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	privacyError "github.com/umefy/go-web-app-template/internal/domain/privacy/error"
+	privacyRepo "github.com/umefy/go-web-app-template/internal/domain/privacy/repo"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userRepo "github.com/umefy/go-web-app-template/internal/domain/user/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/database"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	auditSvc "github.com/umefy/go-web-app-template/internal/service/audit"
+	"github.com/umefy/go-web-app-template/pkg/job"
+	"github.com/umefy/go-web-app-template/pkg/storage"
+)
+
+const (
+	AuditActionDataExportRequested  = "user.data_export.requested"
+	AuditActionDataExportCompleted  = "user.data_export.completed"
+	AuditActionDataExportFailed     = "user.data_export.failed"
+	AuditActionDataExportDownloaded = "user.data_export.downloaded"
+	AuditActionUserErased           = "user.erased"
+)
+
+type Service interface {
+	// RequestDataExport creates a data export and builds its archive in the background.
+	RequestDataExport(ctx context.Context, userID string, input *DataExportCreateInput) (*privacyDomain.DataExport, error)
+	GetDataExport(ctx context.Context, userID string, exportID string) (*privacyDomain.DataExport, error)
+	// OpenDataExport returns the archive of a completed export, the caller must close it.
+	OpenDataExport(ctx context.Context, userID string, exportID string) (*privacyDomain.DataExport, io.ReadCloser, error)
+	// EraseUser anonymizes the user and purges their export archives, which are deleted once the transaction
+	// is committed. Orders are kept for accounting, they only reference the user by id. It must run in a
+	// transaction.
+	EraseUser(ctx context.Context, userID string) (*userDomain.User, error)
+}
+
+type privacyService struct {
+	logger         logger.Logger
+	userRepository userRepo.Repository
+	orderRepo      orderRepo.Repository
+	auditRepo      auditRepo.Repository
+	privacyRepo    privacyRepo.Repository
+	auditService   auditSvc.Service
+	storage        storage.Storage
+	jobRunner      *job.Runner
+}
+
+var _ Service = (*privacyService)(nil)
+
+func NewService(
+	logger logger.Logger,
+	userRepository userRepo.Repository,
+	orderRepo orderRepo.Repository,
+	auditRepo auditRepo.Repository,
+	privacyRepo privacyRepo.Repository,
+	auditService auditSvc.Service,
+	storage storage.Storage,
+	jobRunner *job.Runner,
+) *privacyService {
+	return &privacyService{
+		logger:         logger,
+		userRepository: userRepository,
+		orderRepo:      orderRepo,
+		auditRepo:      auditRepo,
+		privacyRepo:    privacyRepo,
+		auditService:   auditService,
+		storage:        storage,
+		jobRunner:      jobRunner,
+	}
+}
+
+// RequestDataExport implements Service.
+func (s *privacyService) RequestDataExport(ctx context.Context, userID string, input *DataExportCreateInput) (*privacyDomain.DataExport, error) {
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.RequestDataExport", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.FindUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.ErasedAt != nil {
+		return nil, privacyError.UserAlreadyErased
+	}
+
+	dataExport, err := s.privacyRepo.CreateDataExport(ctx, &privacyDomain.DataExport{
+		UserID: id,
+		Format: input.Format,
+		Status: privacyDomain.DataExportStatusPending,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auditService.Record(ctx, &auditDomain.AuditLog{
+		Action:     AuditActionDataExportRequested,
+		EntityType: auditDomain.EntityTypeUser,
+		EntityID:   id,
+		Metadata:   map[string]any{"data_export_id": dataExport.ID, "format": string(dataExport.Format)},
+	}); err != nil {
+		return nil, err
+	}
+
+	// The job outlives the request, so it must not use the request transaction.
+	exportID := dataExport.ID
+	err = s.jobRunner.Submit(database.WithoutTx(ctx), "data_export", func(ctx context.Context) error {
+		return s.processDataExport(ctx, exportID)
+	})
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.RequestDataExport", slog.String("error", err.Error()))
+		return s.failDataExport(ctx, dataExport, err)
+	}
+
+	return dataExport, nil
+}
+
+// GetDataExport implements Service.
+func (s *privacyService) GetDataExport(ctx context.Context, userID string, exportID string) (*privacyDomain.DataExport, error) {
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.GetDataExport", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	dataExportID, err := strconv.Atoi(exportID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.GetDataExport", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("invalid data export id")
+	}
+
+	dataExport, err := s.privacyRepo.FindDataExport(ctx, dataExportID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't leak the existence of exports that belong to someone else.
+	if dataExport.UserID != id {
+		return nil, privacyError.DataExportNotFound
+	}
+
+	return dataExport, nil
+}
+
+// OpenDataExport implements Service.
+func (s *privacyService) OpenDataExport(ctx context.Context, userID string, exportID string) (*privacyDomain.DataExport, io.ReadCloser, error) {
+	dataExport, err := s.GetDataExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dataExport.Status != privacyDomain.DataExportStatusCompleted {
+		return nil, nil, privacyError.DataExportNotReady
+	}
+
+	r, err := s.storage.Get(ctx, dataExport.StorageKey)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.OpenDataExport", slog.String("error", err.Error()))
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, privacyError.DataExportNotFound
+		}
+		return nil, nil, err
+	}
+
+	if err := s.auditService.Record(ctx, &auditDomain.AuditLog{
+		Action:     AuditActionDataExportDownloaded,
+		EntityType: auditDomain.EntityTypeUser,
+		EntityID:   dataExport.UserID,
+		Metadata:   map[string]any{"data_export_id": dataExport.ID},
+	}); err != nil {
+		r.Close()
+		return nil, nil, err
+	}
+
+	return dataExport, r, nil
+}
+
+// EraseUser implements Service.
+func (s *privacyService) EraseUser(ctx context.Context, userID string) (*userDomain.User, error) {
+	id, err := strconv.Atoi(userID)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.EraseUser", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("invalid user id")
+	}
+
+	user, err := s.userRepository.FindUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.ErasedAt != nil {
+		return nil, privacyError.UserAlreadyErased
+	}
+
+	erasedAt := time.Now()
+	user.Email = fmt.Sprintf("erased-%d@erased.invalid", user.ID)
+	user.Age = 0
+	user.ErasedAt = &erasedAt
+
+	erasedUser, err := s.userRepository.UpdateUser(ctx, id, user)
+	if err != nil {
+		return nil, err
+	}
+
+	dataExports, err := s.privacyRepo.FindDataExportsByUserID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var purgedKeys []string
+	for _, dataExport := range dataExports {
+		if dataExport.Status == privacyDomain.DataExportStatusPurged {
+			continue
+		}
+
+		dataExport.Status = privacyDomain.DataExportStatusPurged
+		if _, err := s.privacyRepo.UpdateDataExport(ctx, dataExport); err != nil {
+			return nil, err
+		}
+		if dataExport.StorageKey != "" {
+			purgedKeys = append(purgedKeys, dataExport.StorageKey)
+		}
+	}
+
+	if err := s.auditService.Record(ctx, &auditDomain.AuditLog{
+		Action:     AuditActionUserErased,
+		EntityType: auditDomain.EntityTypeUser,
+		EntityID:   id,
+		Metadata:   map[string]any{"purged_data_exports": len(purgedKeys)},
+	}); err != nil {
+		return nil, err
+	}
+
+	// A deleted archive can't be rolled back, so they go once the erasure is committed. A failed delete leaves
+	// the object behind for cleanup rather than undoing the erasure.
+	database.AfterCommit(ctx, func(ctx context.Context) {
+		for _, key := range purgedKeys {
+			if err := s.storage.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+				s.logger.ErrorContext(ctx, "PrivacyService.EraseUser", slog.String("storage_key", key), slog.String("error", err.Error()))
+			}
+		}
+	})
+
+	return erasedUser, nil
+}
+
+func (s *privacyService) processDataExport(ctx context.Context, exportID int) error {
+	dataExport, err := s.privacyRepo.FindDataExport(ctx, exportID)
+	if err != nil {
+		return err
+	}
+
+	dataExport.Status = privacyDomain.DataExportStatusProcessing
+	if dataExport, err = s.privacyRepo.UpdateDataExport(ctx, dataExport); err != nil {
+		return err
+	}
+
+	key, err := s.buildArchive(ctx, dataExport)
+	if err != nil {
+		_, failErr := s.failDataExport(ctx, dataExport, err)
+		return failErr
+	}
+
+	completedAt := time.Now()
+	dataExport.Status = privacyDomain.DataExportStatusCompleted
+	dataExport.StorageKey = key
+	dataExport.CompletedAt = &completedAt
+	if _, err := s.privacyRepo.UpdateDataExport(ctx, dataExport); err != nil {
+		return err
+	}
+
+	return s.auditService.Record(ctx, &auditDomain.AuditLog{
+		Action:     AuditActionDataExportCompleted,
+		EntityType: auditDomain.EntityTypeUser,
+		EntityID:   dataExport.UserID,
+		Metadata:   map[string]any{"data_export_id": dataExport.ID},
+	})
+}
+
+func (s *privacyService) buildArchive(ctx context.Context, dataExport *privacyDomain.DataExport) (string, error) {
+	user, err := s.userRepository.FindUser(ctx, dataExport.UserID)
+	if err != nil {
+		return "", err
+	}
+	if user.ErasedAt != nil {
+		return "", privacyError.UserAlreadyErased
+	}
+
+	orders, err := s.orderRepo.FindOrdersByUserID(ctx, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	auditLogs, err := s.auditRepo.FindAuditLogsByEntity(ctx, auditDomain.EntityTypeUser, user.ID)
+	if err != nil {
+		return "", err
+	}
+
+	content, err := newArchive(user, orders, auditLogs).encode(dataExport.Format)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("data-exports/%d/%d.%s", user.ID, dataExport.ID, fileExtension(dataExport.Format))
+	if err := s.storage.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// failDataExport marks the export as failed and returns cause.
+func (s *privacyService) failDataExport(ctx context.Context, dataExport *privacyDomain.DataExport, cause error) (*privacyDomain.DataExport, error) {
+	dataExport.Status = privacyDomain.DataExportStatusFailed
+	dataExport.Error = cause.Error()
+	if _, err := s.privacyRepo.UpdateDataExport(ctx, dataExport); err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.failDataExport", slog.String("error", err.Error()))
+	}
+
+	if err := s.auditService.Record(ctx, &auditDomain.AuditLog{
+		Action:     AuditActionDataExportFailed,
+		EntityType: auditDomain.EntityTypeUser,
+		EntityID:   dataExport.UserID,
+		Metadata:   map[string]any{"data_export_id": dataExport.ID},
+	}); err != nil {
+		s.logger.ErrorContext(ctx, "PrivacyService.failDataExport", slog.String("error", err.Error()))
+	}
+
+	return nil, cause
+}
@@ -0,0 +1,223 @@
+package privacy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	auditDomain "github.com/umefy/go-web-app-template/internal/domain/audit"
+	privacyDomain "github.com/umefy/go-web-app-template/internal/domain/privacy"
+	privacyError "github.com/umefy/go-web-app-template/internal/domain/privacy/error"
+	privacyRepo "github.com/umefy/go-web-app-template/internal/domain/privacy/repo"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userRepo "github.com/umefy/go-web-app-template/internal/domain/user/repo"
+	auditSvc "github.com/umefy/go-web-app-template/internal/service/audit"
+	loggerMocks "github.com/umefy/go-web-app-template/mocks/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/storage"
+)
+
+// userRepository holds users by id and keeps the updates.
+type userRepository struct {
+	userRepo.Repository
+	users map[int]*userDomain.User
+}
+
+func (r *userRepository) FindUser(_ context.Context, id int) (*userDomain.User, error) {
+	user := *r.users[id]
+	return &user, nil
+}
+
+func (r *userRepository) UpdateUser(_ context.Context, id int, user *userDomain.User) (*userDomain.User, error) {
+	updated := *user
+	r.users[id] = &updated
+	return user, nil
+}
+
+// dataExportRepository holds data exports by id.
+type dataExportRepository struct {
+	privacyRepo.Repository
+	dataExports map[int]*privacyDomain.DataExport
+}
+
+func (r *dataExportRepository) FindDataExport(_ context.Context, id int) (*privacyDomain.DataExport, error) {
+	dataExport, ok := r.dataExports[id]
+	if !ok {
+		return nil, privacyError.DataExportNotFound
+	}
+	found := *dataExport
+	return &found, nil
+}
+
+func (r *dataExportRepository) FindDataExportsByUserID(_ context.Context, userID int) ([]*privacyDomain.DataExport, error) {
+	var result []*privacyDomain.DataExport
+	for _, dataExport := range r.dataExports {
+		if dataExport.UserID == userID {
+			found := *dataExport
+			result = append(result, &found)
+		}
+	}
+	return result, nil
+}
+
+func (r *dataExportRepository) UpdateDataExport(_ context.Context, dataExport *privacyDomain.DataExport) (*privacyDomain.DataExport, error) {
+	updated := *dataExport
+	r.dataExports[dataExport.ID] = &updated
+	return dataExport, nil
+}
+
+// auditService keeps the recorded actions, or fails with err.
+type auditService struct {
+	auditSvc.Service
+	actions []string
+	err     error
+}
+
+func (s *auditService) Record(_ context.Context, auditLog *auditDomain.AuditLog) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.actions = append(s.actions, auditLog.Action)
+	return nil
+}
+
+// failingStorage fails to delete objects.
+type failingStorage struct {
+	storage.Storage
+}
+
+func (s *failingStorage) Delete(_ context.Context, _ string) error {
+	return errors.New("bucket is gone")
+}
+
+type PrivacyServiceSuite struct {
+	suite.Suite
+	logger       *loggerMocks.MockLogger
+	users        *userRepository
+	dataExports  *dataExportRepository
+	auditService *auditService
+	storage      *storage.LocalStorage
+	service      *privacyService
+}
+
+func (s *PrivacyServiceSuite) SetupTest() {
+	var err error
+	s.storage, err = storage.NewLocalStorage(s.T().TempDir())
+	s.Require().NoError(err)
+	s.Require().NoError(s.storage.Put(s.T().Context(), "data-exports/1/1.json", strings.NewReader(`{"user":{}}`)))
+
+	s.logger = loggerMocks.NewMockLogger(s.T())
+	s.users = &userRepository{users: map[int]*userDomain.User{
+		1: {ID: 1, Email: "john@example.com", Age: 30},
+	}}
+	s.dataExports = &dataExportRepository{dataExports: map[int]*privacyDomain.DataExport{
+		1: {ID: 1, UserID: 1, Status: privacyDomain.DataExportStatusCompleted, StorageKey: "data-exports/1/1.json"},
+		2: {ID: 2, UserID: 1, Status: privacyDomain.DataExportStatusPending},
+		3: {ID: 3, UserID: 2, Status: privacyDomain.DataExportStatusCompleted, StorageKey: "data-exports/2/3.json"},
+	}}
+	s.auditService = &auditService{}
+	s.service = NewService(s.logger, s.users, nil, nil, s.dataExports, s.auditService, s.storage, nil)
+}
+
+func (s *PrivacyServiceSuite) archiveExists(key string) bool {
+	r, err := s.storage.Get(s.T().Context(), key)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return false
+	}
+	s.Require().NoError(err)
+	r.Close() //nolint:errcheck
+	return true
+}
+
+func (s *PrivacyServiceSuite) TestEraseUser() {
+	user, err := s.service.EraseUser(s.T().Context(), "1")
+	s.Require().NoError(err)
+
+	s.Equal("erased-1@erased.invalid", user.Email)
+	s.Zero(user.Age)
+	s.NotNil(user.ErasedAt)
+	s.Equal(user.Email, s.users.users[1].Email)
+
+	s.Equal(privacyDomain.DataExportStatusPurged, s.dataExports.dataExports[1].Status)
+	s.Equal(privacyDomain.DataExportStatusPurged, s.dataExports.dataExports[2].Status)
+	s.Equal(privacyDomain.DataExportStatusCompleted, s.dataExports.dataExports[3].Status)
+	s.Equal([]string{AuditActionUserErased}, s.auditService.actions)
+
+	// outside of a transaction the archives are deleted right away
+	s.False(s.archiveExists("data-exports/1/1.json"))
+}
+
+func (s *PrivacyServiceSuite) TestEraseUserAlreadyErased() {
+	_, err := s.service.EraseUser(s.T().Context(), "1")
+	s.Require().NoError(err)
+	s.auditService.actions = nil
+
+	_, err = s.service.EraseUser(s.T().Context(), "1")
+
+	s.ErrorIs(err, privacyError.UserAlreadyErased)
+	s.Empty(s.auditService.actions)
+}
+
+func (s *PrivacyServiceSuite) TestEraseUserFails() {
+	s.auditService.err = errors.New("database is down")
+
+	_, err := s.service.EraseUser(s.T().Context(), "1")
+
+	// the transaction is rolled back, the archives must still be there
+	s.ErrorIs(err, s.auditService.err)
+	s.True(s.archiveExists("data-exports/1/1.json"))
+}
+
+func (s *PrivacyServiceSuite) TestEraseUserDeleteFails() {
+	s.service.storage = &failingStorage{Storage: s.storage}
+	s.logger.EXPECT().ErrorContext(mock.Anything, "PrivacyService.EraseUser", mock.Anything, mock.Anything).Once()
+
+	// the erasure is committed by then, the archive is only logged for cleanup
+	user, err := s.service.EraseUser(s.T().Context(), "1")
+
+	s.Require().NoError(err)
+	s.NotNil(user.ErasedAt)
+}
+
+func (s *PrivacyServiceSuite) TestOpenDataExport() {
+	tests := []struct {
+		name     string
+		userID   string
+		exportID string
+		err      error
+	}{
+		{name: "completed", userID: "1", exportID: "1"},
+		{name: "not ready", userID: "1", exportID: "2", err: privacyError.DataExportNotReady},
+		{name: "someone else's", userID: "1", exportID: "3", err: privacyError.DataExportNotFound},
+		{name: "unknown", userID: "1", exportID: "9", err: privacyError.DataExportNotFound},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.auditService.actions = nil
+
+			dataExport, r, err := s.service.OpenDataExport(s.T().Context(), test.userID, test.exportID)
+
+			if test.err != nil {
+				s.ErrorIs(err, test.err)
+				s.Nil(dataExport)
+				s.Nil(r)
+				s.Empty(s.auditService.actions)
+				return
+			}
+			s.Require().NoError(err)
+			defer r.Close() //nolint:errcheck
+			content, err := io.ReadAll(r)
+			s.Require().NoError(err)
+			s.JSONEq(`{"user":{}}`, string(content))
+			s.Equal([]string{AuditActionDataExportDownloaded}, s.auditService.actions)
+		})
+	}
+}
+
+func TestPrivacyServiceSuite(t *testing.T) {
+	suite.Run(t, new(PrivacyServiceSuite))
+}
@@ -0,0 +1,105 @@
+package search
+
+import (
+	"html"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+)
+
+const (
+	highlightStart = "<mark>"
+	highlightEnd   = "</mark>"
+)
+
+// searchTerms splits the query the way the simple text search configuration does. "or" and excluded
+// "-word" terms are operators of websearch_to_tsquery and never highlighted.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if word == "or" || strings.HasPrefix(word, "-") {
+			continue
+		}
+		terms = append(terms, strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+
+	slices.Sort(terms)
+	return slices.Compact(terms)
+}
+
+// highlights returns a snippet for every document field containing one of the terms, in field order.
+func highlights(document map[string]string, terms []string) []searchDomain.Highlight {
+	result := []searchDomain.Highlight{}
+	for _, field := range slices.Sorted(maps.Keys(document)) {
+		if snippet, ok := highlight(document[field], terms); ok {
+			result = append(result, searchDomain.Highlight{Field: field, Snippet: snippet})
+		}
+	}
+	return result
+}
+
+// highlight html escapes text and wraps every case-insensitive occurrence of the terms in <mark> tags.
+func highlight(text string, terms []string) (string, bool) {
+	// lowering may change the byte length of some runes, offsets would no longer line up
+	if !lowerKeepsOffsets(text) {
+		return "", false
+	}
+	lower := strings.ToLower(text)
+
+	marked := make([]bool, len(text))
+	found := false
+	for _, term := range terms {
+		for start := 0; ; {
+			i := strings.Index(lower[start:], term)
+			if i < 0 {
+				break
+			}
+			for j := start + i; j < start+i+len(term); j++ {
+				marked[j] = true
+			}
+			found = true
+			start += i + len(term)
+		}
+	}
+
+	if !found {
+		return "", false
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString(highlightStart)
+			b.WriteString(html.EscapeString(text[i:j]))
+			b.WriteString(highlightEnd)
+		} else {
+			b.WriteString(html.EscapeString(text[i:j]))
+		}
+		i = j
+	}
+
+	return b.String(), true
+}
+
+// lowerKeepsOffsets reports whether every rune of text keeps its byte length once lowered. Comparing the
+// total length is not enough, a rune growing and another shrinking would shift the offsets in between.
+func lowerKeepsOffsets(text string) bool {
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if utf8.RuneLen(unicode.ToLower(r)) != size {
+			return false
+		}
+		i += size
+	}
+	return true
+}
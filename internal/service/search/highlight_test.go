@@ -0,0 +1,107 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+)
+
+type HighlightSuite struct {
+	suite.Suite
+}
+
+func (s *HighlightSuite) TestSearchTerms() {
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{query: "", expected: nil},
+		{query: "   ", expected: nil},
+		{query: "John Doe", expected: []string{"doe", "john"}},
+		{query: "john john JOHN", expected: []string{"john"}},
+		{query: "john OR jane -doe", expected: []string{"jane", "john"}},
+		{query: `"John Doe" or jane`, expected: []string{"doe", "jane", "john"}},
+		{query: "o'brien foo-bar", expected: []string{"bar", "brien", "foo", "o"}},
+		{query: "Élodie 東京", expected: []string{"élodie", "東京"}},
+		{query: "-john OR", expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Equal(test.expected, searchTerms(test.query), test.query)
+	}
+}
+
+func (s *HighlightSuite) TestHighlight() {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected string
+		found    bool
+	}{
+		{name: "no terms", text: "John Doe", terms: nil},
+		{name: "no match", text: "John Doe", terms: []string{"jane"}},
+		{name: "case insensitive", text: "John Doe", terms: []string{"john"}, expected: "<mark>John</mark> Doe", found: true},
+		{
+			name:     "repeated term",
+			text:     "john and Johnny",
+			terms:    []string{"john"},
+			expected: "<mark>john</mark> and <mark>John</mark>ny",
+			found:    true,
+		},
+		{name: "overlapping terms", text: "johnson", terms: []string{"john", "ohns"}, expected: "<mark>johns</mark>on", found: true},
+		{name: "adjacent terms", text: "abcd", terms: []string{"ab", "cd"}, expected: "<mark>abcd</mark>", found: true},
+		{
+			name:     "html is escaped",
+			text:     `<b>Tom & "Jerry"</b>`,
+			terms:    []string{"tom"},
+			expected: "&lt;b&gt;<mark>Tom</mark> &amp; &#34;Jerry&#34;&lt;/b&gt;",
+			found:    true,
+		},
+		{
+			name:     "html is escaped between marks",
+			text:     "Tom&Jerry",
+			terms:    []string{"tom", "jerry"},
+			expected: "<mark>Tom</mark>&amp;<mark>Jerry</mark>",
+			found:    true,
+		},
+		{name: "multibyte runes", text: "Élodie à Paris", terms: []string{"élodie"}, expected: "<mark>Élodie</mark> à Paris", found: true},
+		{name: "cjk", text: "東京タワー", terms: []string{"京"}, expected: "東<mark>京</mark>タワー", found: true},
+		{name: "sharp s", text: "STRASSE Straße", terms: []string{"straße"}, expected: "STRASSE <mark>Straße</mark>", found: true},
+		{name: "rune shrinks once lowered", text: "İstanbul", terms: []string{"stanbul"}},
+		// Ⱥ grows by a byte once lowered and the kelvin sign shrinks by two, the total length doesn't change
+		{name: "runes change length but not the text", text: "ȺȺ\u212a", terms: []string{"k"}},
+		{name: "invalid utf-8", text: "john\xff", terms: []string{"john"}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			snippet, found := highlight(test.text, test.terms)
+			s.Equal(test.found, found)
+			s.Equal(test.expected, snippet)
+		})
+	}
+}
+
+func (s *HighlightSuite) TestHighlights() {
+	document := map[string]string{
+		"name":  "John Doe",
+		"email": "john@example.com",
+		"bio":   "nothing to see",
+	}
+
+	s.Equal([]searchDomain.Highlight{
+		{Field: "email", Snippet: "<mark>john</mark>@example.com"},
+		{Field: "name", Snippet: "<mark>John</mark> Doe"},
+	}, highlights(document, searchTerms("JOHN")))
+
+	// documents without matches still get an empty list, not null
+	result := highlights(document, searchTerms(""))
+	s.NotNil(result)
+	s.Empty(result)
+}
+
+func TestHighlightSuite(t *testing.T) {
+	suite.Run(t, new(HighlightSuite))
+}
@@ -0,0 +1,35 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type SearchInput struct {
+	Query string
+	Limit int
+}
+
+func NewSearchInput(query string, limit int) *SearchInput {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	return &SearchInput{
+		Query: strings.TrimSpace(query),
+		Limit: limit,
+	}
+}
+
+func (s *SearchInput) Validate() error {
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Query, validation.Required, validation.RuneLength(2, 100)),
+		validation.Field(&s.Limit, validation.Min(1), validation.Max(MaxLimit)),
+	)
+}
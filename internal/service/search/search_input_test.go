@@ -0,0 +1,73 @@
+package search
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type SearchInputSuite struct {
+	suite.Suite
+}
+
+func (s *SearchInputSuite) TestNewSearchInput() {
+	tests := []struct {
+		query         string
+		limit         int
+		expectedQuery string
+		expectedLimit int
+	}{
+		{query: "john", limit: 10, expectedQuery: "john", expectedLimit: 10},
+		{query: "  john doe \n", limit: 0, expectedQuery: "john doe", expectedLimit: DefaultLimit},
+		{query: "john", limit: -1, expectedQuery: "john", expectedLimit: DefaultLimit},
+		{query: "john", limit: MaxLimit + 1, expectedQuery: "john", expectedLimit: MaxLimit + 1},
+	}
+
+	for _, test := range tests {
+		input := NewSearchInput(test.query, test.limit)
+		s.Equal(test.expectedQuery, input.Query)
+		s.Equal(test.expectedLimit, input.Limit)
+	}
+}
+
+func (s *SearchInputSuite) TestValidate() {
+	tests := []struct {
+		name          string
+		query         string
+		limit         int
+		invalidFields []string
+	}{
+		{name: "valid", query: "john", limit: 0},
+		{name: "shortest query", query: "jo", limit: 1},
+		{name: "two multibyte runes", query: "東京", limit: MaxLimit},
+		{name: "longest multibyte query", query: strings.Repeat("é", 100), limit: 0},
+		{name: "empty query", query: "", limit: 0, invalidFields: []string{"Query"}},
+		{name: "blank query", query: "   ", limit: 0, invalidFields: []string{"Query"}},
+		{name: "one rune", query: "東", limit: 0, invalidFields: []string{"Query"}},
+		{name: "query too long", query: strings.Repeat("é", 101), limit: 0, invalidFields: []string{"Query"}},
+		{name: "limit too large", query: "john", limit: MaxLimit + 1, invalidFields: []string{"Limit"}},
+		{name: "everything invalid", query: "j", limit: MaxLimit + 1, invalidFields: []string{"Limit", "Query"}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := NewSearchInput(test.query, test.limit).Validate()
+			if test.invalidFields == nil {
+				s.NoError(err)
+				return
+			}
+
+			var validateErr *validation.ValidateStructError
+			s.Require().ErrorAs(err, &validateErr)
+			s.Equal(test.invalidFields, slices.Sorted(maps.Keys(validateErr.Errors)))
+		})
+	}
+}
+
+func TestSearchInputSuite(t *testing.T) {
+	suite.Run(t, new(SearchInputSuite))
+}
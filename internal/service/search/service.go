@@ -0,0 +1,49 @@
+package search
+
+import (
+	"context"
+	"log/slog"
+
+	searchDomain "github.com/umefy/go-web-app-template/internal/domain/search"
+	searchRepo "github.com/umefy/go-web-app-template/internal/domain/search/repo"
+	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+)
+
+type Service interface {
+	// Search finds users and orders matching the input query, best matches first, with highlighted snippets.
+	Search(ctx context.Context, input *SearchInput) ([]*searchDomain.Result, error)
+}
+
+type searchService struct {
+	logger     logger.Logger
+	searchRepo searchRepo.Repository
+}
+
+var _ Service = (*searchService)(nil)
+
+func NewService(logger logger.Logger, searchRepo searchRepo.Repository) *searchService {
+	return &searchService{
+		logger:     logger,
+		searchRepo: searchRepo,
+	}
+}
+
+// Search implements Service.
+func (s *searchService) Search(ctx context.Context, input *SearchInput) ([]*searchDomain.Result, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, err := s.searchRepo.Search(ctx, input.Query, input.Limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "SearchService.Search", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	terms := searchTerms(input.Query)
+	for _, result := range results {
+		result.Highlights = highlights(result.Document, terms)
+	}
+
+	return results, nil
+}
@@ -2,23 +2,34 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strconv"
 
 	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
 	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
 	"github.com/umefy/go-web-app-template/internal/domain/user/repo"
 	"github.com/umefy/go-web-app-template/internal/infrastructure/logger"
+	"github.com/umefy/go-web-app-template/pkg/listing"
 	"github.com/umefy/go-web-app-template/pkg/pagination"
+	"github.com/umefy/godash/sliceskit"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type Service interface {
-	GetUsers(ctx context.Context, p pagination.Pagination) ([]*userDomain.User, *pagination.PaginationMetadata, error)
+	GetUsers(ctx context.Context, p pagination.Pagination, q listing.RawQuery) ([]*userDomain.User, *pagination.PaginationMetadata, error)
+	GetUsersByCursor(ctx context.Context, p pagination.CursorPagination, q listing.RawQuery) (*pagination.Connection[*userDomain.User], error)
+	// ExportUsers streams every user matching q to fn in batches, for exports too large to hold in memory.
+	ExportUsers(ctx context.Context, q listing.RawQuery, fn func([]*userDomain.User) error) error
 	GetUser(ctx context.Context, id string) (*userDomain.User, error)
 	IsUserExists(ctx context.Context, email string) (bool, error)
 	CreateUser(ctx context.Context, userCreateInput *UserCreateInput) (*userDomain.User, error)
+	// CreateUsers validates every item and creates the valid ones according to the batch mode.
+	// Invalid items are reported in the result rather than as an error. It must run in a transaction.
+	CreateUsers(ctx context.Context, batchInput *UserBatchCreateInput) (*UserBatchCreateResult, error)
 	UpdateUser(ctx context.Context, id string, userUpdateInput *UserUpdateInput) (*userDomain.User, error)
 }
 
@@ -26,21 +37,27 @@ type userService struct {
 	logger         logger.Logger
 	userRepository repo.Repository
 	tracerProvider trace.TracerProvider
+	cursorCodec    *pagination.CursorCodec
 }
 
 var _ Service = (*userService)(nil)
 
-func NewService(logger logger.Logger, userRepository repo.Repository, tracerProvider trace.TracerProvider) *userService {
-	return &userService{logger: logger, userRepository: userRepository, tracerProvider: tracerProvider}
+func NewService(logger logger.Logger, userRepository repo.Repository, tracerProvider trace.TracerProvider, cursorCodec *pagination.CursorCodec) *userService {
+	return &userService{logger: logger, userRepository: userRepository, tracerProvider: tracerProvider, cursorCodec: cursorCodec}
 }
 
 // GetUsers implements Service.
-func (u *userService) GetUsers(ctx context.Context, p pagination.Pagination) ([]*userDomain.User, *pagination.PaginationMetadata, error) {
+func (u *userService) GetUsers(ctx context.Context, p pagination.Pagination, q listing.RawQuery) ([]*userDomain.User, *pagination.PaginationMetadata, error) {
 	tr := u.tracerProvider.Tracer("userService")
 	_, span := tr.Start(ctx, "GetUsers")
 	defer span.End()
 
-	usersDb, paginationMetadata, err := u.userRepository.FindUsers(ctx, p)
+	listingQuery, err := UserListSchema.Parse(q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usersDb, paginationMetadata, err := u.userRepository.FindUsers(ctx, p, listingQuery)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -48,6 +65,48 @@ func (u *userService) GetUsers(ctx context.Context, p pagination.Pagination) ([]
 	return usersDb, paginationMetadata, nil
 }
 
+// GetUsersByCursor implements Service.
+func (u *userService) GetUsersByCursor(ctx context.Context, p pagination.CursorPagination, q listing.RawQuery) (*pagination.Connection[*userDomain.User], error) {
+	tr := u.tracerProvider.Tracer("userService")
+	_, span := tr.Start(ctx, "GetUsersByCursor")
+	defer span.End()
+
+	listingQuery, err := UserListSchema.Parse(q)
+	if err != nil {
+		return nil, err
+	}
+
+	keyset, err := u.cursorCodec.Keyset(p)
+	if err != nil {
+		u.logger.ErrorContext(ctx, "UserService.GetUsersByCursor", slog.String("error", err.Error()))
+		return nil, userError.InvalidCursor
+	}
+
+	result, err := u.userRepository.FindUsersByKeyset(ctx, keyset, listingQuery)
+	if errors.Is(err, pagination.ErrInvalidCursor) {
+		return nil, userError.InvalidCursor
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return pagination.NewConnection(u.cursorCodec, result)
+}
+
+// ExportUsers implements Service.
+func (u *userService) ExportUsers(ctx context.Context, q listing.RawQuery, fn func([]*userDomain.User) error) error {
+	tr := u.tracerProvider.Tracer("userService")
+	ctx, span := tr.Start(ctx, "ExportUsers")
+	defer span.End()
+
+	listingQuery, err := UserListSchema.Parse(q)
+	if err != nil {
+		return err
+	}
+
+	return u.userRepository.StreamUsers(ctx, listingQuery, fn)
+}
+
 func (u *userService) GetUser(ctx context.Context, id string) (*userDomain.User, error) {
 	userID, err := strconv.Atoi(id)
 	if err != nil {
@@ -89,6 +148,73 @@ func (u *userService) CreateUser(ctx context.Context, createUserInput *UserCreat
 	return userDb, nil
 }
 
+// CreateUsers implements Service.
+func (u *userService) CreateUsers(ctx context.Context, batchInput *UserBatchCreateInput) (*UserBatchCreateResult, error) {
+	tr := u.tracerProvider.Tracer("userService")
+	ctx, span := tr.Start(ctx, "CreateUsers")
+	defer span.End()
+
+	if err := batchInput.Validate(); err != nil {
+		return nil, err
+	}
+
+	result := &UserBatchCreateResult{Items: make([]*UserBatchItemResult, len(batchInput.Items))}
+	indexByEmail := make(map[string]int, len(batchInput.Items))
+	for i, item := range batchInput.Items {
+		result.Items[i] = &UserBatchItemResult{Index: i}
+
+		if err := item.Validate(); err != nil {
+			result.fail(i, err)
+			continue
+		}
+
+		if _, ok := indexByEmail[item.Email]; ok {
+			result.fail(i, userError.DuplicateBatchUser)
+			continue
+		}
+		indexByEmail[item.Email] = i
+	}
+
+	if len(indexByEmail) > 0 {
+		existingEmails, err := u.userRepository.FindExistingEmails(ctx, slices.Collect(maps.Keys(indexByEmail)))
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range existingEmails {
+			result.fail(indexByEmail[email], userError.UserAlreadyExists)
+			delete(indexByEmail, email)
+		}
+	}
+
+	if batchInput.Mode == BatchModeAtomic && result.Count(BatchItemStatusFailed) > 0 {
+		for _, item := range result.Items {
+			if item.Status == "" {
+				item.Status = BatchItemStatusSkipped
+			}
+		}
+		return result, nil
+	}
+
+	indexes := slices.Sorted(maps.Values(indexByEmail))
+	if len(indexes) == 0 {
+		return result, nil
+	}
+
+	users, err := u.userRepository.CreateUsers(ctx, sliceskit.Map(indexes, func(i int) *userDomain.User {
+		return batchInput.Items[i].MapToDomainUser()
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, user := range users {
+		result.Items[indexes[i]].Status = BatchItemStatusCreated
+		result.Items[indexes[i]].User = user
+	}
+
+	return result, nil
+}
+
 // UpdateUser implements Service.
 func (u *userService) UpdateUser(ctx context.Context, id string, updateUserInput *UserUpdateInput) (*userDomain.User, error) {
 	userID, err := strconv.Atoi(id)
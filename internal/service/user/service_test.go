@@ -0,0 +1,198 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	domainError "github.com/umefy/go-web-app-template/internal/domain/error"
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+	userError "github.com/umefy/go-web-app-template/internal/domain/user/error"
+	"github.com/umefy/go-web-app-template/internal/domain/user/repo"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// batchRepository knows of existing emails and records the users it creates.
+type batchRepository struct {
+	repo.Repository
+	existing  map[string]bool
+	createErr error
+	created   []*userDomain.User
+}
+
+func (r *batchRepository) FindExistingEmails(_ context.Context, emails []string) ([]string, error) {
+	var result []string
+	for _, email := range emails {
+		if r.existing[email] {
+			result = append(result, email)
+		}
+	}
+	return result, nil
+}
+
+func (r *batchRepository) CreateUsers(_ context.Context, users []*userDomain.User) ([]*userDomain.User, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	for _, user := range users {
+		user.ID = len(r.created) + 1
+		r.created = append(r.created, user)
+	}
+	return users, nil
+}
+
+type CreateUsersSuite struct {
+	suite.Suite
+}
+
+func (s *CreateUsersSuite) TestCreateUsers() {
+	tests := []struct {
+		name     string
+		mode     BatchMode
+		items    []*UserCreateInput
+		existing []string
+		// expected holds the status of each item, and its error code when it failed
+		expected []string
+		created  []string
+	}{
+		{
+			name:     "atomic",
+			mode:     BatchModeAtomic,
+			items:    []*UserCreateInput{{Email: "john@example.com", Age: 30}, {Email: "jane@example.com", Age: 40}},
+			expected: []string{"created", "created"},
+			created:  []string{"john@example.com", "jane@example.com"},
+		},
+		{
+			name:     "atomic with a failed item creates none",
+			mode:     BatchModeAtomic,
+			items:    []*UserCreateInput{{Email: "john@example.com", Age: 30}, {Email: "not an email", Age: 40}, {Email: "jane@example.com"}},
+			expected: []string{"skipped", "failed VALIDATION_ERROR", "skipped"},
+		},
+		{
+			name:     "partial",
+			mode:     BatchModePartial,
+			items:    []*UserCreateInput{{Email: "john@example.com", Age: 30}, {Email: "not an email", Age: 40}, {Email: "jane@example.com"}},
+			expected: []string{"created", "failed VALIDATION_ERROR", "created"},
+			created:  []string{"john@example.com", "jane@example.com"},
+		},
+		{
+			name:     "per item validation errors",
+			mode:     BatchModePartial,
+			items:    []*UserCreateInput{{Email: "", Age: 30}, {Email: "john@example.com", Age: 200}, {Email: "jane@example.com", Age: -1}},
+			expected: []string{"failed VALIDATION_ERROR", "failed VALIDATION_ERROR", "failed VALIDATION_ERROR"},
+		},
+		{
+			name:  "duplicate emails within the batch",
+			mode:  BatchModePartial,
+			items: []*UserCreateInput{{Email: "john@example.com"}, {Email: "jane@example.com"}, {Email: "john@example.com"}},
+			expected: []string{
+				"created",
+				"created",
+				"failed " + userError.DuplicateBatchUser.Code,
+			},
+			created: []string{"john@example.com", "jane@example.com"},
+		},
+		{
+			name:     "emails that already exist",
+			mode:     BatchModePartial,
+			items:    []*UserCreateInput{{Email: "john@example.com"}, {Email: "jane@example.com"}, {Email: "john@example.com"}},
+			existing: []string{"john@example.com"},
+			expected: []string{
+				"failed " + userError.UserAlreadyExists.Code,
+				"created",
+				"failed " + userError.DuplicateBatchUser.Code,
+			},
+			created: []string{"jane@example.com"},
+		},
+		{
+			name:     "atomic with an email that already exists creates none",
+			mode:     BatchModeAtomic,
+			items:    []*UserCreateInput{{Email: "john@example.com"}, {Email: "jane@example.com"}},
+			existing: []string{"jane@example.com"},
+			expected: []string{"skipped", "failed " + userError.UserAlreadyExists.Code},
+		},
+		{
+			name:     "partial without a valid item",
+			mode:     BatchModePartial,
+			items:    []*UserCreateInput{{Email: "john@example.com"}},
+			existing: []string{"john@example.com"},
+			expected: []string{"failed " + userError.UserAlreadyExists.Code},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			repository := &batchRepository{existing: map[string]bool{}}
+			for _, email := range test.existing {
+				repository.existing[email] = true
+			}
+			service := NewService(nil, repository, noop.NewTracerProvider(), nil)
+
+			result, err := service.CreateUsers(s.T().Context(), &UserBatchCreateInput{Mode: test.mode, Items: test.items})
+			s.Require().NoError(err)
+
+			statuses := make([]string, len(result.Items))
+			for i, item := range result.Items {
+				s.Equal(i, item.Index)
+				statuses[i] = string(item.Status)
+				if item.Err != nil {
+					statuses[i] = fmt.Sprintf("%s %s", item.Status, errorCode(item.Err))
+				}
+				s.Equal(item.Status == BatchItemStatusCreated, item.User != nil, "item %d", i)
+			}
+			s.Equal(test.expected, statuses)
+
+			var created []string
+			for _, user := range repository.created {
+				created = append(created, user.Email)
+			}
+			s.Equal(test.created, created)
+		})
+	}
+}
+
+func (s *CreateUsersSuite) TestInvalidBatch() {
+	repository := &batchRepository{}
+	service := NewService(nil, repository, noop.NewTracerProvider(), nil)
+
+	result, err := service.CreateUsers(s.T().Context(), &UserBatchCreateInput{Mode: BatchModeAtomic})
+
+	s.Nil(result)
+	var validateErr *validation.ValidateStructError
+	s.ErrorAs(err, &validateErr)
+}
+
+func (s *CreateUsersSuite) TestCreateFails() {
+	// the error rolls back the transaction CreateUsers runs in
+	createErr := errors.New("database is down")
+	repository := &batchRepository{createErr: createErr}
+	service := NewService(nil, repository, noop.NewTracerProvider(), nil)
+
+	result, err := service.CreateUsers(s.T().Context(), &UserBatchCreateInput{
+		Mode:  BatchModeAtomic,
+		Items: []*UserCreateInput{{Email: "john@example.com"}},
+	})
+
+	s.Nil(result)
+	s.ErrorIs(err, createErr)
+}
+
+// errorCode is the code errutil.FormatError reports err with.
+func errorCode(err error) string {
+	var validateErr *validation.ValidateStructError
+	if errors.As(err, &validateErr) {
+		return "VALIDATION_ERROR"
+	}
+	var domainErr *domainError.Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return err.Error()
+}
+
+func TestCreateUsersSuite(t *testing.T) {
+	suite.Run(t, new(CreateUsersSuite))
+}
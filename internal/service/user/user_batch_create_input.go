@@ -0,0 +1,29 @@
+package user
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type BatchMode string
+
+const (
+	// BatchModeAtomic creates every item or none of them.
+	BatchModeAtomic BatchMode = "atomic"
+	// BatchModePartial creates the valid items and reports the others as failed.
+	BatchModePartial BatchMode = "partial"
+)
+
+const MaxBatchSize = 1000
+
+type UserBatchCreateInput struct {
+	Mode  BatchMode
+	Items []*UserCreateInput
+}
+
+func (u *UserBatchCreateInput) Validate() error {
+	return validation.ValidateStruct(u,
+		validation.Field(&u.Mode, validation.Required, validation.In(BatchModeAtomic, BatchModePartial)),
+		// items are validated one by one, their errors are reported per item
+		validation.Field(&u.Items, validation.Required, validation.Length(1, MaxBatchSize), validation.Skip),
+	)
+}
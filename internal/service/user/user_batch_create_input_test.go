@@ -0,0 +1,79 @@
+package user
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type UserBatchCreateInputSuite struct {
+	suite.Suite
+}
+
+func (s *UserBatchCreateInputSuite) TestValidate() {
+	validItems := []*UserCreateInput{{Email: "john@example.com", Age: 30}}
+
+	tests := []struct {
+		name   string
+		input  *UserBatchCreateInput
+		fields []string
+	}{
+		{
+			name:  "atomic",
+			input: &UserBatchCreateInput{Mode: BatchModeAtomic, Items: validItems},
+		},
+		{
+			name:  "partial",
+			input: &UserBatchCreateInput{Mode: BatchModePartial, Items: validItems},
+		},
+		{
+			name:  "invalid items are left to the per item validation",
+			input: &UserBatchCreateInput{Mode: BatchModeAtomic, Items: []*UserCreateInput{{Email: "not an email", Age: 200}}},
+		},
+		{
+			name:  "maximum size",
+			input: &UserBatchCreateInput{Mode: BatchModeAtomic, Items: make([]*UserCreateInput, MaxBatchSize)},
+		},
+		{
+			name:   "missing mode",
+			input:  &UserBatchCreateInput{Items: validItems},
+			fields: []string{"Mode"},
+		},
+		{
+			name:   "unknown mode",
+			input:  &UserBatchCreateInput{Mode: "best_effort", Items: validItems},
+			fields: []string{"Mode"},
+		},
+		{
+			name:   "no items",
+			input:  &UserBatchCreateInput{Mode: BatchModeAtomic},
+			fields: []string{"Items"},
+		},
+		{
+			name:   "too many items",
+			input:  &UserBatchCreateInput{Mode: BatchModePartial, Items: make([]*UserCreateInput, MaxBatchSize+1)},
+			fields: []string{"Items"},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+
+			if test.fields == nil {
+				s.NoError(err)
+				return
+			}
+			var validateErr *validation.ValidateStructError
+			s.Require().ErrorAs(err, &validateErr)
+			s.Equal(test.fields, slices.Sorted(maps.Keys(validateErr.Errors)))
+		})
+	}
+}
+
+func TestUserBatchCreateInputSuite(t *testing.T) {
+	suite.Run(t, new(UserBatchCreateInputSuite))
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	userDomain "github.com/umefy/go-web-app-template/internal/domain/user"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemStatusCreated BatchItemStatus = "created"
+	BatchItemStatusFailed  BatchItemStatus = "failed"
+	// BatchItemStatusSkipped marks valid items that were not created because another item of an atomic batch failed.
+	BatchItemStatusSkipped BatchItemStatus = "skipped"
+)
+
+type UserBatchItemResult struct {
+	Index  int
+	Status BatchItemStatus
+	User   *userDomain.User
+	Err    error
+}
+
+// UserBatchCreateResult holds one result per input item, in input order.
+type UserBatchCreateResult struct {
+	Items []*UserBatchItemResult
+}
+
+func (u *UserBatchCreateResult) Count(status BatchItemStatus) int {
+	count := 0
+	for _, item := range u.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (u *UserBatchCreateResult) fail(index int, err error) {
+	u.Items[index].Status = BatchItemStatusFailed
+	u.Items[index].Err = err
+}
@@ -0,0 +1,31 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UserBatchCreateResultSuite struct {
+	suite.Suite
+}
+
+func (s *UserBatchCreateResultSuite) TestCount() {
+	result := &UserBatchCreateResult{Items: []*UserBatchItemResult{
+		{Index: 0, Status: BatchItemStatusCreated},
+		{Index: 1},
+		{Index: 2, Status: BatchItemStatusSkipped},
+		{Index: 3, Status: BatchItemStatusCreated},
+	}}
+	result.fail(1, errors.New("is invalid"))
+
+	s.Equal(2, result.Count(BatchItemStatusCreated))
+	s.Equal(1, result.Count(BatchItemStatusFailed))
+	s.Equal(1, result.Count(BatchItemStatusSkipped))
+	s.EqualError(result.Items[1].Err, "is invalid")
+}
+
+func TestUserBatchCreateResultSuite(t *testing.T) {
+	suite.Run(t, new(UserBatchCreateResultSuite))
+}
@@ -0,0 +1,37 @@
+package user
+
+import (
+	"github.com/umefy/go-web-app-template/pkg/listing"
+)
+
+// UserListSchema whitelists the user fields list endpoints can filter and sort on.
+var UserListSchema = listing.Schema{
+	Fields: map[string]listing.Field{
+		"id": {
+			Type:      listing.FieldTypeInt,
+			Operators: []listing.Operator{listing.OperatorEq, listing.OperatorIn},
+			Sortable:  true,
+		},
+		"email": {
+			Type:      listing.FieldTypeString,
+			Operators: []listing.Operator{listing.OperatorEq, listing.OperatorNeq, listing.OperatorIn, listing.OperatorContains},
+			Sortable:  true,
+		},
+		"age": {
+			Type:      listing.FieldTypeInt,
+			Operators: listing.ComparableOperators,
+			Sortable:  true,
+		},
+		"createdAt": {
+			Type:      listing.FieldTypeTime,
+			Operators: listing.RangeOperators,
+			Sortable:  true,
+		},
+		"updatedAt": {
+			Type:      listing.FieldTypeTime,
+			Operators: listing.RangeOperators,
+			Sortable:  true,
+		},
+	},
+	DefaultSort: []listing.SortKey{{Field: "id"}},
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists audit_logs (
+    id serial primary key,
+    action varchar(100) not null,
+    entity_type varchar(50) not null,
+    entity_id int not null,
+    request_id varchar(255),
+    metadata text,
+    created_at timestamptz default now()
+);
+
+create index if not exists idx_audit_logs_entity on audit_logs (entity_type, entity_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists audit_logs;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table users add column if not exists erased_at timestamptz;
+
+create table if not exists data_exports (
+    id serial primary key,
+    user_id int not null,
+    format varchar(10) not null,
+    status varchar(20) not null,
+    storage_key varchar(255),
+    error text,
+    completed_at timestamptz,
+    created_at timestamptz default now(),
+    updated_at timestamptz default now(),
+    constraint fk_data_exports_user_id foreign key (user_id) references users (id)
+);
+
+create index if not exists idx_data_exports_user_id on data_exports (user_id);
+
+CREATE TRIGGER updated_at_trigger
+BEFORE UPDATE ON data_exports
+FOR EACH ROW
+EXECUTE FUNCTION updated_at_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists data_exports;
+alter table users drop column if exists erased_at;
+-- +goose StatementEnd
@@ -0,0 +1,63 @@
+-- +goose Up
+-- +goose StatementBegin
+create extension if not exists pg_trgm;
+
+alter table users add column if not exists search_vector tsvector;
+alter table orders add column if not exists search_vector tsvector;
+
+-- emails are indexed whole and split into their parts, so "jane" finds "jane.doe@example.com"
+CREATE OR REPLACE FUNCTION users_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector =
+        setweight(to_tsvector('simple', coalesce(NEW.email, '')), 'A') ||
+        setweight(to_tsvector('simple', regexp_replace(coalesce(NEW.email, ''), '[@._+-]+', ' ', 'g')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION orders_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector =
+        setweight(to_tsvector('simple', NEW.id::text), 'A') ||
+        setweight(to_tsvector('simple', NEW.amount_cents::text || ' ' || to_char(NEW.amount_cents / 100.0, 'FM999999999999990.00')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER search_vector_trigger
+BEFORE INSERT OR UPDATE OF email ON users
+FOR EACH ROW
+EXECUTE FUNCTION users_search_vector_trigger();
+
+CREATE TRIGGER search_vector_trigger
+BEFORE INSERT OR UPDATE OF amount_cents ON orders
+FOR EACH ROW
+EXECUTE FUNCTION orders_search_vector_trigger();
+
+-- backfill existing rows through the triggers without touching updated_at
+alter table users disable trigger updated_at_trigger;
+alter table orders disable trigger updated_at_trigger;
+update users set email = email;
+update orders set amount_cents = amount_cents;
+alter table users enable trigger updated_at_trigger;
+alter table orders enable trigger updated_at_trigger;
+
+create index if not exists idx_users_search_vector on users using gin (search_vector);
+create index if not exists idx_users_email_trgm on users using gin (email gin_trgm_ops);
+create index if not exists idx_orders_search_vector on orders using gin (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists search_vector_trigger on orders;
+drop trigger if exists search_vector_trigger on users;
+drop function if exists orders_search_vector_trigger;
+drop function if exists users_search_vector_trigger;
+drop index if exists idx_orders_search_vector;
+drop index if exists idx_users_email_trgm;
+drop index if exists idx_users_search_vector;
+alter table orders drop column if exists search_vector;
+alter table users drop column if exists search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists data_imports (
+    id serial primary key,
+    entity varchar(20) not null,
+    status varchar(20) not null,
+    source_key varchar(255),
+    report_key varchar(255),
+    total_rows int not null default 0,
+    processed_rows int not null default 0,
+    imported_rows int not null default 0,
+    failed_rows int not null default 0,
+    error text,
+    completed_at timestamptz,
+    created_at timestamptz default now(),
+    updated_at timestamptz default now()
+);
+
+CREATE TRIGGER updated_at_trigger
+BEFORE UPDATE ON data_imports
+FOR EACH ROW
+EXECUTE FUNCTION updated_at_trigger();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists data_imports;
+-- +goose StatementEnd
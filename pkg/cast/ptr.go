@@ -0,0 +1,10 @@
+package cast
+
+// PtrToValue returns the value v points to, or the zero value when v is nil.
+func PtrToValue[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,91 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// Encoder writes records one by one, buffered until Flush.
+type Encoder[T any] interface {
+	Encode(record T) error
+	Flush() error
+}
+
+// Column is a CSV column, Value renders the cell of a record.
+type Column[T any] struct {
+	Name  string
+	Value func(record T) string
+}
+
+func NewEncoder[T any](format Format, w io.Writer, columns []Column[T]) Encoder[T] {
+	if format == FormatNDJSON {
+		return NewNDJSONEncoder[T](w)
+	}
+	return NewCSVEncoder(w, columns)
+}
+
+type CSVEncoder[T any] struct {
+	writer        *csv.Writer
+	columns       []Column[T]
+	headerWritten bool
+}
+
+var _ Encoder[any] = (*CSVEncoder[any])(nil)
+
+func NewCSVEncoder[T any](w io.Writer, columns []Column[T]) *CSVEncoder[T] {
+	return &CSVEncoder[T]{writer: csv.NewWriter(w), columns: columns}
+}
+
+func (e *CSVEncoder[T]) Encode(record T) error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		row[i] = column.Value(record)
+	}
+	return e.writer.Write(row)
+}
+
+// Flush writes the header row too, so an export without records still names its columns.
+func (e *CSVEncoder[T]) Flush() error {
+	if err := e.writeHeader(); err != nil {
+		return err
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+func (e *CSVEncoder[T]) writeHeader() error {
+	if e.headerWritten {
+		return nil
+	}
+	e.headerWritten = true
+
+	header := make([]string, len(e.columns))
+	for i, column := range e.columns {
+		header[i] = column.Name
+	}
+	return e.writer.Write(header)
+}
+
+type NDJSONEncoder[T any] struct {
+	encoder *json.Encoder
+}
+
+var _ Encoder[any] = (*NDJSONEncoder[any])(nil)
+
+// NewNDJSONEncoder writes every record as a JSON document on its own line. It does not buffer.
+func NewNDJSONEncoder[T any](w io.Writer) *NDJSONEncoder[T] {
+	return &NDJSONEncoder[T]{encoder: json.NewEncoder(w)}
+}
+
+func (e *NDJSONEncoder[T]) Encode(record T) error {
+	return e.encoder.Encode(record)
+}
+
+func (e *NDJSONEncoder[T]) Flush() error {
+	return nil
+}
@@ -0,0 +1,111 @@
+package export
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type record struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var recordColumns = []Column[record]{
+	{Name: "id", Value: func(r record) string { return strconv.Itoa(r.ID) }},
+	{Name: "name", Value: func(r record) string { return r.Name }},
+}
+
+type ExportSuite struct {
+	suite.Suite
+}
+
+func (s *ExportSuite) TestNegotiateFormat() {
+	for accept, expected := range map[string]Format{
+		"":                                FormatCSV,
+		"*/*":                             FormatCSV,
+		"text/csv":                        FormatCSV,
+		"application/x-ndjson":            FormatNDJSON,
+		"application/json, text/csv;q=.5": FormatCSV,
+		"application/ndjson, text/csv":    FormatNDJSON,
+	} {
+		format, ok := NegotiateFormat(accept)
+		s.True(ok, accept)
+		s.Equal(expected, format, accept)
+	}
+
+	_, ok := NegotiateFormat("application/json")
+	s.False(ok)
+}
+
+func (s *ExportSuite) TestAcceptsGzip() {
+	s.True(AcceptsGzip("gzip, deflate, br"))
+	s.True(AcceptsGzip("br;q=1.0, gzip;q=0.8"))
+	s.False(AcceptsGzip("gzip;q=0"))
+	s.False(AcceptsGzip("deflate"))
+}
+
+func (s *ExportSuite) TestCSVResponse() {
+	req := httptest.NewRequest(http.MethodGet, "/records/export", nil)
+	rec := httptest.NewRecorder()
+
+	resp, err := NewResponse(rec, req, "records", recordColumns)
+	s.Require().NoError(err)
+	s.False(resp.Started())
+
+	s.Require().NoError(resp.Write([]record{{ID: 1, Name: "a,b"}}))
+	s.Require().NoError(resp.Write([]record{{ID: 2, Name: "c"}}))
+	s.Require().NoError(resp.Close())
+
+	s.Equal("text/csv; charset=utf-8", rec.Header().Get("Content-Type"))
+	s.Equal(`attachment; filename="records.csv"`, rec.Header().Get("Content-Disposition"))
+	s.Equal("id,name\n1,\"a,b\"\n2,c\n", rec.Body.String())
+}
+
+func (s *ExportSuite) TestEmptyCSVResponseHasHeader() {
+	req := httptest.NewRequest(http.MethodGet, "/records/export", nil)
+	rec := httptest.NewRecorder()
+
+	resp, err := NewResponse(rec, req, "records", recordColumns)
+	s.Require().NoError(err)
+	s.Require().NoError(resp.Close())
+
+	s.Equal("id,name\n", rec.Body.String())
+}
+
+func (s *ExportSuite) TestGzipNDJSONResponse() {
+	req := httptest.NewRequest(http.MethodGet, "/records/export?format=ndjson", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	resp, err := NewResponse(rec, req, "records", recordColumns)
+	s.Require().NoError(err)
+	s.Require().NoError(resp.Write([]record{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}))
+	s.Require().NoError(resp.Close())
+
+	s.Equal("application/x-ndjson", rec.Header().Get("Content-Type"))
+	s.Equal("gzip", rec.Header().Get("Content-Encoding"))
+
+	gz, err := gzip.NewReader(rec.Body)
+	s.Require().NoError(err)
+	body, err := io.ReadAll(gz)
+	s.Require().NoError(err)
+	s.Equal("{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n", string(body))
+}
+
+func (s *ExportSuite) TestNotAcceptable() {
+	req := httptest.NewRequest(http.MethodGet, "/records/export", nil)
+	req.Header.Set("Accept", "application/xml")
+
+	_, err := NewResponse(httptest.NewRecorder(), req, "records", recordColumns)
+	s.ErrorIs(err, ErrNotAcceptable)
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
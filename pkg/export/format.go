@@ -0,0 +1,59 @@
+package export
+
+import (
+	"mime"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+func (f Format) ContentType() string {
+	if f == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+var mediaTypeFormats = map[string]Format{
+	"text/csv":             FormatCSV,
+	"text/*":               FormatCSV,
+	"*/*":                  FormatCSV,
+	"application/x-ndjson": FormatNDJSON,
+	"application/ndjson":   FormatNDJSON,
+}
+
+// NegotiateFormat picks the format of the first media range in accept that is supported, csv when accept is
+// empty. Quality values are not weighed, ranges are expected in order of preference.
+func NegotiateFormat(accept string) (Format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return FormatCSV, true
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+		if format, ok := mediaTypeFormats[mediaType]; ok {
+			return format, true
+		}
+	}
+
+	return "", false
+}
+
+// AcceptsGzip reports whether the Accept-Encoding header allows gzip.
+func AcceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
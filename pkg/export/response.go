@@ -0,0 +1,121 @@
+package export
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var ErrNotAcceptable = errors.New("export: no acceptable format")
+
+// Response streams an export as an HTTP attachment, gzip compressed when the client accepts it.
+// Nothing is sent before the first Write or Close, so errors up to then can still be answered normally.
+type Response[T any] struct {
+	w        http.ResponseWriter
+	format   Format
+	gzip     bool
+	filename string
+	columns  []Column[T]
+
+	encoder Encoder[T]
+	gz      *gzip.Writer
+}
+
+// NewResponse negotiates the format from the format query parameter, falling back to the Accept header.
+// filename is completed with the format extension.
+func NewResponse[T any](w http.ResponseWriter, r *http.Request, filename string, columns []Column[T]) (*Response[T], error) {
+	format, ok := Format(r.URL.Query().Get("format")), true
+	if format != FormatCSV && format != FormatNDJSON {
+		format, ok = NegotiateFormat(r.Header.Get("Accept"))
+	}
+	if !ok {
+		return nil, ErrNotAcceptable
+	}
+
+	return &Response[T]{
+		w:        w,
+		format:   format,
+		gzip:     AcceptsGzip(r.Header.Get("Accept-Encoding")),
+		filename: fmt.Sprintf("%s.%s", filename, format),
+		columns:  columns,
+	}, nil
+}
+
+// Write encodes records and flushes them to the client.
+func (r *Response[T]) Write(records []T) error {
+	r.start()
+
+	for _, record := range records {
+		if err := r.encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+
+	return r.flush()
+}
+
+// Started reports whether the response headers are sent, errors can't be answered normally from then on.
+func (r *Response[T]) Started() bool {
+	return r.encoder != nil
+}
+
+// Close finishes the export, it must be called once every record is written.
+func (r *Response[T]) Close() error {
+	r.start()
+
+	if err := r.encoder.Flush(); err != nil {
+		return err
+	}
+	if r.gz != nil {
+		if err := r.gz.Close(); err != nil {
+			return err
+		}
+	}
+
+	return flushResponse(r.w)
+}
+
+func (r *Response[T]) start() {
+	if r.encoder != nil {
+		return
+	}
+
+	header := r.w.Header()
+	header.Set("Content-Type", r.format.ContentType())
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, r.filename))
+	header.Add("Vary", "Accept")
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+
+	if r.gzip {
+		header.Set("Content-Encoding", "gzip")
+		r.gz = gzip.NewWriter(r.w)
+		r.encoder = NewEncoder(r.format, r.gz, r.columns)
+	} else {
+		r.encoder = NewEncoder(r.format, r.w, r.columns)
+	}
+
+	r.w.WriteHeader(http.StatusOK)
+}
+
+func (r *Response[T]) flush() error {
+	if err := r.encoder.Flush(); err != nil {
+		return err
+	}
+	if r.gz != nil {
+		if err := r.gz.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return flushResponse(r.w)
+}
+
+// flushResponse pushes buffered bytes to the client, writers that can't flush just keep buffering.
+func flushResponse(w http.ResponseWriter) error {
+	if err := http.NewResponseController(w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+// DiskSpace fails once the filesystem holding path has less than minFreeBytes available.
+func DiskSpace(name string, path string, minFreeBytes uint64) Checker {
+	return Checker{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			free, err := freeDiskBytes(path)
+			if err != nil {
+				return err
+			}
+			if free < minFreeBytes {
+				return fmt.Errorf("%d bytes free, below the minimum of %d", free, minFreeBytes)
+			}
+			return nil
+		},
+	}
+}
+
+// Pinger is implemented by clients that can cheaply check their connection, like *sql.DB and Redis stores.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+func Ping(name string, critical bool, pinger Pinger) Checker {
+	return Checker{
+		Name:     name,
+		Critical: critical,
+		Check:    pinger.PingContext,
+	}
+}
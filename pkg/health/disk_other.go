@@ -0,0 +1,9 @@
+//go:build !unix
+
+package health
+
+import "errors"
+
+func freeDiskBytes(path string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
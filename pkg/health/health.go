@@ -0,0 +1,202 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded" // a non critical check fails, the application still serves traffic
+	StatusFailing  Status = "failing"  // a critical check fails or the application is shutting down
+)
+
+// Checker checks one dependency. A failing critical checker takes the application out of rotation, a failing
+// non critical one is only reported.
+type Checker struct {
+	Name     string
+	Critical bool
+	Timeout  time.Duration // overrides the registry timeout when set
+	Check    func(ctx context.Context) error
+}
+
+type CheckResult struct {
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	// Error is left out of the JSON report, which is served without authentication, and logged instead
+	Error      string    `json:"-"`
+	DurationMs int64     `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Status       Status                 `json:"status"`
+	ShuttingDown bool                   `json:"shutting_down,omitempty"`
+	Checks       map[string]CheckResult `json:"checks"`
+}
+
+type Options struct {
+	Timeout  time.Duration // per check
+	CacheTTL time.Duration // results are reused for this long, 0 runs the checks on every call
+	Logger   *slog.Logger  // logs the errors of failing checks, nil doesn't log them
+}
+
+// Registry runs the checkers concurrently and caches their results, so that probes hitting several instances
+// every few seconds do not turn into load on the dependencies.
+type Registry struct {
+	checkers     []Checker
+	options      Options
+	shuttingDown chan struct{}
+	shutdownOnce sync.Once
+	now          func() time.Time
+
+	mu        sync.Mutex
+	report    Report
+	checkedAt time.Time
+}
+
+func NewRegistry(options Options, checkers ...Checker) *Registry {
+	return &Registry{
+		checkers:     checkers,
+		options:      options,
+		shuttingDown: make(chan struct{}),
+		now:          time.Now,
+	}
+}
+
+// SetShuttingDown makes readiness fail from now on, so that load balancers stop sending traffic while in flight
+// requests drain.
+func (r *Registry) SetShuttingDown() {
+	r.shutdownOnce.Do(func() {
+		close(r.shuttingDown)
+	})
+}
+
+func (r *Registry) ShuttingDown() bool {
+	select {
+	case <-r.shuttingDown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Done is closed by SetShuttingDown, for health reporters that push their status instead of being polled.
+func (r *Registry) Done() <-chan struct{} {
+	return r.shuttingDown
+}
+
+// Check returns the report of all checkers, from the cache when it is fresh. Concurrent callers wait for the
+// same run instead of starting their own.
+func (r *Registry) Check(ctx context.Context) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkedAt.IsZero() || r.now().Sub(r.checkedAt) >= r.options.CacheTTL {
+		// the results are shared with other callers, they must not fail because this caller went away
+		r.report = r.run(context.WithoutCancel(ctx))
+		r.checkedAt = r.now()
+	}
+
+	report := Report{
+		Status: r.report.Status,
+		Checks: r.report.Checks,
+	}
+	if r.ShuttingDown() {
+		report.Status = StatusFailing
+		report.ShuttingDown = true
+	}
+	return report
+}
+
+// Ready reports whether the application should receive traffic.
+func (r *Registry) Ready(ctx context.Context) bool {
+	if r.ShuttingDown() {
+		return false
+	}
+	return r.Check(ctx).Status != StatusFailing
+}
+
+func (r *Registry) run(ctx context.Context) Report {
+	results := make([]CheckResult, len(r.checkers))
+
+	var wg sync.WaitGroup
+	for i, checker := range r.checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.runOne(ctx, checker)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(r.checkers))}
+	for i, checker := range r.checkers {
+		result := results[i]
+		report.Checks[checker.Name] = result
+
+		if result.Status == StatusOK {
+			continue
+		}
+		if r.options.Logger != nil {
+			r.options.Logger.WarnContext(ctx, "Health check failed",
+				slog.String("check", checker.Name),
+				slog.Bool("critical", checker.Critical),
+				slog.String("error", result.Error),
+			)
+		}
+		if checker.Critical {
+			report.Status = StatusFailing
+		} else if report.Status == StatusOK {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (r *Registry) runOne(ctx context.Context, checker Checker) CheckResult {
+	timeout := checker.Timeout
+	if timeout == 0 {
+		timeout = r.options.Timeout
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := r.now()
+	err := runCheck(ctx, checker.Check)
+
+	result := CheckResult{
+		Status:     StatusOK,
+		Critical:   checker.Critical,
+		DurationMs: r.now().Sub(start).Milliseconds(),
+		CheckedAt:  start,
+	}
+	if err != nil {
+		result.Status = StatusFailing
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runCheck gives up on checks that ignore their context once it is done, the check keeps running in the
+// background and its result is dropped.
+func runCheck(ctx context.Context, check func(ctx context.Context) error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+package health
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RegistrySuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *RegistrySuite) SetupTest() {
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *RegistrySuite) newRegistry(options Options, checkers ...Checker) *Registry {
+	registry := NewRegistry(options, checkers...)
+	registry.now = func() time.Time { return s.now }
+	return registry
+}
+
+func check(name string, critical bool, err *error) Checker {
+	return Checker{
+		Name:     name,
+		Critical: critical,
+		Check: func(ctx context.Context) error {
+			return *err
+		},
+	}
+}
+
+func (s *RegistrySuite) TestStatus() {
+	var dbErr, diskErr error
+	registry := s.newRegistry(Options{}, check("database", true, &dbErr), check("disk", false, &diskErr))
+
+	report := registry.Check(context.Background())
+	s.Equal(StatusOK, report.Status)
+	s.Len(report.Checks, 2)
+	s.True(registry.Ready(context.Background()))
+
+	diskErr = errors.New("disk full")
+	report = registry.Check(context.Background())
+	s.Equal(StatusDegraded, report.Status)
+	s.Equal("disk full", report.Checks["disk"].Error)
+	s.True(registry.Ready(context.Background()))
+
+	dbErr = errors.New("connection refused")
+	report = registry.Check(context.Background())
+	s.Equal(StatusFailing, report.Status)
+	s.Equal(StatusFailing, report.Checks["database"].Status)
+	s.False(registry.Ready(context.Background()))
+}
+
+func (s *RegistrySuite) TestCache() {
+	var calls atomic.Int32
+	registry := s.newRegistry(Options{CacheTTL: 5 * time.Second}, Checker{
+		Name: "database",
+		Check: func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		},
+	})
+
+	registry.Check(context.Background())
+	s.now = s.now.Add(4 * time.Second)
+	registry.Check(context.Background())
+	s.Equal(int32(1), calls.Load())
+
+	s.now = s.now.Add(time.Second)
+	registry.Check(context.Background())
+	s.Equal(int32(2), calls.Load())
+}
+
+func (s *RegistrySuite) TestTimeout() {
+	block := make(chan struct{})
+	defer close(block)
+
+	registry := s.newRegistry(Options{Timeout: 10 * time.Millisecond}, Checker{
+		Name:     "replica",
+		Critical: true,
+		// ignores its context, the registry still gives up on it
+		Check: func(ctx context.Context) error {
+			<-block
+			return nil
+		},
+	})
+
+	report := registry.Check(context.Background())
+	s.Equal(StatusFailing, report.Status)
+	s.Equal(context.DeadlineExceeded.Error(), report.Checks["replica"].Error)
+}
+
+func (s *RegistrySuite) TestCanceledCaller() {
+	var err error
+	registry := s.newRegistry(Options{CacheTTL: time.Minute}, check("database", true, &err))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the cached report must not carry the cancelation of the caller that ran the checks
+	s.Equal(StatusOK, registry.Check(ctx).Status)
+}
+
+func (s *RegistrySuite) TestShuttingDown() {
+	var err error
+	registry := s.newRegistry(Options{}, check("database", true, &err))
+	s.True(registry.Ready(context.Background()))
+
+	registry.SetShuttingDown()
+
+	s.False(registry.Ready(context.Background()))
+	report := registry.Check(context.Background())
+	s.Equal(StatusFailing, report.Status)
+	s.True(report.ShuttingDown)
+	s.Equal(StatusOK, report.Checks["database"].Status)
+}
+
+func (s *RegistrySuite) TestErrorsAreLoggedNotReported() {
+	var logs bytes.Buffer
+	dbErr := errors.New("dial tcp 10.0.3.7:5432: connection refused")
+	registry := s.newRegistry(Options{Logger: slog.New(slog.NewTextHandler(&logs, nil))}, check("database", true, &dbErr))
+
+	report := registry.Check(context.Background())
+
+	content, err := json.Marshal(report)
+	s.Require().NoError(err)
+	s.NotContains(string(content), "10.0.3.7")
+	s.Contains(string(content), `"database":{"status":"failing","critical":true`)
+	s.Contains(logs.String(), "check=database")
+	s.Contains(logs.String(), "10.0.3.7:5432")
+}
+
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
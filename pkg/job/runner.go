@@ -0,0 +1,123 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/umefy/godash/logger"
+)
+
+var ErrRunnerClosed = errors.New("job runner is shut down")
+
+type Func func(ctx context.Context) error
+
+// Runner runs background jobs in goroutines and keeps track of them so that they can be
+// waited for on shutdown.
+type Runner struct {
+	logger   *slog.Logger
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.RWMutex
+	closed   bool
+	inFlight atomic.Int64
+}
+
+func NewRunner(logger *logger.Logger) *Runner {
+	loggerHandler := logger.GetHandler()
+	loggerHandler.CallerSkip = 3
+	slogger := slog.New(&loggerHandler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runner{
+		logger: slogger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Submit runs fn in the background. The job context keeps the values of ctx (request id, trace span)
+// but not its cancellation, so jobs outlive the request that submitted them. It is cancelled when the
+// runner is forced to stop.
+func (r *Runner) Submit(ctx context.Context, name string, fn Func) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return ErrRunnerClosed
+	}
+
+	jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(r.ctx, cancel)
+
+	r.wg.Add(1)
+	r.inFlight.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer r.inFlight.Add(-1)
+		defer stop()
+		defer cancel()
+
+		start := time.Now()
+		err := r.run(jobCtx, fn)
+		if err != nil {
+			r.logger.ErrorContext(jobCtx, "Job failed",
+				slog.String("job", name),
+				slog.String("error", err.Error()),
+				slog.String("latency", time.Since(start).String()),
+			)
+			return
+		}
+		r.logger.InfoContext(jobCtx, "Job done",
+			slog.String("job", name),
+			slog.String("latency", time.Since(start).String()),
+		)
+	}()
+
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context, fn Func) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("job panic: %v", rec)
+		}
+	}()
+	return fn(ctx)
+}
+
+// InFlight returns the number of jobs currently running.
+func (r *Runner) InFlight() int {
+	return int(r.inFlight.Load())
+}
+
+// Shutdown stops accepting new jobs and waits for the running ones to finish.
+// If ctx is done first, the remaining jobs are cancelled and ctx.Err() is returned.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	r.logger.Info("Waiting for background jobs", slog.Int("in_flight", r.InFlight()))
+
+	select {
+	case <-done:
+		r.cancel()
+		return nil
+	case <-ctx.Done():
+		r.cancel()
+		r.logger.Error("Background jobs cancelled", slog.Int("in_flight", r.InFlight()))
+		return ctx.Err()
+	}
+}
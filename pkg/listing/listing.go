@@ -0,0 +1,57 @@
+// Package listing implements a small filter and sort language for list endpoints, e.g.
+// ?filter[age][gte]=18&sort=-createdAt. Clients can only use the fields a Schema whitelists.
+package listing
+
+type Operator string
+
+const (
+	OperatorEq       Operator = "eq"
+	OperatorNeq      Operator = "neq"
+	OperatorGt       Operator = "gt"
+	OperatorGte      Operator = "gte"
+	OperatorLt       Operator = "lt"
+	OperatorLte      Operator = "lte"
+	OperatorIn       Operator = "in"
+	OperatorContains Operator = "contains"
+)
+
+var (
+	// ComparableOperators suit numbers
+	ComparableOperators = []Operator{OperatorEq, OperatorNeq, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorIn}
+	// RangeOperators suit timestamps, where equality is rarely meaningful
+	RangeOperators = []Operator{OperatorGt, OperatorGte, OperatorLt, OperatorLte}
+)
+
+// RawCondition is a filter condition as sent by a client, before it is checked against a Schema.
+type RawCondition struct {
+	Field    string
+	Operator Operator
+	// Values has one value, except for the in operator
+	Values []string
+}
+
+// RawQuery is a filter and sort spec as sent by a client. Sort is a comma separated list of
+// fields, descending ones are prefixed with "-".
+type RawQuery struct {
+	Filter []RawCondition
+	Sort   string
+}
+
+// Condition is a checked filter condition. Value has the Go type of the field, or is a []any
+// of those for the in operator.
+type Condition struct {
+	Field    string
+	Operator Operator
+	Value    any
+}
+
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Query is a checked filter and sort spec, ready to be translated by a repository.
+type Query struct {
+	Filter []Condition
+	Sort   []SortKey
+}
@@ -0,0 +1,94 @@
+package listing
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var testSchema = Schema{
+	Fields: map[string]Field{
+		"age": {
+			Type:      FieldTypeInt,
+			Operators: []Operator{OperatorEq, OperatorGte, OperatorIn},
+			Sortable:  true,
+		},
+		"email": {
+			Type:      FieldTypeString,
+			Operators: []Operator{OperatorContains},
+		},
+		"createdAt": {
+			Type:      FieldTypeTime,
+			Operators: []Operator{OperatorLt},
+			Sortable:  true,
+		},
+	},
+	DefaultSort: []SortKey{{Field: "age"}},
+}
+
+type ListingSuite struct {
+	suite.Suite
+}
+
+func (s *ListingSuite) TestParse() {
+	values, err := url.ParseQuery("filter[age][gte]=18&filter[age][in]=20,30&filter[email][contains]=doe&filter[createdAt][lt]=2025-01-01T00:00:00Z&sort=-createdAt,age&offset=10")
+	s.Require().NoError(err)
+
+	raw, err := ParseURLValues(values)
+	s.Require().NoError(err)
+
+	query, err := testSchema.Parse(raw)
+	s.Require().NoError(err)
+
+	s.Equal([]Condition{
+		{Field: "age", Operator: OperatorGte, Value: 18},
+		{Field: "age", Operator: OperatorIn, Value: []any{20, 30}},
+		{Field: "createdAt", Operator: OperatorLt, Value: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Field: "email", Operator: OperatorContains, Value: "doe"},
+	}, query.Filter)
+	s.Equal([]SortKey{{Field: "createdAt", Desc: true}, {Field: "age"}}, query.Sort)
+}
+
+func (s *ListingSuite) TestParseDefaults() {
+	raw, err := ParseURLValues(url.Values{"filter[age]": {"18"}})
+	s.Require().NoError(err)
+
+	query, err := testSchema.Parse(raw)
+	s.Require().NoError(err)
+
+	s.Equal([]Condition{{Field: "age", Operator: OperatorEq, Value: 18}}, query.Filter)
+	s.Equal(testSchema.DefaultSort, query.Sort)
+}
+
+func (s *ListingSuite) TestParseReportsEveryError() {
+	raw, err := ParseURLValues(url.Values{"filter[age": {"1"}})
+	s.assertValidationErrorKeys(err, "filter[age")
+	s.Empty(raw.Filter)
+
+	values, err := url.ParseQuery("filter[password]=x&filter[email][eq]=x&filter[age][gte]=old&sort=email")
+	s.Require().NoError(err)
+
+	raw, err = ParseURLValues(values)
+	s.Require().NoError(err)
+
+	_, err = testSchema.Parse(raw)
+	s.assertValidationErrorKeys(err, "filter[password][eq]", "filter[email][eq]", "filter[age][gte]", "sort")
+}
+
+func (s *ListingSuite) assertValidationErrorKeys(err error, keys ...string) {
+	var validateErr *validation.ValidateStructError
+	s.Require().True(errors.As(err, &validateErr), err)
+
+	s.Len(validateErr.Errors, len(keys))
+	for _, key := range keys {
+		s.Contains(validateErr.Errors, key)
+	}
+}
+
+func TestListingSuite(t *testing.T) {
+	suite.Run(t, new(ListingSuite))
+}
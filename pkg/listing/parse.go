@@ -0,0 +1,56 @@
+package listing
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+var filterParamPattern = regexp.MustCompile(`^filter\[([A-Za-z0-9_]+)\](?:\[([a-z]+)\])?$`)
+
+// ParseURLValues reads filter[field][operator]=value and sort params. The operator defaults to
+// eq and the values of the in operator are comma separated.
+func ParseURLValues(values url.Values) (RawQuery, error) {
+	var raw RawQuery
+	errs := validation.Errors{}
+
+	// map iteration order is random, keep conditions stable
+	params := make([]string, 0, len(values))
+	for param := range values {
+		params = append(params, param)
+	}
+	slices.Sort(params)
+
+	for _, param := range params {
+		if !strings.HasPrefix(param, "filter") {
+			continue
+		}
+
+		match := filterParamPattern.FindStringSubmatch(param)
+		if match == nil {
+			errs[param] = errors.New("must be in the form filter[field] or filter[field][operator]")
+			continue
+		}
+
+		operator := Operator(match[2])
+		if operator == "" {
+			operator = OperatorEq
+		}
+
+		for _, value := range values[param] {
+			condition := RawCondition{Field: match[1], Operator: operator, Values: []string{value}}
+			if operator == OperatorIn {
+				condition.Values = strings.Split(value, ",")
+			}
+			raw.Filter = append(raw.Filter, condition)
+		}
+	}
+
+	raw.Sort = values.Get("sort")
+
+	return raw, validation.NewValidateStructError(errs)
+}
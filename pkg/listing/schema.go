@@ -0,0 +1,159 @@
+package listing
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/umefy/go-web-app-template/pkg/validation"
+)
+
+type FieldType int
+
+const (
+	FieldTypeString FieldType = iota
+	FieldTypeInt
+	FieldTypeTime
+)
+
+// Field whitelists a field for filtering with Operators and, if Sortable, for sorting.
+type Field struct {
+	Type      FieldType
+	Operators []Operator
+	Sortable  bool
+}
+
+// Schema lists the fields of an entity clients can filter and sort on.
+type Schema struct {
+	Fields map[string]Field
+	// DefaultSort is used when the client doesn't ask for a sort
+	DefaultSort []SortKey
+}
+
+// Parse checks raw against the schema and converts the values to the field types. All problems
+// are reported at once as a validation error keyed by param, e.g. filter[age][gte].
+func (s Schema) Parse(raw RawQuery) (Query, error) {
+	var query Query
+	errs := validation.Errors{}
+
+	for _, rawCondition := range raw.Filter {
+		key := fmt.Sprintf("filter[%s][%s]", rawCondition.Field, rawCondition.Operator)
+
+		condition, err := s.parseCondition(rawCondition)
+		if err != nil {
+			errs[key] = err
+			continue
+		}
+		query.Filter = append(query.Filter, condition)
+	}
+
+	sortKeys, err := s.parseSort(raw.Sort)
+	if err != nil {
+		errs["sort"] = err
+	}
+	query.Sort = sortKeys
+
+	if err := validation.NewValidateStructError(errs); err != nil {
+		return Query{}, err
+	}
+	return query, nil
+}
+
+func (s Schema) parseCondition(raw RawCondition) (Condition, error) {
+	field, ok := s.Fields[raw.Field]
+	if !ok {
+		return Condition{}, validation.NewError("validation_unknown_field", fmt.Sprintf("unknown field, must be one of: %s", s.fieldNames(false)))
+	}
+
+	operators := make([]any, len(field.Operators))
+	for i, operator := range field.Operators {
+		operators[i] = operator
+	}
+	if err := validation.ValidateByRules(raw.Operator, validation.In(operators...).Error(fmt.Sprintf("unsupported operator, must be one of: %s", joinOperators(field.Operators)))); err != nil {
+		return Condition{}, err
+	}
+
+	if len(raw.Values) == 0 || (raw.Operator != OperatorIn && len(raw.Values) > 1) {
+		return Condition{}, errors.New("must have a single value")
+	}
+
+	values := make([]any, len(raw.Values))
+	for i, rawValue := range raw.Values {
+		value, err := field.Type.parse(rawValue)
+		if err != nil {
+			return Condition{}, err
+		}
+		values[i] = value
+	}
+
+	condition := Condition{Field: raw.Field, Operator: raw.Operator, Value: values[0]}
+	if raw.Operator == OperatorIn {
+		condition.Value = values
+	}
+	return condition, nil
+}
+
+func (s Schema) parseSort(raw string) ([]SortKey, error) {
+	if strings.TrimSpace(raw) == "" {
+		return s.DefaultSort, nil
+	}
+
+	var sortKeys []SortKey
+	seen := map[string]bool{}
+	for rawKey := range strings.SplitSeq(raw, ",") {
+		rawKey = strings.TrimSpace(rawKey)
+		sortKey := SortKey{Field: strings.TrimPrefix(rawKey, "-"), Desc: strings.HasPrefix(rawKey, "-")}
+
+		if field, ok := s.Fields[sortKey.Field]; !ok || !field.Sortable {
+			return nil, validation.NewError("validation_unknown_sort_field", fmt.Sprintf("unknown sort field %q, must be one of: %s", sortKey.Field, s.fieldNames(true)))
+		}
+		if seen[sortKey.Field] {
+			return nil, fmt.Errorf("field %q is sorted on twice", sortKey.Field)
+		}
+		seen[sortKey.Field] = true
+
+		sortKeys = append(sortKeys, sortKey)
+	}
+	return sortKeys, nil
+}
+
+func (s Schema) fieldNames(sortable bool) string {
+	var names []string
+	for name, field := range s.Fields {
+		if !sortable || field.Sortable {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return strings.Join(names, ", ")
+}
+
+func (t FieldType) parse(value string) (any, error) {
+	switch t {
+	case FieldTypeInt:
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+		return v, nil
+	case FieldTypeTime:
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, errors.New("must be a RFC 3339 date time")
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+func joinOperators(operators []Operator) string {
+	names := make([]string, len(operators))
+	for i, operator := range operators {
+		names[i] = string(operator)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,133 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+)
+
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+// Cursor points at a row of a keyset ordering. It holds the sort keys it was built for and the
+// values of those keys for the row, so that the next page can be fetched with a seek condition
+// instead of an offset.
+type Cursor struct {
+	Keys   []string          `json:"k"`
+	Values []json.RawMessage `json:"v"`
+}
+
+func NewCursor(keys []string, values ...any) (Cursor, error) {
+	if len(keys) != len(values) {
+		return Cursor{}, errors.New("pagination: cursor keys and values mismatch")
+	}
+
+	cursor := Cursor{Keys: keys, Values: make([]json.RawMessage, len(values))}
+	for i, value := range values {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return Cursor{}, err
+		}
+		cursor.Values[i] = raw
+	}
+	return cursor, nil
+}
+
+// Matches reports whether the cursor was built for the given sort keys.
+func (c Cursor) Matches(keys []string) bool {
+	return slices.Equal(c.Keys, keys)
+}
+
+// Scan decodes the cursor values into dest, in the order of the sort keys.
+func (c Cursor) Scan(dest ...any) error {
+	if len(dest) != len(c.Values) {
+		return ErrInvalidCursor
+	}
+	for i, raw := range c.Values {
+		if err := json.Unmarshal(raw, dest[i]); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	return nil
+}
+
+// CursorCodec turns cursors into opaque tokens signed with HMAC-SHA256, so that clients can't
+// forge cursors to seek on values they were never given.
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret []byte) *CursorCodec {
+	return &CursorCodec{secret: secret}
+}
+
+func (c *CursorCodec) Encode(cursor Cursor) (string, error) {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+func (c *CursorCodec) Decode(token string) (Cursor, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if !hmac.Equal(signature, c.sign(payload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || len(cursor.Keys) != len(cursor.Values) {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// Keyset decodes the cursors of p.
+func (c *CursorCodec) Keyset(p CursorPagination) (Keyset, error) {
+	keyset := Keyset{PageSize: p.PageSize, IncludeTotal: p.IncludeTotal}
+
+	if p.After != "" && p.Before != "" {
+		return Keyset{}, ErrInvalidCursor
+	}
+
+	if p.After != "" {
+		after, err := c.Decode(p.After)
+		if err != nil {
+			return Keyset{}, err
+		}
+		keyset.After = &after
+	}
+
+	if p.Before != "" {
+		before, err := c.Decode(p.Before)
+		if err != nil {
+			return Keyset{}, err
+		}
+		keyset.Before = &before
+	}
+
+	return keyset, nil
+}
+
+func (c *CursorCodec) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,155 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CursorPagination is the cursor counterpart of Pagination, After and Before are opaque tokens
+// from CursorCodec. Only one of them can be set.
+type CursorPagination struct {
+	PageSize     int
+	After        string
+	Before       string
+	IncludeTotal bool
+}
+
+func NewCursorPagination(pageSize int, after, before string, includeTotal bool) CursorPagination {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return CursorPagination{
+		PageSize:     pageSize,
+		After:        after,
+		Before:       before,
+		IncludeTotal: includeTotal,
+	}
+}
+
+func NewCursorPaginationFromQueryParams(pageSize, after, before, includeTotal string) CursorPagination {
+	pageSizeInt, err := strconv.Atoi(pageSize)
+	if err != nil {
+		pageSizeInt = DefaultPageSize
+	}
+
+	return NewCursorPagination(pageSizeInt, after, before, includeTotal == "true")
+}
+
+// Keyset is a CursorPagination with decoded cursors, as used by the repositories.
+type Keyset struct {
+	PageSize     int
+	After        *Cursor
+	Before       *Cursor
+	IncludeTotal bool
+}
+
+// KeysetResult is a page fetched with a Keyset, Cursors[i] points at Items[i].
+type KeysetResult[T any] struct {
+	Items       []T
+	Cursors     []Cursor
+	HasNext     bool
+	HasPrevious bool
+	Total       *int64
+}
+
+type Edge[T any] struct {
+	Node   T
+	Cursor string
+}
+
+// CursorPageInfo follows the Relay PageInfo shape.
+type CursorPageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
+	// The total number of items
+	Total *int64
+}
+
+type Connection[T any] struct {
+	Edges    []Edge[T]
+	PageInfo CursorPageInfo
+}
+
+// NewConnection encodes the cursors of result.
+func NewConnection[T any](codec *CursorCodec, result *KeysetResult[T]) (*Connection[T], error) {
+	connection := &Connection[T]{
+		Edges: make([]Edge[T], len(result.Items)),
+		PageInfo: CursorPageInfo{
+			HasNextPage:     result.HasNext,
+			HasPreviousPage: result.HasPrevious,
+			Total:           result.Total,
+		},
+	}
+
+	for i, item := range result.Items {
+		cursor, err := codec.Encode(result.Cursors[i])
+		if err != nil {
+			return nil, err
+		}
+		connection.Edges[i] = Edge[T]{Node: item, Cursor: cursor}
+	}
+
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection, nil
+}
+
+// Nodes returns the nodes of the connection in order.
+func (c *Connection[T]) Nodes() []T {
+	nodes := make([]T, len(c.Edges))
+	for i, edge := range c.Edges {
+		nodes[i] = edge.Node
+	}
+	return nodes
+}
+
+// LinkHeader renders RFC 8288 next/prev links for u, keeping its other query params.
+func LinkHeader(u *url.URL, pageSize int, pageInfo CursorPageInfo) string {
+	var links []string
+
+	link := func(param, cursor, rel string) string {
+		query := u.Query()
+		query.Del("after")
+		query.Del("before")
+		query.Del("offset")
+		query.Set(param, cursor)
+		query.Set("pageSize", strconv.Itoa(pageSize))
+
+		linkURL := *u
+		linkURL.RawQuery = query.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, linkURL.RequestURI(), rel)
+	}
+
+	if pageInfo.HasNextPage && pageInfo.EndCursor != nil {
+		links = append(links, link("after", *pageInfo.EndCursor, "next"))
+	}
+	if pageInfo.HasPreviousPage && pageInfo.StartCursor != nil {
+		links = append(links, link("before", *pageInfo.StartCursor, "prev"))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// MapKeysetResult converts the items of r with mapFunc, keeping their cursors.
+func MapKeysetResult[T any, U any](r *KeysetResult[T], mapFunc func(T) U) *KeysetResult[U] {
+	items := make([]U, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = mapFunc(item)
+	}
+
+	return &KeysetResult[U]{
+		Items:       items,
+		Cursors:     r.Cursors,
+		HasNext:     r.HasNext,
+		HasPrevious: r.HasPrevious,
+		Total:       r.Total,
+	}
+}
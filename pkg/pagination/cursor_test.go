@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CursorSuite struct {
+	suite.Suite
+	codec *CursorCodec
+}
+
+func (s *CursorSuite) SetupTest() {
+	s.codec = NewCursorCodec([]byte("test-cursor-secret-0123456789abcdef"))
+}
+
+func (s *CursorSuite) TestEncodeDecode() {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	cursor, err := NewCursor([]string{"-createdAt", "id"}, createdAt, 42)
+	s.Require().NoError(err)
+
+	token, err := s.codec.Encode(cursor)
+	s.Require().NoError(err)
+
+	decoded, err := s.codec.Decode(token)
+	s.Require().NoError(err)
+	s.True(decoded.Matches([]string{"-createdAt", "id"}))
+	s.False(decoded.Matches([]string{"id"}))
+
+	var gotCreatedAt time.Time
+	var gotID int
+	s.Require().NoError(decoded.Scan(&gotCreatedAt, &gotID))
+	s.True(createdAt.Equal(gotCreatedAt))
+	s.Equal(42, gotID)
+}
+
+func (s *CursorSuite) TestDecodeRejectsTamperedCursors() {
+	cursor, err := NewCursor([]string{"id"}, 1)
+	s.Require().NoError(err)
+	token, err := s.codec.Encode(cursor)
+	s.Require().NoError(err)
+
+	forged, err := NewCursor([]string{"id"}, 1000)
+	s.Require().NoError(err)
+	forgedToken, err := s.codec.Encode(forged)
+	s.Require().NoError(err)
+
+	payload, _, _ := strings.Cut(forgedToken, ".")
+	_, signature, _ := strings.Cut(token, ".")
+
+	otherCodec := NewCursorCodec([]byte("another-cursor-secret-0123456789ab"))
+	otherToken, err := otherCodec.Encode(cursor)
+	s.Require().NoError(err)
+
+	for _, token := range []string{"", "garbage", payload + "." + signature, otherToken} {
+		_, err := s.codec.Decode(token)
+		s.ErrorIs(err, ErrInvalidCursor, token)
+	}
+}
+
+func (s *CursorSuite) TestKeysetRejectsAfterAndBefore() {
+	cursor, err := NewCursor([]string{"id"}, 1)
+	s.Require().NoError(err)
+	token, err := s.codec.Encode(cursor)
+	s.Require().NoError(err)
+
+	_, err = s.codec.Keyset(NewCursorPagination(10, token, token, false))
+	s.ErrorIs(err, ErrInvalidCursor)
+
+	keyset, err := s.codec.Keyset(NewCursorPagination(0, token, "", false))
+	s.Require().NoError(err)
+	s.Equal(DefaultPageSize, keyset.PageSize)
+	s.NotNil(keyset.After)
+	s.Nil(keyset.Before)
+}
+
+func (s *CursorSuite) TestLinkHeader() {
+	u, err := url.Parse("/api/v1/users?after=&pageSize=2&includeTotal=true")
+	s.Require().NoError(err)
+
+	start, end := "start", "end"
+	link := LinkHeader(u, 2, CursorPageInfo{HasNextPage: true, HasPreviousPage: true, StartCursor: &start, EndCursor: &end})
+
+	s.Equal(`</api/v1/users?after=end&includeTotal=true&pageSize=2>; rel="next", </api/v1/users?before=start&includeTotal=true&pageSize=2>; rel="prev"`, link)
+	s.Empty(LinkHeader(u, 2, CursorPageInfo{}))
+}
+
+func TestCursorSuite(t *testing.T) {
+	suite.Run(t, new(CursorSuite))
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+// MemoryStore keeps the counters in process memory, each instance of the application counts on its own.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counters  map[string]*counter
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type counter struct {
+	start    time.Time
+	window   time.Duration
+	previous int
+	current  int
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counters: map[string]*counter{},
+		now:      time.Now,
+	}
+}
+
+func (s *MemoryStore) Hit(_ context.Context, key string, limit int, window time.Duration) (Result, error) {
+	now := s.now()
+	start, elapsed := windowOf(now, window)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	c, ok := s.counters[key]
+	if !ok {
+		c = &counter{start: start, window: window}
+		s.counters[key] = c
+	}
+	c.advance(start)
+
+	count := slidingCount(c.previous, c.current, window, elapsed)
+	if count >= limit {
+		return newResult(false, count, limit, window, elapsed), nil
+	}
+
+	c.current++
+	return newResult(true, count+1, limit, window, elapsed), nil
+}
+
+// advance moves the counter to the fixed window starting at start.
+func (c *counter) advance(start time.Time) {
+	switch {
+	case c.start.Equal(start):
+		return
+	case c.start.Add(c.window).Equal(start):
+		c.previous = c.current
+	default:
+		c.previous = 0
+	}
+	c.current = 0
+	c.start = start
+}
+
+// sweep drops the counters whose windows ended long enough ago to not count anymore.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, c := range s.counters {
+		if now.Sub(c.start) >= 2*c.window {
+			delete(s.counters, key)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Store counts hits per key in sliding windows. The windows are approximated with the counts of the current
+// and previous fixed windows, the previous one weighted by how much of it the sliding window still covers.
+type Store interface {
+	// Hit records a hit for key, unless key already reached limit within window.
+	Hit(ctx context.Context, key string, limit int, window time.Duration) (Result, error)
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	Window    time.Duration
+	// Reset is the time left in the current fixed window, after which the count starts to go down
+	Reset time.Duration
+}
+
+// Headers returns the RateLimit-Policy, RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers of
+// the IETF RateLimit header fields draft, and Retry-After when the hit was refused.
+func (r Result) Headers() map[string]string {
+	reset := strconv.Itoa(int(math.Ceil(r.Reset.Seconds())))
+	headers := map[string]string{
+		"RateLimit-Policy":    strconv.Itoa(r.Limit) + ";w=" + strconv.Itoa(int(r.Window.Seconds())),
+		"RateLimit-Limit":     strconv.Itoa(r.Limit),
+		"RateLimit-Remaining": strconv.Itoa(r.Remaining),
+		"RateLimit-Reset":     reset,
+	}
+	if !r.Allowed {
+		headers["Retry-After"] = reset
+	}
+	return headers
+}
+
+// SetHeaders adds the headers of result to header, see Result.Headers.
+func SetHeaders(header http.Header, result Result) {
+	for key, value := range result.Headers() {
+		header.Set(key, value)
+	}
+}
+
+// windowOf returns the start of the fixed window of now and the time elapsed in it.
+func windowOf(now time.Time, window time.Duration) (time.Time, time.Duration) {
+	start := now.Truncate(window)
+	return start, now.Sub(start)
+}
+
+// slidingCount estimates the hits of the sliding window ending elapsed into the current fixed window.
+func slidingCount(previous, current int, window, elapsed time.Duration) int {
+	return int(float64(previous)*float64(window-elapsed)/float64(window)) + current
+}
+
+func newResult(allowed bool, count, limit int, window, elapsed time.Duration) Result {
+	return Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: max(limit-count, 0),
+		Window:    window,
+		Reset:     window - elapsed,
+	}
+}
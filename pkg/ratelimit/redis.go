@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// hitScript counts a hit in KEYS[1], the current fixed window, unless the estimate with KEYS[2], the
+// previous fixed window, reached the limit. It returns whether the hit was counted and the estimate.
+var hitScript = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local elapsed = tonumber(ARGV[3])
+
+local count = math.floor(previous * (window - elapsed) / window) + current
+if count >= limit then
+	return {0, count}
+end
+
+redis.call("INCR", KEYS[1])
+redis.call("PEXPIRE", KEYS[1], window * 2)
+return {1, count + 1}
+`)
+
+// RedisStore keeps the counters in Redis, so that all instances of the application share them. Windows
+// follow the clock of the application, instances are expected to have synchronized clocks.
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+	now    func() time.Time
+}
+
+var _ Store = (*RedisStore)(nil)
+
+// NewRedisStore stores the counters under keys starting with prefix.
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: prefix,
+		now:    time.Now,
+	}
+}
+
+func (s *RedisStore) Hit(ctx context.Context, key string, limit int, window time.Duration) (Result, error) {
+	start, elapsed := windowOf(s.now(), window)
+
+	// the hash tag keeps both windows of a key in the same Redis Cluster slot
+	keys := []string{
+		s.prefix + "{" + key + "}:" + strconv.FormatInt(start.UnixMilli(), 10),
+		s.prefix + "{" + key + "}:" + strconv.FormatInt(start.Add(-window).UnixMilli(), 10),
+	}
+
+	reply, err := hitScript.Run(ctx, s.client, keys, limit, window.Milliseconds(), elapsed.Milliseconds()).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(reply[0] == 1, int(reply[1]), limit, window, elapsed), nil
+}
+
+// PingContext checks the connection to Redis.
+func (s *RedisStore) PingContext(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type StoreSuite struct {
+	suite.Suite
+	now      time.Time
+	newStore func(s *StoreSuite) Store
+	store    Store
+}
+
+func (s *StoreSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.store = s.newStore(s)
+}
+
+func (s *StoreSuite) clock() time.Time {
+	return s.now
+}
+
+func (s *StoreSuite) hit(key string) Result {
+	result, err := s.store.Hit(context.Background(), key, 3, time.Minute)
+	s.Require().NoError(err)
+	return result
+}
+
+func (s *StoreSuite) TestLimit() {
+	for remaining := 2; remaining >= 0; remaining-- {
+		result := s.hit("client")
+		s.True(result.Allowed)
+		s.Equal(remaining, result.Remaining)
+	}
+
+	result := s.hit("client")
+	s.False(result.Allowed)
+	s.Equal(0, result.Remaining)
+	s.Equal(time.Minute, result.Reset)
+
+	// keys are counted apart
+	s.True(s.hit("other").Allowed)
+}
+
+func (s *StoreSuite) TestSlidingWindow() {
+	for range 3 {
+		s.Require().True(s.hit("client").Allowed)
+	}
+
+	// a third into the next window, two thirds of the previous hits still count
+	s.now = s.now.Add(time.Minute + 20*time.Second)
+	result := s.hit("client")
+	s.True(result.Allowed)
+	s.Equal(0, result.Remaining)
+	s.Equal(40*time.Second, result.Reset)
+	s.False(s.hit("client").Allowed)
+
+	// two windows later the previous hits are gone
+	s.now = s.now.Add(2 * time.Minute)
+	s.Equal(2, s.hit("client").Remaining)
+}
+
+func (s *StoreSuite) TestHeaders() {
+	header := http.Header{}
+	SetHeaders(header, Result{Allowed: false, Limit: 3, Window: time.Minute, Reset: 1500 * time.Millisecond})
+
+	s.Equal("3;w=60", header.Get("RateLimit-Policy"))
+	s.Equal("3", header.Get("RateLimit-Limit"))
+	s.Equal("0", header.Get("RateLimit-Remaining"))
+	s.Equal("2", header.Get("RateLimit-Reset"))
+	s.Equal("2", header.Get("Retry-After"))
+}
+
+func TestMemoryStoreSuite(t *testing.T) {
+	suite.Run(t, &StoreSuite{newStore: func(s *StoreSuite) Store {
+		store := NewMemoryStore()
+		store.now = s.clock
+		return store
+	}})
+}
+
+func TestRedisStoreSuite(t *testing.T) {
+	suite.Run(t, &StoreSuite{newStore: func(s *StoreSuite) Store {
+		server := miniredis.RunT(s.T())
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		s.T().Cleanup(func() { _ = client.Close() })
+
+		store := NewRedisStore(client, "ratelimit:")
+		store.now = s.clock
+		return store
+	}})
+}
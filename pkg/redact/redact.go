@@ -0,0 +1,188 @@
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// TagKey and TagValue mark the struct fields redacted whatever their name, e.g. `log:"redact"`.
+	TagKey   = "log"
+	TagValue = "redact"
+
+	Mask = "[REDACTED]"
+)
+
+type Options struct {
+	// Keys are the attribute keys, struct fields and SQL columns to redact. They are matched ignoring case, "_"
+	// and "-", and can contain * wildcards, e.g. "*token*" matches access_token and X-Refresh-Token.
+	Keys []string
+	// Hash replaces the values by a hash instead of Mask, equal values keep equal hashes so that they can still
+	// be followed across logs. The hash is keyed with HashKey, without it common values are easy to guess.
+	Hash    bool
+	HashKey string
+}
+
+// Redactor replaces sensitive values before they are logged.
+type Redactor struct {
+	patterns []string
+	hash     bool
+	hashKey  []byte
+	// tagged caches whether struct types have fields tagged with `log:"redact"`
+	tagged sync.Map
+}
+
+func New(options Options) *Redactor {
+	patterns := make([]string, len(options.Keys))
+	for i, key := range options.Keys {
+		patterns[i] = normalize(key)
+	}
+
+	return &Redactor{
+		patterns: patterns,
+		hash:     options.Hash,
+		hashKey:  []byte(options.HashKey),
+	}
+}
+
+// ValidPattern returns an error when key is not a valid pattern for Options.Keys.
+func ValidPattern(key string) error {
+	_, err := path.Match(normalize(key), "")
+	return err
+}
+
+// Match reports whether values under key are redacted.
+func (r *Redactor) Match(key string) bool {
+	key = normalize(key)
+	for _, pattern := range r.patterns {
+		if matched, _ := path.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Value returns Mask, or the hash of value.
+func (r *Redactor) Value(value string) string {
+	if !r.hash {
+		return Mask
+	}
+
+	mac := hmac.New(sha256.New, r.hashKey)
+	mac.Write([]byte(value))
+	return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+// Attr redacts attr when its key matches, and the matching attributes nested in it. Structs, and pointers to
+// structs, with fields tagged `log:"redact"` become groups of their exported fields, with the tagged ones and
+// the matching ones redacted.
+func (r *Redactor) Attr(attr slog.Attr) slog.Attr {
+	value := attr.Value.Resolve()
+
+	if r.Match(attr.Key) {
+		return slog.String(attr.Key, r.Value(format(value)))
+	}
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, nested := range group {
+			attrs[i] = r.Attr(nested)
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(attrs...)}
+	case slog.KindAny:
+		if group, ok := r.structGroup(value.Any()); ok {
+			return slog.Attr{Key: attr.Key, Value: group}
+		}
+	}
+
+	return slog.Attr{Key: attr.Key, Value: value}
+}
+
+func (r *Redactor) structGroup(value any) (slog.Value, bool) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return slog.Value{}, false
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || !r.hasTaggedFields(rv.Type()) {
+		return slog.Value{}, false
+	}
+
+	attrs := make([]slog.Attr, 0, rv.NumField())
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := fieldName(field)
+		if name == "" {
+			continue
+		}
+		if field.Tag.Get(TagKey) == TagValue {
+			attrs = append(attrs, slog.String(name, r.Value(format(slog.AnyValue(rv.Field(i).Interface())))))
+			continue
+		}
+		attrs = append(attrs, r.Attr(slog.Any(name, rv.Field(i).Interface())))
+	}
+	return slog.GroupValue(attrs...), true
+}
+
+func (r *Redactor) hasTaggedFields(t reflect.Type) bool {
+	if tagged, ok := r.tagged.Load(t); ok {
+		return tagged.(bool)
+	}
+
+	tagged := false
+	for i := range t.NumField() {
+		if t.Field(i).Tag.Get(TagKey) == TagValue {
+			tagged = true
+			break
+		}
+	}
+	r.tagged.Store(t, tagged)
+	return tagged
+}
+
+// fieldName follows the json tag, the name the field has when the struct is logged as JSON.
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+func format(value slog.Value) string {
+	if value.Kind() != slog.KindAny {
+		return value.String()
+	}
+
+	v := value.Any()
+	if valuer, ok := v.(driver.Valuer); ok {
+		if resolved, err := valuer.Value(); err == nil {
+			v = resolved
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func normalize(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
@@ -0,0 +1,170 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type account struct {
+	ID       int
+	Email    string `json:"email" log:"redact"`
+	Nickname string
+	Token    string
+	internal string
+}
+
+type RedactSuite struct {
+	suite.Suite
+	redactor *Redactor
+}
+
+func (s *RedactSuite) SetupTest() {
+	s.redactor = New(Options{Keys: []string{"email", "password", "*token*", "authorization"}})
+}
+
+// log returns the record logged with attrs by a JSON handler.
+func (s *RedactSuite) log(attrs ...slog.Attr) map[string]any {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && (attr.Key == slog.TimeKey || attr.Key == slog.LevelKey || attr.Key == slog.MessageKey) {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	})
+
+	record := slog.Record{}
+	for _, attr := range attrs {
+		record.AddAttrs(s.redactor.Attr(attr))
+	}
+	s.Require().NoError(handler.Handle(s.T().Context(), record))
+
+	var result map[string]any
+	s.Require().NoError(json.Unmarshal(buf.Bytes(), &result))
+	return result
+}
+
+func (s *RedactSuite) TestKeys() {
+	s.Equal(map[string]any{
+		"Email":           Mask,
+		"access_token":    Mask,
+		"X-Refresh-Token": Mask,
+		"Authorization":   Mask,
+		"user":            map[string]any{"password": Mask, "age": float64(30)},
+		"method":          "GET",
+	}, s.log(
+		slog.String("Email", "john@example.com"),
+		slog.String("access_token", "abc"),
+		slog.String("X-Refresh-Token", "def"),
+		slog.String("Authorization", "Bearer abc"),
+		slog.Group("user", slog.String("password", "secret"), slog.Int("age", 30)),
+		slog.String("method", "GET"),
+	))
+}
+
+func (s *RedactSuite) TestStructTag() {
+	user := account{ID: 1, Email: "john@example.com", Nickname: "john", Token: "abc", internal: "x"}
+
+	expected := map[string]any{"ID": float64(1), "email": Mask, "Nickname": "john", "Token": Mask}
+	s.Equal(map[string]any{"user": expected, "pointer": expected}, s.log(slog.Any("user", user), slog.Any("pointer", &user)))
+
+	// structs without tagged fields are logged as they are
+	s.Equal(map[string]any{"value": map[string]any{"Email": "john@example.com"}}, s.log(slog.Any("value", struct{ Email string }{"john@example.com"})))
+}
+
+func (s *RedactSuite) TestHash() {
+	s.redactor = New(Options{Keys: []string{"email"}, Hash: true, HashKey: "key"})
+
+	first := s.redactor.Attr(slog.String("email", "john@example.com")).Value.String()
+	s.Regexp(`^sha256:[0-9a-f]{16}$`, first)
+	s.Equal(first, s.redactor.Attr(slog.String("email", "john@example.com")).Value.String())
+	s.NotEqual(first, s.redactor.Attr(slog.String("email", "jane@example.com")).Value.String())
+	s.NotEqual(first, New(Options{Keys: []string{"email"}, Hash: true, HashKey: "other"}).Value("john@example.com"))
+}
+
+func (s *RedactSuite) TestSQLParams() {
+	tests := []struct {
+		name     string
+		sql      string
+		params   []any
+		expected []any
+	}{
+		{
+			name:     "insert tuples",
+			sql:      `INSERT INTO "users" ("email","age","created_at") VALUES ($1,$2,$3),($4,$5,$6) RETURNING "id"`,
+			params:   []any{"john@example.com", 30, "now", "jane@example.com", 40, "now"},
+			expected: []any{Mask, 30, "now", Mask, 40, "now"},
+		},
+		{
+			name:     "insert with upsert",
+			sql:      `INSERT INTO "users" ("age","email") VALUES ($1,$2) ON CONFLICT ("email") DO UPDATE SET "age"="excluded"."age"`,
+			params:   []any{30, "john@example.com"},
+			expected: []any{30, Mask},
+		},
+		{
+			name:     "update",
+			sql:      `UPDATE "users" SET "email"=$1,"version"=$2 WHERE "users"."id" = $3 AND "users"."version" = $4`,
+			params:   []any{"john@example.com", 2, 1, 1},
+			expected: []any{Mask, 2, 1, 1},
+		},
+		{
+			name:     "in",
+			sql:      `SELECT "email" FROM "users" WHERE "users"."email" IN ($1,$2)`,
+			params:   []any{"john@example.com", "jane@example.com"},
+			expected: []any{Mask, Mask},
+		},
+		{
+			name:     "not in with spaces",
+			sql:      `SELECT * FROM "users" WHERE id NOT IN ( $1 , $2 ) AND email NOT IN ( $3 , $4 )`,
+			params:   []any{1, 2, "john@example.com", "jane@example.com"},
+			expected: []any{1, 2, Mask, Mask},
+		},
+		{
+			name:     "ilike",
+			sql:      `SELECT * FROM "users" WHERE "users"."email" ILIKE $1 AND "name" ILIKE $2`,
+			params:   []any{"%john%", "%john%"},
+			expected: []any{Mask, "%john%"},
+		},
+		{
+			name:     "ilike and in",
+			sql:      `SELECT * FROM "users" WHERE email ILIKE $1 AND "users"."email" IN ($2,$3) AND age > $4`,
+			params:   []any{"%john%", "john@example.com", "jane@example.com", 18},
+			expected: []any{Mask, Mask, Mask, 18},
+		},
+		{
+			name:     "more params than placeholders",
+			sql:      `SELECT * FROM "users" WHERE email = $1`,
+			params:   []any{"john@example.com", "jane@example.com"},
+			expected: []any{Mask, "jane@example.com"},
+		},
+		{
+			name:     "no params",
+			sql:      `SELECT * FROM "users"`,
+			params:   nil,
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			params := append([]any{}, test.params...)
+			s.Equal(test.expected, s.redactor.SQLParams(test.sql, test.params))
+			// the params are still bound to the statement, they must not be redacted in place
+			s.Equal(params, append([]any{}, test.params...))
+		})
+	}
+}
+
+func (s *RedactSuite) TestValidPattern() {
+	s.NoError(ValidPattern("*token*"))
+	s.Error(ValidPattern("[token"))
+}
+
+func TestRedactSuite(t *testing.T) {
+	suite.Run(t, new(RedactSuite))
+}
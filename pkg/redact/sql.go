@@ -0,0 +1,65 @@
+package redact
+
+import (
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	placeholderRegex = regexp.MustCompile(`\$(\d+)`)
+	insertRegex      = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s+\S+\s*\(([^)]*)\)\s*VALUES\s*(.*)$`)
+	tupleRegex       = regexp.MustCompile(`\(([^()]*)\)`)
+	// comparisonRegex finds the column compared to placeholders, e.g. "users"."email" = $1, email ILIKE $2 or
+	// id IN ($3,$4), which also covers the SET clause of updates
+	comparisonRegex = regexp.MustCompile(`(?i)("?\w+"?)\s*(?:=|<>|!=|<=|>=|<|>|\bI?LIKE\b|\bNOT\s+I?LIKE\b|\bIN\b|\bNOT\s+IN\b)\s*\(?\s*((?:\$\d+\s*,?\s*)+)`)
+)
+
+// SQLParams redacts the params of a PostgreSQL statement, where they are written $1, $2..., bound to a
+// matching column. Params whose column cannot be told from the statement are kept.
+func (r *Redactor) SQLParams(sql string, params []any) []any {
+	if len(params) == 0 || len(r.patterns) == 0 {
+		return params
+	}
+
+	redacted := make([]any, len(params))
+	copy(redacted, params)
+
+	for index, column := range r.columnsByPlaceholder(sql) {
+		if index < len(redacted) && r.Match(column) {
+			redacted[index] = r.Value(format(slog.AnyValue(redacted[index])))
+		}
+	}
+	return redacted
+}
+
+// columnsByPlaceholder returns the column of each param, by index.
+func (r *Redactor) columnsByPlaceholder(sql string) map[int]string {
+	columns := map[int]string{}
+
+	if match := insertRegex.FindStringSubmatch(sql); match != nil {
+		names := strings.Split(match[1], ",")
+		for _, tuple := range tupleRegex.FindAllStringSubmatch(match[2], -1) {
+			for position, value := range strings.Split(tuple[1], ",") {
+				if position < len(names) {
+					addPlaceholders(columns, value, names[position])
+				}
+			}
+		}
+	}
+
+	for _, match := range comparisonRegex.FindAllStringSubmatch(sql, -1) {
+		addPlaceholders(columns, match[2], match[1])
+	}
+	return columns
+}
+
+func addPlaceholders(columns map[int]string, sql string, column string) {
+	column = strings.Trim(strings.TrimSpace(column), `"`)
+	for _, placeholder := range placeholderRegex.FindAllStringSubmatch(sql, -1) {
+		if number, err := strconv.Atoi(placeholder[1]); err == nil && number > 0 {
+			columns[number-1] = column
+		}
+	}
+}
@@ -78,12 +78,29 @@ func (s *GrpcServer) Start() error {
 	return nil
 }
 
+// Shutdown stops accepting connections and waits for the pending RPCs. The RPCs still running after timeout
+// are canceled and the connections closed.
 func (s *GrpcServer) Shutdown(ctx context.Context, timeout time.Duration) error {
 	s.logger.Info("Graceful shutting down the GRPC server...", slog.String("timeout", timeout.String()))
 
-	_, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	s.server.GracefulStop()
-	return nil
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("GRPC server gracefully stopped")
+		return nil
+	case <-ctx.Done():
+		s.logger.Error("GRPC server did not stop in time, closing the remaining connections", slog.String("error", ctx.Err().Error()))
+		// Stop makes the pending GracefulStop return too
+		s.server.Stop()
+		<-done
+		return ctx.Err()
+	}
 }
@@ -15,23 +15,53 @@ import (
 )
 
 type Server struct {
-	server *http.Server
-	logger *slog.Logger
+	server   *http.Server
+	logger   *slog.Logger
+	tls      bool
+	redirect *http.Server
+	inFlight *inFlight
+}
+
+type Option func(*Server)
+
+// WithTLS serves HTTPS, the certificates come from the TLSConfig of the http.Server.
+func WithTLS() Option {
+	return func(s *Server) {
+		s.tls = true
+	}
+}
+
+// WithRedirectServer runs redirect, e.g. a RedirectToHTTPS listener, along with the server.
+func WithRedirectServer(redirect *http.Server) Option {
+	return func(s *Server) {
+		s.redirect = redirect
+	}
 }
 
 // New creates a new http server.
 // It need pass the original http.Server and logger.Logger.
 // The original http.Server can be created by User requirement itself.
 // Our wrapper will help to start the server and graceful shutdown.
-func New(server *http.Server, logger *logger.Logger) *Server {
+func New(server *http.Server, logger *logger.Logger, opts ...Option) *Server {
 
 	loggerHandler := logger.GetHandler()
 	loggerHandler.CallerSkip = 3
 	slogger := slog.New(&loggerHandler)
 
 	s := &Server{
-		server: server,
-		logger: slogger,
+		server:   server,
+		logger:   slogger,
+		inFlight: newInFlight(),
+	}
+
+	handler := server.Handler
+	if handler == nil {
+		handler = http.DefaultServeMux
+	}
+	server.Handler = s.inFlight.middleware(handler)
+
+	for _, opt := range opts {
+		opt(s)
 	}
 
 	return s
@@ -44,29 +74,11 @@ func (s *Server) StartWithGracefulShutdown(shutdownTimeout time.Duration) {
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	g.Go(func() error {
-		s.logger.Info("Starting HTTP server", slog.String("address", s.server.Addr))
-
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.logger.Error("HTTP server error", slog.String("error", err.Error()))
-			return err
-		}
-		return nil
-	})
+	g.Go(s.Start)
 
 	g.Go(func() error {
 		<-ctx.Done()
-		s.logger.Info("Graceful shutting down the HTTP server...", slog.String("timeout", shutdownTimeout.String()))
-
-		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-		defer cancel()
-
-		if err := s.server.Shutdown(ctx); err != nil {
-			s.logger.Error("Error shutting down HTTP server", slog.String("error", err.Error()))
-			return err
-		}
-		s.logger.Info("HTTP server gracefully stopped")
-		return nil
+		return s.Shutdown(context.Background(), shutdownTimeout)
 	})
 
 	if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
@@ -76,25 +88,81 @@ func (s *Server) StartWithGracefulShutdown(shutdownTimeout time.Duration) {
 }
 
 // normal start without graceful shutdown. Can be used with Fx who control the lifecycle outside.
+// The redirect server runs along, the first of them failing closes the other one and its error is returned.
 func (s *Server) Start() error {
-	s.logger.Info("Starting HTTP server", slog.String("address", s.server.Addr))
-
-	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		s.logger.Error("HTTP server error", slog.String("error", err.Error()))
-		return err
+	var g errgroup.Group
+
+	if s.redirect != nil {
+		g.Go(func() error {
+			s.logger.Info("Starting HTTP redirect server", slog.String("address", s.redirect.Addr))
+			if err := s.redirect.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.logger.Error("HTTP redirect server error", slog.String("error", err.Error()))
+				// nolint: errcheck
+				s.server.Close()
+				return err
+			}
+			return nil
+		})
 	}
-	return nil
+
+	g.Go(func() error {
+		s.logger.Info("Starting HTTP server", slog.String("address", s.server.Addr), slog.Bool("tls", s.tls))
+
+		var err error
+		if s.tls {
+			err = s.server.ListenAndServeTLS("", "")
+		} else {
+			err = s.server.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server error", slog.String("error", err.Error()))
+			if s.redirect != nil {
+				// nolint: errcheck
+				s.redirect.Close()
+			}
+			return err
+		}
+		return nil
+	})
+
+	return g.Wait()
 }
 
 // normal shutdown. Used with Fx who controls the lifecycle outside.
+// It stops accepting connections, closes the upgraded ones and waits for the in-flight requests. The
+// connections still open after timeout are closed.
 func (s *Server) Shutdown(ctx context.Context, timeout time.Duration) error {
-	s.logger.Info("Graceful shutting down the HTTP server...", slog.String("timeout", timeout.String()))
+	s.logger.Info("Graceful shutting down the HTTP server...",
+		slog.String("timeout", timeout.String()),
+		slog.Int("in_flight", s.inFlight.requests()),
+	)
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	if err := s.server.Shutdown(ctx); err != nil {
-		s.logger.Error("Error shutting down HTTP server", slog.String("error", err.Error()))
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			s.logger.Error("Error shutting down HTTP redirect server", slog.String("error", err.Error()))
+		}
+	}
+
+	upgradesErr := make(chan error, 1)
+	go func() {
+		upgradesErr <- s.inFlight.closeUpgrades(ctx)
+	}()
+
+	err := s.server.Shutdown(ctx)
+	if uErr := <-upgradesErr; err == nil {
+		err = uErr
+	}
+	if err != nil {
+		s.logger.Error("Error shutting down HTTP server, closing the remaining connections",
+			slog.String("error", err.Error()),
+			slog.Int("in_flight", s.inFlight.requests()),
+		)
+		if closeErr := s.server.Close(); closeErr != nil {
+			s.logger.Error("Error closing HTTP server", slog.String("error", closeErr.Error()))
+		}
 		return err
 	}
 	s.logger.Info("HTTP server gracefully stopped")
@@ -0,0 +1,81 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+// inFlight tracks the requests being handled. http.Server.Shutdown waits for regular requests but neither
+// waits for nor closes upgraded connections, like GraphQL WebSockets, so their context is canceled on
+// shutdown instead, which makes the handler send a close frame and return.
+type inFlight struct {
+	count    atomic.Int64
+	upgrades sync.WaitGroup
+
+	mu      sync.Mutex
+	closing bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+func newInFlight() *inFlight {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &inFlight{ctx: ctx, cancel: cancel}
+}
+
+func (f *inFlight) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.count.Add(1)
+		defer f.count.Add(-1)
+
+		if r.Header.Get("Upgrade") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		f.mu.Lock()
+		if f.closing {
+			f.mu.Unlock()
+			w.Header().Set("Connection", "close")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+		f.upgrades.Add(1)
+		f.mu.Unlock()
+		defer f.upgrades.Done()
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		stop := context.AfterFunc(f.ctx, cancel)
+		defer stop()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (f *inFlight) requests() int {
+	return int(f.count.Load())
+}
+
+// closeUpgrades cancels the upgraded connections and waits for their handlers to return, or ctx to be done.
+func (f *inFlight) closeUpgrades(ctx context.Context) error {
+	f.mu.Lock()
+	f.closing = true
+	f.mu.Unlock()
+	f.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		f.upgrades.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type InFlightSuite struct {
+	suite.Suite
+	inFlight *inFlight
+	started  chan struct{}
+	finished chan error
+}
+
+func (s *InFlightSuite) SetupTest() {
+	s.inFlight = newInFlight()
+	s.started = make(chan struct{}, 1)
+	s.finished = make(chan error, 1)
+}
+
+// blocking waits for its context, like a WebSocket handler, or for release.
+func (s *InFlightSuite) blocking(release <-chan struct{}) http.Handler {
+	return s.inFlight.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.started <- struct{}{}
+		select {
+		case <-r.Context().Done():
+			s.finished <- r.Context().Err()
+		case <-release:
+			s.finished <- nil
+		}
+	}))
+}
+
+func (s *InFlightSuite) serve(handler http.Handler, r *http.Request) {
+	go handler.ServeHTTP(httptest.NewRecorder(), r)
+	<-s.started
+	s.Equal(1, s.inFlight.requests())
+}
+
+func (s *InFlightSuite) TestCloseUpgrades() {
+	r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	r.Header.Set("Upgrade", "websocket")
+	s.serve(s.blocking(nil), r)
+
+	s.Require().NoError(s.inFlight.closeUpgrades(context.Background()))
+	s.ErrorIs(<-s.finished, context.Canceled)
+	s.Eventually(func() bool { return s.inFlight.requests() == 0 }, time.Second, time.Millisecond)
+
+	// no new upgrades once closing
+	rr := httptest.NewRecorder()
+	s.blocking(nil).ServeHTTP(rr, r)
+	s.Equal(http.StatusServiceUnavailable, rr.Code)
+}
+
+func (s *InFlightSuite) TestRegularRequestsAreNotCanceled() {
+	release := make(chan struct{})
+	s.serve(s.blocking(release), httptest.NewRequest(http.MethodGet, "/api/v1/users", nil))
+
+	s.Require().NoError(s.inFlight.closeUpgrades(context.Background()))
+	s.Equal(1, s.inFlight.requests())
+
+	close(release)
+	s.NoError(<-s.finished)
+}
+
+func (s *InFlightSuite) TestCloseUpgradesTimeout() {
+	release := make(chan struct{})
+	defer close(release)
+
+	r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	r.Header.Set("Upgrade", "websocket")
+	// ignores the cancellation
+	handler := s.inFlight.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.started <- struct{}{}
+		<-release
+	}))
+	s.serve(handler, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(s.inFlight.closeUpgrades(ctx), context.DeadlineExceeded)
+}
+
+func TestInFlightSuite(t *testing.T) {
+	suite.Run(t, new(InFlightSuite))
+}
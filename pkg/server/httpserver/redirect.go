@@ -0,0 +1,30 @@
+package httpserver
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RedirectToHTTPS redirects every request to the same URL on HTTPS at httpsPort.
+// 308 keeps the method and body of non-GET requests.
+func RedirectToHTTPS(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://"+httpsHost(r.Host, httpsPort)+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
+func httpsHost(requestHost string, httpsPort int) string {
+	hostname := requestHost
+	if h, _, err := net.SplitHostPort(requestHost); err == nil {
+		hostname = h
+	}
+	hostname = strings.Trim(hostname, "[]")
+
+	// the default port is left out, unless the brackets of an IPv6 address are needed anyway
+	if httpsPort == 443 && !strings.Contains(hostname, ":") {
+		return hostname
+	}
+	return net.JoinHostPort(hostname, strconv.Itoa(httpsPort))
+}
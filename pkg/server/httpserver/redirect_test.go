@@ -0,0 +1,64 @@
+package httpserver
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/godash/logger"
+)
+
+type RedirectSuite struct {
+	suite.Suite
+}
+
+func (s *RedirectSuite) redirect(httpsPort int, method, target string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	RedirectToHTTPS(httpsPort).ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+	return rr
+}
+
+func (s *RedirectSuite) TestRedirect() {
+	rr := s.redirect(8443, http.MethodPost, "http://example.com:8080/api/v1/users?offset=10")
+
+	s.Equal(http.StatusPermanentRedirect, rr.Code)
+	s.Equal("https://example.com:8443/api/v1/users?offset=10", rr.Header().Get("Location"))
+}
+
+func (s *RedirectSuite) TestRedirectDefaultPort() {
+	s.Equal("https://example.com/", s.redirect(443, http.MethodGet, "http://example.com/").Header().Get("Location"))
+	s.Equal("https://[::1]:443/", s.redirect(443, http.MethodGet, "http://[::1]:8080/").Header().Get("Location"))
+}
+
+func (s *RedirectSuite) TestStartFailsWithRedirectServer() {
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer taken.Close()
+
+	server := New(
+		&http.Server{Addr: "127.0.0.1:0", Handler: http.NotFoundHandler()},
+		logger.New(logger.NewLoggerOps(false, io.Discard, slog.LevelInfo, false, "", 0), nil),
+		WithRedirectServer(&http.Server{Addr: taken.Addr().String(), Handler: RedirectToHTTPS(443)}),
+	)
+
+	started := make(chan error, 1)
+	go func() { started <- server.Start() }()
+
+	// the main server is closed with its redirect, Start does not keep serving without it
+	select {
+	case err := <-started:
+		var opErr *net.OpError
+		s.ErrorAs(err, &opErr)
+	case <-time.After(5 * time.Second):
+		s.Fail("Start did not return")
+	}
+}
+
+func TestRedirectSuite(t *testing.T) {
+	suite.Run(t, new(RedirectSuite))
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+type bodyCtxKey struct{}
+
+// MaxBodySize limits request bodies to n bytes, reading past the limit fails with *http.MaxBytesError.
+// Routes taking larger (or smaller) bodies change the limit with SetMaxBodySize.
+func MaxBodySize(n int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), bodyCtxKey{}, r.Body)
+			r = r.WithContext(ctx)
+			r.Body = http.MaxBytesReader(w, r.Body, n)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetMaxBodySize replaces the limit set by MaxBodySize for r. It must be called before the body is read.
+func SetMaxBodySize(w http.ResponseWriter, r *http.Request, n int64) {
+	body, ok := r.Context().Value(bodyCtxKey{}).(io.ReadCloser)
+	if !ok {
+		body = r.Body
+	}
+	r.Body = http.MaxBytesReader(w, body, n)
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type BodyLimitSuite struct {
+	suite.Suite
+}
+
+// read serves body through MaxBodySize(limit), with the route limit set to routeLimit when positive, and
+// returns the error of reading the whole body.
+func (s *BodyLimitSuite) read(limit, routeLimit int64, body string) error {
+	var readErr error
+	handler := MaxBodySize(limit)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if routeLimit > 0 {
+			SetMaxBodySize(w, r, routeLimit)
+		}
+		_, readErr = io.ReadAll(r.Body)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return readErr
+}
+
+func (s *BodyLimitSuite) TestWithinLimit() {
+	s.NoError(s.read(8, 0, "12345678"))
+}
+
+func (s *BodyLimitSuite) TestOverLimit() {
+	var maxBytesErr *http.MaxBytesError
+	s.True(errors.As(s.read(4, 0, "12345678"), &maxBytesErr))
+	s.Equal(int64(4), maxBytesErr.Limit)
+}
+
+func (s *BodyLimitSuite) TestRouteLimit() {
+	s.NoError(s.read(4, 16, "12345678"))
+
+	var maxBytesErr *http.MaxBytesError
+	s.True(errors.As(s.read(16, 4, "12345678"), &maxBytesErr))
+	s.Equal(int64(4), maxBytesErr.Limit)
+}
+
+func TestBodyLimitSuite(t *testing.T) {
+	suite.Run(t, new(BodyLimitSuite))
+}
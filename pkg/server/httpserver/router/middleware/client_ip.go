@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+type clientIPCtxKey struct{}
+
+// ClientIP resolves the client IP of each request once and stores it in the context, see ExtractIP.
+//
+// Forwarding headers are only followed when the request comes from one of trustedProxies. In that case
+// the RFC 7239 Forwarded header, or else X-Forwarded-For, is read right to left, skipping the trusted
+// proxies, and the first other address is the client. X-Real-IP is used when neither is sent. Without
+// trusted proxies, the peer address is always the client.
+func ClientIP(trustedProxies []netip.Prefix) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := resolveClientIP(r, trustedProxies)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPCtxKey{}, ip)))
+		})
+	}
+}
+
+// ExtractIP returns the client IP resolved by ClientIP, or the peer address when ClientIP did not run.
+func ExtractIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPCtxKey{}).(string); ok {
+		return ip
+	}
+	return remoteHost(r)
+}
+
+func resolveClientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote := remoteHost(r)
+	remoteAddr, err := netip.ParseAddr(remote)
+	if err != nil || !isTrusted(remoteAddr, trustedProxies) {
+		return remote
+	}
+	remoteAddr = remoteAddr.Unmap()
+
+	var hops []string
+	switch {
+	case r.Header.Get("Forwarded") != "":
+		hops = forwardedFor(r.Header.Values("Forwarded"))
+	case r.Header.Get("X-Forwarded-For") != "":
+		hops = splitList(r.Header.Values("X-Forwarded-For"))
+	case r.Header.Get("X-Real-IP") != "":
+		hops = []string{strings.TrimSpace(r.Header.Get("X-Real-IP"))}
+	}
+
+	// the nearest trusted proxy stands for the client when the chain ends with proxies or garbage
+	client := remoteAddr
+	for _, hop := range slices.Backward(hops) {
+		addr, err := parseHop(hop)
+		if err != nil {
+			break
+		}
+		client = addr
+		if !isTrusted(addr, trustedProxies) {
+			break
+		}
+	}
+	return client.String()
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func isTrusted(addr netip.Addr, trustedProxies []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// forwardedFor returns the for= parameters of the Forwarded header elements, in order.
+func forwardedFor(values []string) []string {
+	var hops []string
+	for _, element := range splitList(values) {
+		for pair := range strings.SplitSeq(element, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			if ok && strings.EqualFold(key, "for") {
+				hops = append(hops, strings.Trim(value, `"`))
+			}
+		}
+	}
+	return hops
+}
+
+func splitList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for item := range strings.SplitSeq(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+	}
+	return items
+}
+
+// parseHop parses an address of a forwarding header, which may carry a port, e.g. "[2001:db8::1]:4711".
+func parseHop(hop string) (netip.Addr, error) {
+	if addrPort, err := netip.ParseAddrPort(hop); err == nil {
+		return addrPort.Addr().Unmap(), nil
+	}
+	addr, err := netip.ParseAddr(strings.TrimSuffix(strings.TrimPrefix(hop, "["), "]"))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
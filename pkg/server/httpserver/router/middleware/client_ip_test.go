@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClientIPSuite struct {
+	suite.Suite
+	trustedProxies []netip.Prefix
+}
+
+func (s *ClientIPSuite) SetupTest() {
+	s.trustedProxies = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8:ffff::/48"),
+	}
+}
+
+func (s *ClientIPSuite) clientIP(remoteAddr string, header http.Header) string {
+	var ip string
+	handler := ClientIP(s.trustedProxies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip = ExtractIP(r)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return ip
+}
+
+func (s *ClientIPSuite) TestUntrustedPeer() {
+	s.Equal("203.0.113.7", s.clientIP("203.0.113.7:5000", http.Header{
+		"X-Forwarded-For": {"198.51.100.1"},
+		"X-Real-Ip":       {"198.51.100.2"},
+	}))
+}
+
+func (s *ClientIPSuite) TestXForwardedFor() {
+	// the left most entry is made up by the client, the proxies only append
+	s.Equal("198.51.100.1", s.clientIP("10.0.0.2:5000", http.Header{
+		"X-Forwarded-For": {"1.2.3.4, 198.51.100.1", "10.0.0.1"},
+	}))
+
+	// only proxies, the furthest one stands for the client
+	s.Equal("10.0.0.1", s.clientIP("10.0.0.2:5000", http.Header{"X-Forwarded-For": {"10.0.0.1"}}))
+
+	// garbage stops the walk at the last proxy
+	s.Equal("10.0.0.1", s.clientIP("10.0.0.2:5000", http.Header{"X-Forwarded-For": {"unknown, 10.0.0.1"}}))
+}
+
+func (s *ClientIPSuite) TestForwarded() {
+	s.Equal("2001:db8::1", s.clientIP("[2001:db8:ffff::2]:5000", http.Header{
+		"Forwarded":       {`for=1.2.3.4, for="[2001:db8::1]:4711";proto=https, For=10.0.0.1`},
+		"X-Forwarded-For": {"198.51.100.1"},
+	}))
+	s.Equal("192.0.2.43", s.clientIP("10.0.0.2:5000", http.Header{"Forwarded": {`for="192.0.2.43:47011"`}}))
+}
+
+func (s *ClientIPSuite) TestXRealIP() {
+	s.Equal("198.51.100.2", s.clientIP("10.0.0.2:5000", http.Header{"X-Real-Ip": {"198.51.100.2"}}))
+}
+
+func (s *ClientIPSuite) TestWithoutMiddleware() {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "203.0.113.7:5000"
+	req.Header.Set("X-Forwarded-For", "198.51.100.1")
+
+	s.Equal("203.0.113.7", ExtractIP(req))
+}
+
+func TestClientIPSuite(t *testing.T) {
+	suite.Run(t, new(ClientIPSuite))
+}
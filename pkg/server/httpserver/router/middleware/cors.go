@@ -2,16 +2,80 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-chi/cors"
 )
 
-func Cors(
-	allowedOrigins []string,
-) func(next http.Handler) http.Handler {
-	return cors.Handler(
-		cors.Options{
-			AllowedOrigins: allowedOrigins,
-		},
-	)
+type CorsOptions struct {
+	// AllowedOrigins are matched with OriginMatcher
+	AllowedOrigins []string
+	// AllowedMethods defaults to GET, POST and HEAD
+	AllowedMethods []string
+	// AllowedHeaders defaults to Origin, Accept and Content-Type
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long preflight responses are cached, 0 leaves it to the browser
+	MaxAge time.Duration
+}
+
+// CorsRoute applies Options to the paths under PathPrefix, e.g. "/api" covers "/api" and "/api/users".
+type CorsRoute struct {
+	PathPrefix string
+	Options    CorsOptions
+}
+
+// Cors handles CORS requests with options, or with the options of the route with the longest matching prefix.
+func Cors(options CorsOptions, routes ...CorsRoute) func(next http.Handler) http.Handler {
+	routes = slices.Clone(routes)
+	slices.SortFunc(routes, func(a, b CorsRoute) int { return len(b.PathPrefix) - len(a.PathPrefix) })
+
+	return func(next http.Handler) http.Handler {
+		defaultHandler := corsHandler(options)(next)
+		routeHandlers := make([]http.Handler, len(routes))
+		for i, route := range routes {
+			routeHandlers[i] = corsHandler(route.Options)(next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for i, route := range routes {
+				if HasPathPrefix(r.URL.Path, route.PathPrefix) {
+					routeHandlers[i].ServeHTTP(w, r)
+					return
+				}
+			}
+			defaultHandler.ServeHTTP(w, r)
+		})
+	}
+}
+
+func corsHandler(options CorsOptions) func(next http.Handler) http.Handler {
+	corsOptions := cors.Options{
+		AllowedMethods:   options.AllowedMethods,
+		AllowedHeaders:   options.AllowedHeaders,
+		ExposedHeaders:   options.ExposedHeaders,
+		AllowCredentials: options.AllowCredentials,
+		MaxAge:           int(options.MaxAge.Seconds()),
+	}
+
+	// "*" is only sent back as is without credentials, otherwise the origin of the request is echoed
+	matcher := NewOriginMatcher(options.AllowedOrigins)
+	if matcher.AllowsAll() && !options.AllowCredentials {
+		corsOptions.AllowedOrigins = []string{"*"}
+	} else {
+		corsOptions.AllowOriginFunc = func(_ *http.Request, origin string) bool {
+			return matcher.Match(origin)
+		}
+	}
+
+	return cors.Handler(corsOptions)
+}
+
+// HasPathPrefix tells whether path is prefix or below it, "/api" matches "/api/users" but not "/apis".
+func HasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/")
 }
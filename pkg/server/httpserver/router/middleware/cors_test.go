@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CorsSuite struct {
+	suite.Suite
+}
+
+func (s *CorsSuite) preflight(handler http.Handler, path, origin string) http.Header {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	req.Header.Set("Access-Control-Request-Headers", "Idempotency-Key")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr.Header()
+}
+
+func (s *CorsSuite) TestOriginMatcher() {
+	matcher := NewOriginMatcher([]string{"https://app.example.com", "https://*.example.org", "http://localhost:*"})
+
+	s.True(matcher.Match("https://app.example.com"))
+	s.True(matcher.Match("HTTPS://APP.EXAMPLE.COM"))
+	s.True(matcher.Match("https://admin.example.org"))
+	s.True(matcher.Match("http://localhost:3000"))
+	s.False(matcher.Match("https://example.com"))
+	s.False(matcher.Match("https://evil.com/.example.org"))
+	s.False(matcher.Match(""))
+
+	s.True(NewOriginMatcher([]string{"*"}).Match("https://anything.com"))
+}
+
+func (s *CorsSuite) TestHasPathPrefix() {
+	s.True(HasPathPrefix("/api", "/api"))
+	s.True(HasPathPrefix("/api/users", "/api"))
+	s.True(HasPathPrefix("/api/users", "/api/"))
+	s.True(HasPathPrefix("/anything", "/"))
+	s.True(HasPathPrefix("/anything", ""))
+	s.False(HasPathPrefix("/apis", "/api"))
+	s.False(HasPathPrefix("/", "/api"))
+}
+
+func (s *CorsSuite) TestPreflight() {
+	handler := Cors(CorsOptions{
+		AllowedOrigins:   []string{"https://*.example.com"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPatch},
+		AllowedHeaders:   []string{"Content-Type", "Idempotency-Key"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	})(http.NotFoundHandler())
+
+	header := s.preflight(handler, "/api/v1/users", "https://app.example.com")
+	s.Equal("https://app.example.com", header.Get("Access-Control-Allow-Origin"))
+	s.Equal("PATCH", header.Get("Access-Control-Allow-Methods"))
+	s.Equal("Idempotency-Key", header.Get("Access-Control-Allow-Headers"))
+	s.Equal("true", header.Get("Access-Control-Allow-Credentials"))
+	s.Equal("600", header.Get("Access-Control-Max-Age"))
+
+	s.Empty(s.preflight(handler, "/api/v1/users", "https://example.org").Get("Access-Control-Allow-Origin"))
+}
+
+func (s *CorsSuite) TestRoutes() {
+	handler := Cors(
+		CorsOptions{AllowedOrigins: []string{"*"}, AllowedMethods: []string{http.MethodPatch}, AllowedHeaders: []string{"Idempotency-Key"}},
+		CorsRoute{
+			PathPrefix: "/api/v1/admin",
+			Options: CorsOptions{
+				AllowedOrigins: []string{"https://admin.example.com"},
+				AllowedMethods: []string{http.MethodPatch},
+				AllowedHeaders: []string{"Idempotency-Key"},
+			},
+		},
+	)(http.NotFoundHandler())
+
+	s.Equal("*", s.preflight(handler, "/api/v1/users", "https://app.example.com").Get("Access-Control-Allow-Origin"))
+	s.Equal("*", s.preflight(handler, "/api/v1/administrators", "https://app.example.com").Get("Access-Control-Allow-Origin"))
+	s.Empty(s.preflight(handler, "/api/v1/admin/users", "https://app.example.com").Get("Access-Control-Allow-Origin"))
+	s.Equal(
+		"https://admin.example.com",
+		s.preflight(handler, "/api/v1/admin", "https://admin.example.com").Get("Access-Control-Allow-Origin"),
+	)
+}
+
+func TestCorsSuite(t *testing.T) {
+	suite.Run(t, new(CorsSuite))
+}
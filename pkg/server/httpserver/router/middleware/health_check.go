@@ -5,26 +5,63 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/umefy/go-web-app-template/pkg/health"
 	"github.com/umefy/godash/jsonkit"
 	"github.com/umefy/godash/logger"
 )
 
-func HealthCheck(endpoint string, env string, version string, logger *logger.Logger) func(next http.Handler) http.Handler {
+type HealthProbesOptions struct {
+	LiveEndpoint   string // answers as long as the process serves requests
+	ReadyEndpoint  string // fails while a critical check fails or the server is shutting down
+	ReportEndpoint string // every check with its result, failing like readiness
+	Env            string
+	Version        string
+}
+
+type healthReport struct {
+	health.Report
+	Env     string `json:"env"`
+	Version string `json:"version"`
+}
+
+// HealthProbes answers the probe endpoints before the rest of the middlewares run, so that probes are neither
+// traced nor rate limited.
+func HealthProbes(opts HealthProbesOptions, registry *health.Registry, logger *logger.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if (r.Method == http.MethodGet || r.Method == http.MethodHead) && strings.EqualFold(r.URL.Path, endpoint) {
-				resp := map[string]string{
-					"status":  "ok",
-					"env":     env,
-					"version": version,
-				}
-				err := jsonkit.JSONResponse(w, http.StatusOK, resp)
-				if err != nil {
-					logger.ErrorContext(r.Context(), "Health check failed", slog.String("error", err.Error()))
-				}
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var status int
+			var resp any
+			switch {
+			case strings.EqualFold(r.URL.Path, opts.LiveEndpoint):
+				status, resp = http.StatusOK, map[string]health.Status{"status": health.StatusOK}
+			case strings.EqualFold(r.URL.Path, opts.ReadyEndpoint):
+				report := registry.Check(r.Context())
+				status, resp = probeStatus(report), map[string]health.Status{"status": report.Status}
+			case strings.EqualFold(r.URL.Path, opts.ReportEndpoint):
+				report := registry.Check(r.Context())
+				status, resp = probeStatus(report), healthReport{Report: report, Env: opts.Env, Version: opts.Version}
+			default:
+				next.ServeHTTP(w, r)
 				return
 			}
-			next.ServeHTTP(w, r)
+
+			w.Header().Set("Cache-Control", "no-store")
+			if err := jsonkit.JSONResponse(w, status, resp); err != nil {
+				logger.ErrorContext(r.Context(), "Health probe failed", slog.String("path", r.URL.Path), slog.String("error", err.Error()))
+			}
 		})
 	}
 }
+
+// probeStatus keeps degraded instances in rotation, only failing ones are taken out.
+func probeStatus(report health.Report) int {
+	if report.Status == health.StatusFailing {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusOK
+}
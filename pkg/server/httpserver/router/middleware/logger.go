@@ -3,9 +3,7 @@ package middleware
 import (
 	"fmt"
 	"log/slog"
-	"net"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/umefy/godash/logger"
@@ -69,20 +67,7 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// ExtractIP extracts the real client IP, handling proxies
-func ExtractIP(r *http.Request) string {
-	// Check for a forwarded IP (common in reverse proxies)
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		ips := strings.Split(xff, ",")
-		return strings.TrimSpace(ips[0]) // Take the first IP
-	}
-
-	// Fallback to direct RemoteAddr
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		return r.RemoteAddr // Use full RemoteAddr if parsing fails
-	}
-
-	return host
+// Unwrap lets http.ResponseController reach the flusher of streamed responses.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
 }
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const metricsScope = "github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
+
+// durationBuckets are in seconds, the default ones of the SDK are meant for milliseconds.
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics counts the requests and records their duration per method, route template and status code. The
+// route template, e.g. /api/v1/users/{id}, keeps the number of series bounded, requests matching no route
+// are recorded under "unmatched".
+func Metrics(meterProvider metric.MeterProvider) (func(next http.Handler) http.Handler, error) {
+	meter := meterProvider.Meter(metricsScope)
+
+	requests, err := meter.Int64Counter("http.server.requests",
+		metric.WithDescription("Number of HTTP requests handled, by method, route and status code."),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram("http.server.duration",
+		metric.WithDescription("Duration of HTTP requests, by method, route and status code."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// WebSocket connections last as long as the client wants, their duration says nothing
+			if IsWebSocketUpgrade(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			// a panic is answered with a 500 by the recover middleware, further up
+			panicked := true
+			defer func() {
+				statusCode := ww.statusCode
+				if panicked {
+					statusCode = http.StatusInternalServerError
+				}
+
+				attrs := metric.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("http.route", routePattern(r)),
+					attribute.String("http.response.status_code", strconv.Itoa(statusCode)),
+				)
+				// the request is over, a canceled context must not drop the measurement
+				ctx := context.WithoutCancel(r.Context())
+				requests.Add(ctx, 1, attrs)
+				duration.Record(ctx, time.Since(start).Seconds(), attrs)
+			}()
+
+			next.ServeHTTP(ww, r)
+			panicked = false
+		})
+	}, nil
+}
+
+// routePattern is only complete once the request went through every mounted router.
+func routePattern(r *http.Request) string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return "unmatched"
+	}
+
+	pattern := rctx.RoutePattern()
+	if pattern == "" || pattern == "/*" {
+		return "unmatched"
+	}
+	return pattern
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+	reader *sdkmetric.ManualReader
+	router *chi.Mux
+}
+
+func (s *MetricsSuite) SetupTest() {
+	s.reader = sdkmetric.NewManualReader()
+	metrics, err := Metrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(s.reader)))
+	s.Require().NoError(err)
+
+	users := chi.NewRouter()
+	users.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	users.Get("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	s.router = chi.NewRouter()
+	s.router.Use(metrics)
+	s.router.Mount("/api/v1/users", users)
+}
+
+func (s *MetricsSuite) serve(target string) {
+	s.router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+// requests returns the request count per route and status code.
+func (s *MetricsSuite) requests() map[[2]string]int64 {
+	var rm metricdata.ResourceMetrics
+	s.Require().NoError(s.reader.Collect(context.Background(), &rm))
+
+	counts := map[[2]string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "http.server.requests" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				route, _ := dp.Attributes.Value(attribute.Key("http.route"))
+				status, _ := dp.Attributes.Value(attribute.Key("http.response.status_code"))
+				counts[[2]string{route.AsString(), status.AsString()}] = dp.Value
+			}
+		}
+	}
+	return counts
+}
+
+func (s *MetricsSuite) TestRouteTemplate() {
+	s.serve("/api/v1/users/1")
+	s.serve("/api/v1/users/2")
+	s.serve("/unknown")
+
+	s.Equal(map[[2]string]int64{
+		{"/api/v1/users/{id}", "404"}: 2,
+		{"unmatched", "404"}:          1,
+	}, s.requests())
+}
+
+func (s *MetricsSuite) TestPanic() {
+	s.Panics(func() { s.serve("/api/v1/users/panic") })
+
+	s.Equal(map[[2]string]int64{{"/api/v1/users/panic", "500"}: 1}, s.requests())
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
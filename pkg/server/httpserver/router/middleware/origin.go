@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"strings"
+)
+
+// OriginMatcher matches request origins against a list of allowed origins. "*" allows any origin and one
+// "*" in an allowed origin stands for any characters but "/", e.g. "https://*.example.com" or
+// "http://localhost:*". Origins are compared case-insensitively.
+type OriginMatcher struct {
+	all       bool
+	exact     map[string]struct{}
+	wildcards []originWildcard
+}
+
+type originWildcard struct {
+	prefix string
+	suffix string
+}
+
+func NewOriginMatcher(allowedOrigins []string) *OriginMatcher {
+	m := &OriginMatcher{exact: map[string]struct{}{}}
+	for _, origin := range allowedOrigins {
+		origin = strings.ToLower(origin)
+		if origin == "*" {
+			m.all = true
+			continue
+		}
+
+		if prefix, suffix, ok := strings.Cut(origin, "*"); ok {
+			m.wildcards = append(m.wildcards, originWildcard{prefix: prefix, suffix: suffix})
+			continue
+		}
+		m.exact[origin] = struct{}{}
+	}
+	return m
+}
+
+// AllowsAll tells whether any origin matches.
+func (m *OriginMatcher) AllowsAll() bool {
+	return m.all
+}
+
+func (m *OriginMatcher) Match(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if m.all {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+	if _, ok := m.exact[origin]; ok {
+		return true
+	}
+
+	for _, w := range m.wildcards {
+		if len(origin) < len(w.prefix)+len(w.suffix) ||
+			!strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+			continue
+		}
+		if !strings.Contains(origin[len(w.prefix):len(origin)-len(w.suffix)], "/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type cspNonceCtxKey struct{}
+
+type SecureHeadersOptions struct {
+	// HSTSMaxAge sends Strict-Transport-Security when positive. Browsers ignore it over plain HTTP.
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	// FrameOptions is the X-Frame-Options value, DENY or SAMEORIGIN
+	FrameOptions      string
+	ReferrerPolicy    string
+	PermissionsPolicy string
+	// ContentSecurityPolicy is not sent when nil
+	ContentSecurityPolicy *ContentSecurityPolicy
+	// CSPReportOnly sends the policy as Content-Security-Policy-Report-Only, to try it out without breaking pages
+	CSPReportOnly bool
+}
+
+// SecureHeaders sets the security response headers, empty options are left out. X-Content-Type-Options is
+// always nosniff. When the policy uses a nonce, a new one is made for every request, see CSPNonce.
+func SecureHeaders(opts SecureHeadersOptions) func(next http.Handler) http.Handler {
+	static := http.Header{}
+	static.Set("X-Content-Type-Options", "nosniff")
+	if opts.HSTSMaxAge > 0 {
+		static.Set("Strict-Transport-Security", hstsValue(opts))
+	}
+	if opts.FrameOptions != "" {
+		static.Set("X-Frame-Options", opts.FrameOptions)
+	}
+	if opts.ReferrerPolicy != "" {
+		static.Set("Referrer-Policy", opts.ReferrerPolicy)
+	}
+	if opts.PermissionsPolicy != "" {
+		static.Set("Permissions-Policy", opts.PermissionsPolicy)
+	}
+
+	cspHeader := "Content-Security-Policy"
+	if opts.CSPReportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+	policy := opts.ContentSecurityPolicy
+	var staticPolicy string
+	if policy != nil && !policy.UsesNonce() {
+		staticPolicy = policy.Build("")
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			for key := range static {
+				header.Set(key, static.Get(key))
+			}
+
+			switch {
+			case policy == nil:
+			case staticPolicy != "":
+				header.Set(cspHeader, staticPolicy)
+			default:
+				nonce := newNonce()
+				header.Set(cspHeader, policy.Build(nonce))
+				r = r.WithContext(context.WithValue(r.Context(), cspNonceCtxKey{}, nonce))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CSPNonce returns the nonce of the request set by SecureHeaders, to be added as the nonce attribute of
+// inline scripts and styles. It is empty when the policy uses no nonce.
+func CSPNonce(ctx context.Context) string {
+	nonce, _ := ctx.Value(cspNonceCtxKey{}).(string)
+	return nonce
+}
+
+func hstsValue(opts SecureHeadersOptions) string {
+	value := fmt.Sprintf("max-age=%d", int64(opts.HSTSMaxAge.Seconds()))
+	if opts.HSTSIncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if opts.HSTSPreload {
+		value += "; preload"
+	}
+	return value
+}
+
+func newNonce() string {
+	b := make([]byte, 16)
+	// crypto/rand.Read never returns an error
+	_, _ = rand.Read(b)
+	return base64.StdEncoding.EncodeToString(b)
+}
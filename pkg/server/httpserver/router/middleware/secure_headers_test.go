@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SecureHeadersSuite struct {
+	suite.Suite
+}
+
+// serve returns the response headers of SecureHeaders(opts) and the nonce seen by the handler.
+func (s *SecureHeadersSuite) serve(opts SecureHeadersOptions) (http.Header, string) {
+	var nonce string
+	handler := SecureHeaders(opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nonce = CSPNonce(r.Context())
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rr.Header(), nonce
+}
+
+func (s *SecureHeadersSuite) TestHeaders() {
+	header, nonce := s.serve(SecureHeadersOptions{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		HSTSIncludeSubdomains: true,
+		FrameOptions:          "DENY",
+		ReferrerPolicy:        "no-referrer",
+		PermissionsPolicy:     "camera=()",
+		ContentSecurityPolicy: NewContentSecurityPolicy().Directive("default-src", "'none'").Directive("frame-ancestors", "'none'"),
+	})
+
+	s.Equal("max-age=31536000; includeSubDomains", header.Get("Strict-Transport-Security"))
+	s.Equal("nosniff", header.Get("X-Content-Type-Options"))
+	s.Equal("DENY", header.Get("X-Frame-Options"))
+	s.Equal("no-referrer", header.Get("Referrer-Policy"))
+	s.Equal("camera=()", header.Get("Permissions-Policy"))
+	s.Equal("default-src 'none'; frame-ancestors 'none'", header.Get("Content-Security-Policy"))
+	s.Empty(nonce)
+}
+
+func (s *SecureHeadersSuite) TestEmptyOptions() {
+	header, _ := s.serve(SecureHeadersOptions{})
+
+	s.Equal("nosniff", header.Get("X-Content-Type-Options"))
+	s.Empty(header.Get("Strict-Transport-Security"))
+	s.Empty(header.Get("X-Frame-Options"))
+	s.Empty(header.Get("Content-Security-Policy"))
+}
+
+func (s *SecureHeadersSuite) TestNonce() {
+	opts := SecureHeadersOptions{
+		ContentSecurityPolicy: NewContentSecurityPolicy().
+			Directive("script-src", "'self'").
+			Nonce("script-src", "style-src"),
+		CSPReportOnly: true,
+	}
+
+	header, nonce := s.serve(opts)
+	s.NotEmpty(nonce)
+	s.Empty(header.Get("Content-Security-Policy"))
+	s.Equal(
+		"script-src 'self' 'nonce-"+nonce+"'; style-src 'nonce-"+nonce+"'",
+		header.Get("Content-Security-Policy-Report-Only"),
+	)
+
+	_, otherNonce := s.serve(opts)
+	s.NotEqual(nonce, otherNonce)
+	s.False(strings.ContainsAny(nonce, "\"'; "))
+}
+
+func TestSecureHeadersSuite(t *testing.T) {
+	suite.Run(t, new(SecureHeadersSuite))
+}
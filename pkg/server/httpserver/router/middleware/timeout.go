@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"sync"
 	"time"
-
-	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 )
 
+type timeoutCtxKey struct{}
+
+// Timeout gives the request context a deadline t from now and answers 504 once the handler returns past it,
+// unless the handler already wrote its own status. Handlers streaming long responses can move the deadline
+// with ResetTimeout, or lift it with StopTimeout.
 func Timeout(t time.Duration) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -15,7 +20,146 @@ func Timeout(t time.Duration) func(next http.Handler) http.Handler {
 				return
 			}
 
-			chiMiddleware.Timeout(t)(next).ServeHTTP(w, r)
+			ctx := newTimeoutContext(r.Context(), t)
+			tw := &timeoutWriter{ResponseWriter: w}
+			defer func() {
+				ctx.cancel(context.Canceled)
+				if ctx.timedOut() && !tw.wroteHeader {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
 		})
 	}
 }
+
+// StopTimeout lifts the request deadline set by Timeout. The context is still cancelled when the client
+// goes away. It reports false when there is no deadline or it already passed.
+func StopTimeout(ctx context.Context) bool {
+	tc, ok := ctx.Value(timeoutCtxKey{}).(*timeoutContext)
+	return ok && tc.reset(0)
+}
+
+// ResetTimeout moves the request deadline set by Timeout to t from now. It reports false when there is no
+// deadline or it already passed.
+func ResetTimeout(ctx context.Context, t time.Duration) bool {
+	tc, ok := ctx.Value(timeoutCtxKey{}).(*timeoutContext)
+	return ok && tc.reset(t)
+}
+
+// timeoutContext is a context whose deadline can still be moved once it is handed to the handler, which
+// context.WithDeadline does not allow. It fails with context.DeadlineExceeded, like the standard one, so that
+// the database driver, the gRPC clients and errutil see a timeout.
+type timeoutContext struct {
+	context.Context
+	done chan struct{}
+
+	mu       sync.Mutex
+	deadline time.Time // zero once the timeout is stopped
+	timer    *time.Timer
+	err      error
+	stop     func() bool
+}
+
+var _ context.Context = (*timeoutContext)(nil)
+
+func newTimeoutContext(parent context.Context, t time.Duration) *timeoutContext {
+	c := &timeoutContext{Context: parent, done: make(chan struct{})}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deadline = time.Now().Add(t)
+	if parentDeadline, ok := parent.Deadline(); ok && parentDeadline.Before(c.deadline) {
+		c.deadline = parentDeadline
+	}
+	c.timer = time.AfterFunc(time.Until(c.deadline), func() { c.cancel(context.DeadlineExceeded) })
+	c.stop = context.AfterFunc(parent, func() { c.cancel(parent.Err()) })
+	return c
+}
+
+func (c *timeoutContext) Deadline() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.deadline.IsZero() {
+		return c.Context.Deadline()
+	}
+	return c.deadline, true
+}
+
+func (c *timeoutContext) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *timeoutContext) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+func (c *timeoutContext) Value(key any) any {
+	if key == (timeoutCtxKey{}) {
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+func (c *timeoutContext) cancel(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	c.timer.Stop()
+	c.stop()
+	close(c.done)
+}
+
+// reset moves the deadline to t from now, or removes it when t is 0. A stopped timeout can be set again.
+func (c *timeoutContext) reset(t time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return false
+	}
+	// the timer fired, cancel is waiting for the lock
+	if !c.deadline.IsZero() && !c.timer.Stop() {
+		return false
+	}
+
+	if t == 0 {
+		c.deadline = time.Time{}
+		return true
+	}
+	c.deadline = time.Now().Add(t)
+	c.timer.Reset(t)
+	return true
+}
+
+func (c *timeoutContext) timedOut() bool {
+	return c.Err() == context.DeadlineExceeded
+}
+
+// timeoutWriter tells whether the handler wrote its status, the 504 would otherwise be superfluous.
+type timeoutWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the flusher and the deadlines of the connection.
+func (w *timeoutWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
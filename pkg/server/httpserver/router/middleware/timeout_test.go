@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TimeoutSuite struct {
+	suite.Suite
+}
+
+// serve runs handler behind Timeout(timeout) and returns the response.
+func (s *TimeoutSuite) serve(timeout time.Duration, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	Timeout(timeout)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+	return recorder
+}
+
+func (s *TimeoutSuite) TestDeadline() {
+	var ctxErr error
+	start := time.Now()
+	recorder := s.serve(20*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		s.True(ok)
+		s.WithinDuration(start.Add(20*time.Millisecond), deadline, 10*time.Millisecond)
+
+		<-r.Context().Done()
+		ctxErr = r.Context().Err()
+	})
+
+	s.True(errors.Is(ctxErr, context.DeadlineExceeded))
+	s.Equal(http.StatusGatewayTimeout, recorder.Code)
+}
+
+func (s *TimeoutSuite) TestDerivedContext() {
+	s.serve(20*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		// contexts derived by the handlers, e.g. by the database driver, inherit the deadline
+		ctx, cancel := context.WithTimeout(r.Context(), time.Hour)
+		defer cancel()
+
+		<-ctx.Done()
+		s.True(errors.Is(ctx.Err(), context.DeadlineExceeded))
+	})
+}
+
+func (s *TimeoutSuite) TestHandlerStatusKept() {
+	recorder := s.serve(10*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		<-r.Context().Done()
+	})
+
+	s.Equal(http.StatusServiceUnavailable, recorder.Code)
+}
+
+func (s *TimeoutSuite) TestStopTimeout() {
+	var ctxErr error
+	recorder := s.serve(10*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		s.True(StopTimeout(r.Context()))
+		_, ok := r.Context().Deadline()
+		s.False(ok)
+
+		time.Sleep(30 * time.Millisecond)
+		ctxErr = r.Context().Err()
+	})
+
+	s.NoError(ctxErr)
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func (s *TimeoutSuite) TestResetTimeout() {
+	var ctxErr error
+	start := time.Now()
+	recorder := s.serve(10*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		s.True(ResetTimeout(r.Context(), 50*time.Millisecond))
+		deadline, ok := r.Context().Deadline()
+		s.True(ok)
+		s.WithinDuration(start.Add(50*time.Millisecond), deadline, 10*time.Millisecond)
+
+		time.Sleep(30 * time.Millisecond)
+		s.NoError(r.Context().Err())
+
+		<-r.Context().Done()
+		ctxErr = r.Context().Err()
+	})
+
+	s.True(errors.Is(ctxErr, context.DeadlineExceeded))
+	s.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+	s.Equal(http.StatusGatewayTimeout, recorder.Code)
+}
+
+func (s *TimeoutSuite) TestResetAfterDeadline() {
+	s.serve(10*time.Millisecond, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		s.False(ResetTimeout(r.Context(), time.Second))
+		s.False(StopTimeout(r.Context()))
+	})
+
+	s.False(StopTimeout(context.Background()))
+}
+
+func (s *TimeoutSuite) TestClientGone() {
+	ctx, cancel := context.WithCancel(context.Background())
+	var ctxErr error
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		<-r.Context().Done()
+		ctxErr = r.Context().Err()
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx))
+
+	s.True(errors.Is(ctxErr, context.Canceled))
+	s.Equal(http.StatusOK, recorder.Code)
+}
+
+func TestTimeoutSuite(t *testing.T) {
+	suite.Run(t, new(TimeoutSuite))
+}
@@ -1,32 +1,38 @@
 package router
 
 import (
+	"net/http"
+	"net/netip"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/httprate"
 	"github.com/umefy/go-web-app-template/pkg/server/httpserver/router/middleware"
 	"github.com/umefy/godash/logger"
 )
 
 var (
-	allowedContentTypes = [...]string{"application/json"}
+	allowedContentTypes = [...]string{"application/json", "text/csv", "multipart/form-data"}
 )
 
 type Router = chi.Router
 type Mux = chi.Mux
 
-func NewRootRouter(logger *logger.Logger) *Mux {
+// NewRootRouter bounds every request to requestTimeout and maxBodySize bytes of body, routes can change both,
+// see middleware.ResetTimeout and middleware.SetMaxBodySize. Forwarding headers are only trusted from
+// trustedProxies, see middleware.ClientIP. tracing starts the span of the request, e.g. middleware.OTelTracing,
+// it runs before the logger so that the request logs carry the trace id.
+func NewRootRouter(logger *logger.Logger, tracing func(next http.Handler) http.Handler, requestTimeout time.Duration, maxBodySize int64, trustedProxies []netip.Prefix) *Mux {
 	r := chi.NewRouter()
+	r.Use(middleware.ClientIP(trustedProxies))
 	r.Use(middleware.RequestID)
+	r.Use(tracing)
 	r.Use(middleware.Logger(logger))
 	r.Use(middleware.Recover(logger))
-	r.Use(middleware.Timeout(time.Second * 60))
+	r.Use(middleware.Timeout(requestTimeout))
+	r.Use(middleware.MaxBodySize(maxBodySize))
 
 	r.Use(chiMiddleware.AllowContentType(allowedContentTypes[:]...))
-	r.Use(httprate.LimitAll(600, time.Minute))
-	r.Use(httprate.LimitByIP(100, time.Minute))
 
 	return r
 }
@@ -0,0 +1,204 @@
+package tlsreload
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/umefy/go-web-app-template/pkg/validation"
+	"github.com/umefy/godash/logger"
+)
+
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile turns on mutual TLS, client certificates are required and verified against it
+	ClientCAFile string
+	// Interval is how often the files are checked for changes
+	Interval time.Duration
+}
+
+func (c Config) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.CertFile, validation.Required),
+		validation.Field(&c.KeyFile, validation.Required),
+		validation.Field(&c.Interval, validation.Required),
+	)
+}
+
+// Reloader serves TLS with the certificate (and client CA) currently on disk. The files are polled, so that
+// rotated certificates are picked up without a restart, including the symlink swaps of Kubernetes secrets,
+// and reloaded on SIGHUP. A rotation that fails to load is logged and the previous certificate is kept.
+type Reloader struct {
+	config  Config
+	base    *tls.Config
+	logger  *slog.Logger
+	current atomic.Pointer[tls.Config]
+	mu      sync.Mutex
+	version string
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// New loads the files once, failing if they can't be used. base holds the settings that don't come from the
+// files (minimum version, ALPN protocols, ...), it is not modified.
+func New(config Config, base *tls.Config, logger *logger.Logger) (*Reloader, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	loggerHandler := logger.GetHandler()
+	loggerHandler.CallerSkip = 3
+	slogger := slog.New(&loggerHandler)
+
+	r := &Reloader{
+		config: config,
+		base:   base,
+		logger: slogger,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	version, err := r.filesVersion()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.version = version
+
+	return r, nil
+}
+
+// TLSConfig returns the config to serve with, every handshake uses the latest loaded files.
+func (r *Reloader) TLSConfig() *tls.Config {
+	config := r.base.Clone()
+	config.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		return r.current.Load(), nil
+	}
+	return config
+}
+
+// Start polls the files and listens for SIGHUP until Stop is called.
+func (r *Reloader) Start() {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		defer close(r.done)
+		defer signal.Stop(hangup)
+
+		ticker := time.NewTicker(r.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.reloadIfChanged()
+			case <-hangup:
+				if err := r.Reload(); err != nil {
+					r.logger.Error("Failed to reload TLS files, keeping the previous ones", slog.String("error", err.Error()))
+				}
+			}
+		}
+	}()
+}
+
+func (r *Reloader) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+// Reload loads the files now, whether they changed or not. The previous files are kept on error.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, err := r.filesVersion()
+	if err != nil {
+		return err
+	}
+	if err := r.load(); err != nil {
+		return err
+	}
+	r.version = version
+
+	r.logger.Info("Reloaded TLS files", slog.String("cert_file", r.config.CertFile))
+	return nil
+}
+
+func (r *Reloader) reloadIfChanged() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	version, err := r.filesVersion()
+	if err != nil {
+		r.logger.Error("Failed to check TLS files", slog.String("error", err.Error()))
+		return
+	}
+	if version == r.version {
+		return
+	}
+	// remember the version even if loading fails, files caught half written change again once complete
+	r.version = version
+
+	if err := r.load(); err != nil {
+		r.logger.Error("Failed to reload TLS files, keeping the previous ones", slog.String("error", err.Error()))
+		return
+	}
+	r.logger.Info("Reloaded TLS files", slog.String("cert_file", r.config.CertFile))
+}
+
+func (r *Reloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	config := r.base.Clone()
+	config.Certificates = []tls.Certificate{cert}
+
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return errors.New("client CA file has no certificate")
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.current.Store(config)
+	return nil
+}
+
+// filesVersion changes whenever one of the files is replaced or rewritten.
+func (r *Reloader) filesVersion() (string, error) {
+	var version strings.Builder
+	for _, file := range []string{r.config.CertFile, r.config.KeyFile, r.config.ClientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&version, "%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+	}
+	return version.String(), nil
+}
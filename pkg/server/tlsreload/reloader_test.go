@@ -0,0 +1,106 @@
+package tlsreload
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/umefy/godash/logger"
+)
+
+type ReloaderSuite struct {
+	suite.Suite
+	config Config
+}
+
+func (s *ReloaderSuite) SetupTest() {
+	dir := s.T().TempDir()
+	s.config = Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		Interval: time.Hour,
+	}
+}
+
+func (s *ReloaderSuite) newReloader() *Reloader {
+	l := logger.New(logger.NewLoggerOps(false, io.Discard, slog.LevelInfo, false, "source", 0), nil)
+	r, err := New(s.config, &tls.Config{MinVersion: tls.VersionTLS12}, l)
+	s.Require().NoError(err)
+	return r
+}
+
+// writeCert writes a self-signed key pair for commonName and bumps the modification time,
+// so that two writes within the clock resolution still look different.
+func (s *ReloaderSuite) writeCert(commonName string, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	s.Require().NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	s.Require().NoError(err)
+
+	s.Require().NoError(os.WriteFile(s.config.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	s.Require().NoError(os.WriteFile(s.config.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	s.Require().NoError(os.Chtimes(s.config.CertFile, modTime, modTime))
+	s.Require().NoError(os.Chtimes(s.config.KeyFile, modTime, modTime))
+}
+
+func (s *ReloaderSuite) servedCommonName(r *Reloader) string {
+	config, err := r.TLSConfig().GetConfigForClient(&tls.ClientHelloInfo{})
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(config.Certificates[0].Certificate[0])
+	s.Require().NoError(err)
+	return cert.Subject.CommonName
+}
+
+func (s *ReloaderSuite) TestReloadOnChange() {
+	now := time.Now()
+	s.writeCert("first", now)
+	r := s.newReloader()
+	s.Equal("first", s.servedCommonName(r))
+
+	s.writeCert("second", now.Add(time.Second))
+	r.reloadIfChanged()
+
+	s.Equal("second", s.servedCommonName(r))
+}
+
+func (s *ReloaderSuite) TestKeepPreviousOnInvalidFiles() {
+	now := time.Now()
+	s.writeCert("first", now)
+	r := s.newReloader()
+
+	s.Require().NoError(os.WriteFile(s.config.KeyFile, []byte("not a key"), 0o600))
+	r.reloadIfChanged()
+
+	s.Equal("first", s.servedCommonName(r))
+}
+
+func (s *ReloaderSuite) TestMissingFiles() {
+	l := logger.New(logger.NewLoggerOps(false, io.Discard, slog.LevelInfo, false, "source", 0), nil)
+	_, err := New(s.config, &tls.Config{}, l)
+	s.Error(err)
+}
+
+func TestReloaderSuite(t *testing.T) {
+	suite.Run(t, new(ReloaderSuite))
+}
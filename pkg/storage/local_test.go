@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalStorageSuite struct {
+	suite.Suite
+	storage *LocalStorage
+}
+
+func (s *LocalStorageSuite) SetupTest() {
+	storage, err := NewLocalStorage(s.T().TempDir())
+	s.Require().NoError(err)
+	s.storage = storage
+}
+
+func (s *LocalStorageSuite) TestPutGetDelete() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.storage.Put(ctx, "exports/1/1.json", strings.NewReader(`{"id":1}`)))
+
+	r, err := s.storage.Get(ctx, "exports/1/1.json")
+	s.Require().NoError(err)
+	content, err := io.ReadAll(r)
+	s.Require().NoError(err)
+	s.Require().NoError(r.Close())
+	s.Equal(`{"id":1}`, string(content))
+
+	s.Require().NoError(s.storage.Delete(ctx, "exports/1/1.json"))
+	_, err = s.storage.Get(ctx, "exports/1/1.json")
+	s.ErrorIs(err, ErrObjectNotFound)
+
+	// deleting a missing object is not an error
+	s.NoError(s.storage.Delete(ctx, "exports/1/1.json"))
+}
+
+func (s *LocalStorageSuite) TestGetFailure() {
+	ctx := context.Background()
+
+	s.Require().NoError(s.storage.Put(ctx, "exports/1/1.json", strings.NewReader(`{"id":1}`)))
+
+	// a file is in the way of the key, which is an error but not a missing object
+	r, err := s.storage.Get(ctx, "exports/1/1.json/2.json")
+	s.Error(err)
+	s.NotErrorIs(err, ErrObjectNotFound)
+	// compared to nil itself, an interface holding a nil *os.File would pass s.Nil
+	s.True(r == nil)
+}
+
+func (s *LocalStorageSuite) TestRejectsKeysOutsideBaseDir() {
+	ctx := context.Background()
+
+	for _, key := range []string{"../escape.json", "/etc/passwd", "a/../../escape.json", ""} {
+		s.Error(s.storage.Put(ctx, key, strings.NewReader("x")), key)
+	}
+}
+
+func TestLocalStorageSuite(t *testing.T) {
+	suite.Run(t, new(LocalStorageSuite))
+}
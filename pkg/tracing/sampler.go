@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RateLimitedSampler records at most perSecond traces per second, with bursts of up to one second worth of
+// traces. Unlike a ratio, the cost of tracing stays bounded when the traffic spikes.
+type RateLimitedSampler struct {
+	perSecond float64
+
+	mu     sync.Mutex
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+var _ sdktrace.Sampler = (*RateLimitedSampler)(nil)
+
+func RateLimited(perSecond float64) *RateLimitedSampler {
+	burst := math.Max(1, perSecond)
+	return &RateLimitedSampler{
+		perSecond: perSecond,
+		burst:     burst,
+		tokens:    burst,
+		now:       time.Now,
+	}
+}
+
+func (s *RateLimitedSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if s.take() {
+		decision = sdktrace.RecordAndSample
+	}
+
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (s *RateLimitedSampler) Description() string {
+	return fmt.Sprintf("RateLimitedSampler{%g}", s.perSecond)
+}
+
+func (s *RateLimitedSampler) take() bool {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.last.IsZero() {
+		s.tokens = math.Min(s.burst, s.tokens+now.Sub(s.last).Seconds()*s.perSecond)
+	}
+	s.last = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type RateLimitedSuite struct {
+	suite.Suite
+	now      time.Time
+	recorder *tracetest.SpanRecorder
+	tracer   trace.Tracer
+}
+
+func (s *RateLimitedSuite) SetupTest() {
+	s.now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	sampler := RateLimited(2)
+	sampler.now = func() time.Time { return s.now }
+
+	s.recorder = tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sampler)),
+		sdktrace.WithSpanProcessor(s.recorder),
+	)
+	s.tracer = provider.Tracer("test")
+}
+
+// startTraces starts n root spans, each with a child, and returns how many of them were sampled.
+func (s *RateLimitedSuite) startTraces(n int) int {
+	sampled := 0
+	for range n {
+		ctx, root := s.tracer.Start(context.Background(), "root")
+		_, child := s.tracer.Start(ctx, "child")
+		s.Equal(root.SpanContext().IsSampled(), child.SpanContext().IsSampled())
+		if root.SpanContext().IsSampled() {
+			sampled++
+		}
+		child.End()
+		root.End()
+	}
+	return sampled
+}
+
+func (s *RateLimitedSuite) TestLimit() {
+	s.Equal(2, s.startTraces(5))
+	// children follow their root, they do not count against the limit
+	s.Len(s.recorder.Ended(), 4)
+
+	s.now = s.now.Add(500 * time.Millisecond)
+	s.Equal(1, s.startTraces(5))
+
+	// the burst is capped to one second worth of traces
+	s.now = s.now.Add(time.Minute)
+	s.Equal(2, s.startTraces(5))
+	s.Len(s.recorder.Ended(), 10)
+}
+
+func (s *RateLimitedSuite) TestBelowOnePerSecond() {
+	sampler := RateLimited(0.5)
+	sampler.now = func() time.Time { return s.now }
+
+	result := func() sdktrace.SamplingDecision {
+		return sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: context.Background()}).Decision
+	}
+
+	s.Equal(sdktrace.RecordAndSample, result())
+	s.Equal(sdktrace.Drop, result())
+
+	s.now = s.now.Add(time.Second)
+	s.Equal(sdktrace.Drop, result())
+
+	s.now = s.now.Add(time.Second)
+	s.Equal(sdktrace.RecordAndSample, result())
+}
+
+func TestRateLimitedSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitedSuite))
+}
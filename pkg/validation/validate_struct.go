@@ -20,6 +20,15 @@ func ValidateStruct(v interface{}, fields ...*val.FieldRules) error {
 	return err
 }
 
+// NewValidateStructError reports errs, keyed by field name, the same way ValidateStruct does.
+// It returns nil when errs is empty.
+func NewValidateStructError(errs Errors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidateStructError{Errors: errs}
+}
+
 type ValidateStructError struct {
 	Errors val.Errors
 }
@@ -12,6 +12,11 @@ var (
 	ValidateByRules     = val.Validate
 )
 
+// Re-export error types
+type Errors = val.Errors
+
+var NewError = val.NewError
+
 // Re-export field validation helpers
 var (
 	Field       = val.Field
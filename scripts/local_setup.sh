@@ -47,6 +47,8 @@ check_and_install "lefthook" "brew install lefthook"
 check_and_install "protoc" "brew install protobuf"
 check_and_install "protoc-gen-go" "go install google.golang.org/protobuf/cmd/protoc-gen-go@latest"
 check_and_install "protoc-gen-go-grpc" "go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest"
+check_and_install "protoc-gen-grpc-gateway" "go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-grpc-gateway@latest"
+check_and_install "protoc-gen-openapiv2" "go install github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2@latest"
 check_and_install "mockery" "go install github.com/vektra/mockery/v3@latest"
 check_and_install "goose" "go install github.com/pressly/goose/v3/cmd/goose@latest"
 check_and_install "goimports" "go install golang.org/x/tools/cmd/goimports@latest"
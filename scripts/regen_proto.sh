@@ -13,18 +13,31 @@ generate_proto() {
     rm -rf $output_dir
     mkdir -p $output_dir
 
+    # Files with google.api.http options also get a REST gateway and the OpenAPI document
+    local gateway_files
+    gateway_files=$(grep -l "google.api.http" $proto_files)
+
     # Generate files
     {
-        protoc -I $proto_dir \
+        protoc -I $proto_dir -I $THIRD_PARTY_PROTO_DIR \
             --go_out=$output_dir --go_opt=paths=source_relative \
             --go-grpc_out=$output_dir --go-grpc_opt=paths=source_relative \
             $proto_files
+
+        protoc -I $proto_dir -I $THIRD_PARTY_PROTO_DIR \
+            --grpc-gateway_out=$output_dir --grpc-gateway_opt=paths=source_relative \
+            --openapiv2_out=$OPENAPI_OUT_DIR \
+            --openapiv2_opt=allow_merge=true,merge_file_name=api_v2,output_format=yaml,json_names_for_fields=true \
+            $gateway_files
     } 2>&1 | grep -v "warning: Import google/protobuf/.* is unused" || true
 }
 
 # GRPC directories
 GRPC_PROTO_DIR=$BASE_PATH/proto/grpc
 GRPC_GO_OUT_DIR=$BASE_PATH/protogen/grpc
+# google/api annotations, copied from googleapis
+THIRD_PARTY_PROTO_DIR=$BASE_PATH/proto/third_party
+OPENAPI_OUT_DIR=$BASE_PATH/openapi/docs
 
 # Set protoc flags
 export PROTOC_FLAGS="--allow_unused_imports"